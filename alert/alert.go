@@ -0,0 +1,34 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Names of the channels alerts are delivered to, stored with every delivery
+const (
+	MQTT    = "mqtt"
+	Webhook = "webhook"
+	Mail    = "mail"
+)
+
+// Alert reports an article whose stock fell below its reorder threshold,
+// it is the JSON body published on MQTT and posted to the webhooks
+type Alert struct {
+	Uuid             uuid.UUID `json:"uuid"`
+	ArticleUuid      uuid.UUID `json:"article_uuid"`
+	Article          string    `json:"article"`
+	Stock            int32     `json:"stock"`
+	ReorderThreshold int32     `json:"reorder_threshold"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Channel delivers alerts to one kind of receiver. Send may be called again
+// for the same alert after a failure, receivers can tell repeated alerts
+// apart by their uuid.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, alert Alert) error
+}
@@ -0,0 +1,50 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// MailConfig is the SMTP server alerts are sent with and the addresses of
+// the purchasing team
+type MailConfig struct {
+	Host     string
+	Port     string
+	Username string // optional, without it the server is used without authentication
+	Password string
+	From     string
+	To       []string
+}
+
+// mailChannel mails alerts to the purchasing team
+type mailChannel struct {
+	config MailConfig
+}
+
+func NewMailChannel(config MailConfig) Channel {
+	return mailChannel{config}
+}
+
+func (mailChannel) Name() string {
+	return Mail
+}
+
+func (c mailChannel) Send(ctx context.Context, alert Alert) error {
+	var auth smtp.Auth
+	if c.config.Username != "" {
+		auth = smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", c.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(c.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: Low stock: %s\r\n", alert.Article)
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "The stock of %s fell to %d units, below its reorder threshold of %d.\r\n", alert.Article, alert.Stock, alert.ReorderThreshold)
+	fmt.Fprintf(&msg, "\r\nArticle: %s\r\nAlert: %s\r\nTime: %s\r\n", alert.ArticleUuid, alert.Uuid, alert.CreatedAt.Format("2006-01-02 15:04"))
+
+	return smtp.SendMail(net.JoinHostPort(c.config.Host, c.config.Port), auth, c.config.From, c.config.To, []byte(msg.String()))
+}
@@ -0,0 +1,30 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+// mqttChannel publishes alerts as JSON on a topic of the broker
+type mqttChannel struct {
+	client *util.Client
+	topic  string
+}
+
+func NewMQTTChannel(client *util.Client, topic string) Channel {
+	return mqttChannel{client, topic}
+}
+
+func (mqttChannel) Name() string {
+	return MQTT
+}
+
+func (c mqttChannel) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	return c.client.Publish(string(body), c.topic)
+}
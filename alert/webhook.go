@@ -0,0 +1,86 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the body for webhooks with a secret
+const SignatureHeader = "X-Rupay-Signature"
+
+// webhookChannel posts alerts as JSON to every registered webhook
+type webhookChannel struct {
+	q      *db.Queries
+	client *http.Client
+}
+
+func NewWebhookChannel(q *db.Queries) Channel {
+	return webhookChannel{q, &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (webhookChannel) Name() string {
+	return Webhook
+}
+
+// Send posts the alert to all webhooks that did not receive it yet, it fails
+// if any of them did not answer with a 2xx status. Every delivery is
+// recorded, a retry only posts to the webhooks that failed.
+func (c webhookChannel) Send(ctx context.Context, alert Alert) error {
+	webhooks, err := c.q.GetUndeliveredWebhooks(ctx, alert.Uuid)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if err := c.post(ctx, webhook, body); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", webhook.Url, err))
+			continue
+		}
+
+		err := c.q.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{AlertUuid: alert.Uuid, WebhookUuid: webhook.Uuid})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: failed to record the delivery: %w", webhook.Url, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c webhookChannel) post(ctx context.Context, webhook db.Webhook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if webhook.Secret.Valid {
+		mac := hmac.New(sha256.New, []byte(webhook.Secret.String))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -3,27 +3,30 @@ package controllers
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"net/http"
+	"time"
 
 	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
 	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
 	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
 )
 
 type ArticleController struct {
-	db  *db.Queries
+	db  *db.Store
 	ctx context.Context
 }
 
-func NewArticleController(db *db.Queries, ctx context.Context) *ArticleController {
+func NewArticleController(db *db.Store, ctx context.Context) *ArticleController {
 	return &ArticleController{db, ctx}
 }
 
 // CreateArticle godoc
 // @Summary Create a new article
-// @Description Create a new article with the provided payload
+// @Description Create a new article with the provided payload, its prices start the price history
 // @Tags Articles
 // @Accept json
 // @Produce json
@@ -40,15 +43,34 @@ func (cc *ArticleController) CreateArticle(ctx *gin.Context) {
 		return
 	}
 
+	if err := validVatRate(payload.VatRate); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if payload.ReorderThreshold.Valid && payload.ReorderThreshold.Int32 < 0 {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "reorder_threshold can not be negative"})
+		return
+	}
+
+	stockPolicy := payload.StockPolicy
+	if stockPolicy == "" {
+		stockPolicy = db.StockPolicyAllow
+	}
+
 	args := &db.CreateArticleParams{
-		Name:            payload.Name,
-		Desc:            payload.Desc,
-		PurchasePrice:   payload.PurchasePrice,
-		ResellPrice:     payload.ResellPrice,
-		ArticleTypeUuid: payload.ArticleTypeUuid,
+		Name:             payload.Name,
+		Desc:             payload.Desc,
+		PurchasePrice:    payload.PurchasePrice,
+		ResellPrice:      payload.ResellPrice,
+		ArticleTypeUuid:  payload.ArticleTypeUuid,
+		Deposit:          payload.Deposit,
+		VatRate:          payload.VatRate,
+		StockPolicy:      stockPolicy,
+		ReorderThreshold: payload.ReorderThreshold,
 	}
 
-	article, err := cc.db.CreateArticle(ctx, *args)
+	article, err := cc.db.CreateArticleTx(ctx, *args, time.Now())
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create Article", Error: err.Error()})
 		return
@@ -59,7 +81,7 @@ func (cc *ArticleController) CreateArticle(ctx *gin.Context) {
 
 // UpdateArticle godoc
 // @Summary Update an existing article
-// @Description Update an article with the provided ID and payload
+// @Description Update an article with the provided ID and payload. Changed prices take effect right away and are recorded in the price history.
 // @Tags Articles
 // @Accept json
 // @Produce json
@@ -79,16 +101,35 @@ func (cc *ArticleController) UpdateArticle(ctx *gin.Context) {
 		return
 	}
 
+	if err := validVatRate(payload.VatRate); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if payload.StockPolicy.Valid && !db.IsStockPolicy(payload.StockPolicy.String) {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "unknown stock policy " + payload.StockPolicy.String})
+		return
+	}
+
+	if payload.ReorderThreshold.Valid && payload.ReorderThreshold.Int32 < 0 {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "reorder_threshold can not be negative"})
+		return
+	}
+
 	args := &db.UpdateArticleParams{
-		Uuid:            uuid.MustParse(articleId),
-		Name:            payload.Name,
-		Desc:            payload.Desc,
-		PurchasePrice:   payload.PurchasePrice,
-		ResellPrice:     payload.ResellPrice,
-		ArticleTypeUuid: payload.ArticleTypeUuid,
+		Uuid:             uuid.MustParse(articleId),
+		Name:             payload.Name,
+		Desc:             payload.Desc,
+		PurchasePrice:    payload.PurchasePrice,
+		ResellPrice:      payload.ResellPrice,
+		ArticleTypeUuid:  payload.ArticleTypeUuid,
+		Deposit:          payload.Deposit,
+		VatRate:          payload.VatRate,
+		StockPolicy:      payload.StockPolicy,
+		ReorderThreshold: payload.ReorderThreshold,
 	}
 
-	article, err := cc.db.UpdateArticle(ctx, *args)
+	article, err := cc.db.UpdateArticleTx(ctx, *args, time.Now())
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
@@ -127,16 +168,131 @@ func (cc *ArticleController) GetArticleById(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, article)
 }
 
+// GetArticlePrices godoc
+// @Summary Get the price history of an article
+// @Description Retrieve all prices of an article with the time they are valid, including the scheduled ones
+// @Tags Articles
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Success 200 {array} db.ArticlePrice "Successfully retrieved the price history"
+// @Failure 404 {object} e.ErrorResponse "Article not found"
+// @Failure 500 {object} e.ErrorResponse "Failed to retrieve the price history"
+// @Router /article/{articleId}/price [get]
+func (cc *ArticleController) GetArticlePrices(ctx *gin.Context) {
+	articleId := uuid.MustParse(ctx.Param("articleId"))
+
+	if _, err := cc.db.GetArticleById(ctx, articleId); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
+		return
+	}
+
+	prices, err := cc.db.GetArticlePrices(ctx, articleId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the price history", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prices)
+}
+
+// ScheduleArticlePrice godoc
+// @Summary Schedule a price change of an article
+// @Description Change the resell price of an article from the given time on, for example new prices from the first of the month.
+// @Description A change scheduled for the same time is replaced. The purchase price follows the stock and can not be scheduled.
+// @Tags Articles
+// @Accept json
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Param payload body schemas.ScheduleArticlePrice true "ScheduleArticlePrice payload"
+// @Success 200 {object} db.ArticlePrice "Successfully scheduled the price"
+// @Failure 400 {object} e.ErrorResponse "Invalid payload"
+// @Failure 404 {object} e.ErrorResponse "Article not found"
+// @Failure 422 {object} e.ErrorResponse "Price change is in the past"
+// @Failure 500 {object} e.ErrorResponse "Failed to schedule the price"
+// @Router /article/{articleId}/price [post]
+func (cc *ArticleController) ScheduleArticlePrice(ctx *gin.Context) {
+	var payload *schemas.ScheduleArticlePrice
+	articleId := uuid.MustParse(ctx.Param("articleId"))
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	price, err := cc.db.ScheduleArticlePriceTx(ctx, db.ScheduleArticlePriceTxParams{
+		ArticleUuid: articleId,
+		ResellPrice: payload.ResellPrice,
+		ValidFrom:   payload.ValidFrom,
+		Now:         time.Now(),
+	})
+	if err != nil {
+		respondArticlePriceError(ctx, err, "Failed to schedule the price")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, price)
+}
+
+// UnscheduleArticlePrice godoc
+// @Summary Remove a scheduled price change
+// @Description Remove a price change that did not take effect yet, the price before it stays in effect
+// @Tags Articles
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Param priceId path string true "Price ID"
+// @Success 204 "Successfully removed the price change"
+// @Failure 404 {object} e.ErrorResponse "Article or price not found"
+// @Failure 409 {object} e.ErrorResponse "Price is already in effect"
+// @Failure 500 {object} e.ErrorResponse "Failed to remove the price change"
+// @Router /article/{articleId}/price/{priceId} [delete]
+func (cc *ArticleController) UnscheduleArticlePrice(ctx *gin.Context) {
+	articleId := uuid.MustParse(ctx.Param("articleId"))
+	priceId := uuid.MustParse(ctx.Param("priceId"))
+
+	if err := cc.db.UnscheduleArticlePriceTx(ctx, articleId, priceId, time.Now()); err != nil {
+		respondArticlePriceError(ctx, err, "Failed to remove the price change")
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func respondArticlePriceError(ctx *gin.Context, err error, message string) {
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article or price not found", Error: err.Error()})
+	case errors.Is(err, db.ErrPriceInPast):
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.PriceInPast, Message: "Price change is in the past", Error: err.Error()})
+	case errors.Is(err, db.ErrPriceInEffect):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PriceInEffect, Message: "Price is already in effect", Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: message, Error: err.Error()})
+	}
+}
+
 // GetAllArticles godoc
 // @Summary Retrieve all articles
-// @Description Get a list of all articles
+// @Description Get a list of all articles, archived ones only on request
 // @Tags Articles
 // @Produce json
+// @Param archived query bool false "Include archived articles"
 // @Success 200 {array} db.Article "Successfully retrieved all articles"
+// @Failure 400 {object} e.ErrorResponse "Invalid filter"
 // @Failure 500 {object} e.ErrorResponse "Failed to retrieve articles"
 // @Router /articles [get]
 func (cc *ArticleController) GetAllArticles(ctx *gin.Context) {
-	articles, err := cc.db.GetArticles(ctx)
+	var filter schemas.ArchivedFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	articles, err := cc.db.GetArticles(ctx, filter.Archived)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Articles", Error: err.Error()})
 		return
@@ -149,34 +305,227 @@ func (cc *ArticleController) GetAllArticles(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, articles)
 }
 
-// DeleteArticleById godoc
-// @Summary Delete an article by ID
-// @Description Remove an article using its ID
+// GetArticleComponents godoc
+// @Summary Retrieve the components of a bundle
+// @Description Get the component articles of a bundle with their amount per bundle, plain articles have none
 // @Tags Articles
 // @Produce json
 // @Param articleId path string true "Article ID"
-// @Success 204 "Successfully deleted article"
+// @Success 200 {array} db.ArticleComponent "Successfully retrieved the components"
+// @Failure 500 {object} e.ErrorResponse "Failed to retrieve components"
+// @Router /article/{articleId}/components [get]
+func (cc *ArticleController) GetArticleComponents(ctx *gin.Context) {
+	articleId := ctx.Param("articleId")
+
+	components, err := cc.db.GetArticleComponents(ctx, uuid.MustParse(articleId))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Components", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, components)
+}
+
+// SetArticleComponents godoc
+// @Summary Define an article as a bundle
+// @Description Replace the components of a bundle. The bundle is sold at its own resell price, every sale also counts the units of its components.
+// @Description The revenue of a bundle is split across its components in proportion to their resell price. Bundles can not be nested.
+// @Tags Articles
+// @Accept json
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Param payload body schemas.SetArticleComponents true "Components payload"
+// @Success 200 {array} db.ArticleComponent "Successfully set the components"
+// @Failure 400 {object} e.ErrorResponse "Invalid payload or nested bundle"
 // @Failure 404 {object} e.ErrorResponse "Article not found"
-// @Failure 500 {object} e.ErrorResponse "Failed to delete article"
+// @Router /article/{articleId}/components [put]
+func (cc *ArticleController) SetArticleComponents(ctx *gin.Context) {
+	var payload *schemas.SetArticleComponents
+	articleId := uuid.MustParse(ctx.Param("articleId"))
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	articleUuids := []uuid.UUID{articleId}
+	components := make([]db.ArticleComponentParams, 0, len(payload.Components))
+	for _, component := range payload.Components {
+		articleUuids = append(articleUuids, component.ArticleUuid)
+		components = append(components, db.ArticleComponentParams{ComponentUuid: component.ArticleUuid, Amount: component.Amount})
+	}
+
+	for _, articleUuid := range articleUuids {
+		if _, err := cc.db.GetArticleById(ctx, articleUuid); err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
+			return
+		}
+	}
+
+	result, err := cc.db.SetArticleComponentsTx(ctx, articleId, components)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidBundle) {
+			ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid bundle", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to set Components", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ArchiveArticleById godoc
+// @Summary Archive an article by ID
+// @Description Archive an article instead of deleting it. Archived articles are hidden from the menu and can not be sold,
+// @Description their sales stay in the reports and receipts.
+// @Tags Articles
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Success 204 "Successfully archived article"
+// @Failure 404 {object} e.ErrorResponse "Article not found"
+// @Failure 500 {object} e.ErrorResponse "Failed to archive article"
 // @Router /articles/{articleId} [delete]
-func (cc *ArticleController) DeleteArticleById(ctx *gin.Context) {
+func (cc *ArticleController) ArchiveArticleById(ctx *gin.Context) {
 	articleId := ctx.Param("articleId")
 
-	_, err := cc.db.GetArticleById(ctx, uuid.MustParse(articleId))
+	_, err := cc.db.ArchiveArticle(ctx, uuid.MustParse(articleId))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to archive Article", Error: err.Error()})
 		return
 	}
 
-	err = cc.db.DeleteArticle(ctx, uuid.MustParse(articleId))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// RestoreArticleById godoc
+// @Summary Restore an archived article
+// @Description Put an archived article back on the menu, it stays hidden while its article type is archived
+// @Tags Articles
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Success 200 {object} db.Article "Successfully restored article"
+// @Failure 404 {object} e.ErrorResponse "Article not found"
+// @Failure 500 {object} e.ErrorResponse "Failed to restore article"
+// @Router /article/{articleId}/restore [post]
+func (cc *ArticleController) RestoreArticleById(ctx *gin.Context) {
+	articleId := ctx.Param("articleId")
+
+	article, err := cc.db.RestoreArticle(ctx, uuid.MustParse(articleId))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Article", Error: err.Error()})
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to restore Article", Error: err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusNoContent, nil)
+	ctx.JSON(http.StatusOK, article)
+}
+
+// DeliverStock godoc
+// @Summary Book a delivery of an article
+// @Description Add the delivered units to the stock of the article at the location, or at the default location
+// @Tags Articles
+// @Accept json
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Param payload body schemas.StockDelivery true "Delivery payload"
+// @Success 200 {object} db.StockMovement "Successfully booked the delivery"
+// @Failure 400 {object} e.ErrorResponse "Invalid payload"
+// @Failure 404 {object} e.ErrorResponse "Article or location not found"
+// @Failure 500 {object} e.ErrorResponse "Failed to book the delivery"
+// @Router /article/{articleId}/stock/delivery [post]
+func (cc *ArticleController) DeliverStock(ctx *gin.Context) {
+	var payload *schemas.StockDelivery
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	cc.moveStock(ctx, db.MoveStockParams{
+		ArticleUuid:  uuid.MustParse(ctx.Param("articleId")),
+		Quantity:     payload.Quantity,
+		Reason:       db.StockMovementDelivery,
+		LocationUuid: payload.LocationUuid,
+		Note:         payload.Note,
+	})
+}
+
+// CorrectStock godoc
+// @Summary Correct the stock of an article
+// @Description Add or remove units from the stock of the article at the location, or at the default location, e.g. for breakage or after counting.
+// @Description The note is the reason of the correction.
+// @Tags Articles
+// @Accept json
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Param payload body schemas.StockCorrection true "Correction payload"
+// @Success 200 {object} db.StockMovement "Successfully corrected the stock"
+// @Failure 400 {object} e.ErrorResponse "Invalid payload"
+// @Failure 404 {object} e.ErrorResponse "Article or location not found"
+// @Failure 500 {object} e.ErrorResponse "Failed to correct the stock"
+// @Router /article/{articleId}/stock/correction [post]
+func (cc *ArticleController) CorrectStock(ctx *gin.Context) {
+	var payload *schemas.StockCorrection
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	cc.moveStock(ctx, db.MoveStockParams{
+		ArticleUuid:  uuid.MustParse(ctx.Param("articleId")),
+		Quantity:     payload.Quantity,
+		Reason:       db.StockMovementCorrection,
+		LocationUuid: payload.LocationUuid,
+		Note:         null.StringFrom(payload.Note),
+	})
+}
+
+func (cc *ArticleController) moveStock(ctx *gin.Context, arg db.MoveStockParams) {
+	if !findLocation(ctx, cc.db.Queries, arg.LocationUuid) {
+		return
+	}
+
+	movement, err := cc.db.MoveStockTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to move the Stock", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, movement)
+}
+
+// GetStockMovements godoc
+// @Summary Retrieve the stock history of an article
+// @Description Get every change of the stock of the article with its reason, newest first
+// @Tags Articles
+// @Produce json
+// @Param articleId path string true "Article ID"
+// @Success 200 {array} db.StockMovement "Successfully retrieved the stock movements"
+// @Failure 500 {object} e.ErrorResponse "Failed to retrieve the stock movements"
+// @Router /article/{articleId}/stock/movements [get]
+func (cc *ArticleController) GetStockMovements(ctx *gin.Context) {
+	movements, err := cc.db.GetStockMovementsByArticle(ctx, uuid.MustParse(ctx.Param("articleId")))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Stock Movements", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, movements)
 }
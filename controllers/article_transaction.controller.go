@@ -3,32 +3,42 @@ package controllers
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 
 	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
 	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/payment"
 	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type ArticleTransactionController struct {
-	db  *db.Queries
-	ctx context.Context
+	db       *db.Store
+	payments *payment.Registry
+	ctx      context.Context
 }
 
-func NewArticleTransactionController(db *db.Queries, ctx context.Context) *ArticleTransactionController {
-	return &ArticleTransactionController{db, ctx}
+func NewArticleTransactionController(db *db.Store, payments *payment.Registry, ctx context.Context) *ArticleTransactionController {
+	return &ArticleTransactionController{db, payments, ctx}
 }
 
 // @Summary Create a new article transaction
-// @Description Create a new article transaction with the provided payload
+// @Description Add a sale line with its component and deposit lines to a pending transaction whose payment did not start yet, the total of the transaction is updated.
+// @Description The price is calculated by the server, an optional price sent by the client has to match. The new total is checked against the credit of the resident.
 // @Tags ArticleTransactions
 // @Accept json
 // @Produce json
-// @Param payload body db.ArticleTransaction true "CreateArticleTransaction payload"
-// @Success 200 {object} db.ArticleTransaction "ArticleTransaction data"
+// @Param payload body schemas.CreateArticleTransaction true "CreateArticleTransaction payload"
+// @Success 200 {object} db.ArticleTransactionTxResult "ArticleTransaction data with its component and deposit lines and stock warnings"
 // @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 402 {object} e.InsufficientFundsResponse "Credit policy blocks the new total"
+// @Failure 404 {object} e.ErrorResponse "Article or transaction not found"
+// @Failure 409 {object} e.ErrorResponse "Transaction is not pending, business day is closed or article is out of stock or archived"
+// @Failure 422 {object} e.ErrorResponse "Price mismatch or total not positive"
 // @Router /article-transaction [post]
 func (cc *ArticleTransactionController) CreateArticleTransaction(ctx *gin.Context) {
 	var payload *schemas.CreateArticleTransaction
@@ -38,17 +48,23 @@ func (cc *ArticleTransactionController) CreateArticleTransaction(ctx *gin.Contex
 		return
 	}
 
-	args := &db.CreateArticleTransactionParams{
-		ArticleUuid:     payload.ArticleUuid,
-		TransactionUuid: payload.TransactionUuid,
-		Amount:          payload.Amount,
-		Price:           payload.Price,
+	line, ok := priceArticleLine(ctx, cc.db.Queries, payload.ArticleUuid, payload.Amount, payload.Price)
+	if !ok {
+		return
+	}
+
+	if !checkTransactionOpen(ctx, cc.db.Queries, payload.TransactionUuid) {
+		return
 	}
 
-	articleTransaction, err := cc.db.CreateArticleTransaction(ctx, *args)
+	credit, ok := cc.transactionCredit(ctx, payload.TransactionUuid)
+	if !ok {
+		return
+	}
 
+	articleTransaction, err := cc.db.CreateArticleTransactionTx(ctx, payload.TransactionUuid, line, credit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create the ArticleTransaction", Error: err.Error()})
+		respondArticleTransactionError(ctx, err, "Failed to create the ArticleTransaction")
 		return
 	}
 
@@ -56,15 +72,19 @@ func (cc *ArticleTransactionController) CreateArticleTransaction(ctx *gin.Contex
 }
 
 // @Summary Update an article transaction
-// @Description Update an article transaction with the provided payload
+// @Description Update a sale line of a pending transaction whose payment did not start yet. The line is priced again by the server,
+// @Description an optional price sent by the client has to match. The totals of the transactions are updated, the new total is checked against the credit of the resident.
 // @Tags ArticleTransactions
 // @Accept json
 // @Produce json
 // @Param articleTransactionId path string true "Article Transaction ID"
 // @Param payload body schemas.UpdateArticleTransaction true "UpdateArticleTransaction payload"
-// @Success 200 {object} db.ArticleTransaction "ArticleTransaction data"
+// @Success 200 {object} db.ArticleTransactionTxResult "ArticleTransaction data with its component and deposit lines and stock warnings"
 // @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 402 {object} e.InsufficientFundsResponse "Credit policy blocks the new total"
 // @Failure 404 {object} e.ErrorResponse "Article transaction not found"
+// @Failure 409 {object} e.ErrorResponse "Transaction is not pending, business day is closed or article is out of stock or archived"
+// @Failure 422 {object} e.ErrorResponse "Price mismatch, not a sale line or total not positive"
 // @Router /article-transaction/{articleTransactionId} [put]
 func (cc *ArticleTransactionController) UpdateArticleTransaction(ctx *gin.Context) {
 	var payload *schemas.UpdateArticleTransaction
@@ -75,26 +95,143 @@ func (cc *ArticleTransactionController) UpdateArticleTransaction(ctx *gin.Contex
 		return
 	}
 
-	args := &db.UpdateArticleTransactionParams{
-		Uuid:            uuid.MustParse(articleTransactionId),
-		ArticleUuid:     payload.ArticleUuid,
-		TransactionUuid: payload.TransactionUuid,
-		Amount:          payload.Amount,
-		Price:           payload.Price,
+	existing, err := cc.db.GetArticleTransactionById(ctx, uuid.MustParse(articleTransactionId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Failed to find ArticleTransaction", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve ArticleTransaction", Error: err.Error()})
+		return
+	}
+
+	if !checkTransactionOpen(ctx, cc.db.Queries, existing.TransactionUuid) {
+		return
+	}
+	if payload.TransactionUuid.Valid && !checkTransactionOpen(ctx, cc.db.Queries, payload.TransactionUuid.UUID) {
+		return
+	}
+
+	articleUuid := existing.ArticleUuid
+	if payload.ArticleUuid.Valid {
+		articleUuid = payload.ArticleUuid.UUID
+	}
+	transactionUuid := existing.TransactionUuid
+	if payload.TransactionUuid.Valid {
+		transactionUuid = payload.TransactionUuid.UUID
+	}
+	amount := existing.Amount
+	if payload.Amount.Valid {
+		amount = payload.Amount.Int32
+	}
+
+	line, ok := priceArticleLine(ctx, cc.db.Queries, articleUuid, amount, payload.Price)
+	if !ok {
+		return
+	}
+
+	credit, ok := cc.transactionCredit(ctx, transactionUuid)
+	if !ok {
+		return
+	}
+
+	articleTransaction, err := cc.db.UpdateArticleTransactionTx(ctx, existing.Uuid, transactionUuid, line, credit)
+	if err != nil {
+		respondArticleTransactionError(ctx, err, "Failed to update ArticleTransaction")
+		return
 	}
 
-	articleTransaction, err := cc.db.UpdateArticleTransaction(ctx, *args)
+	ctx.JSON(http.StatusOK, articleTransaction)
+}
 
+// priceArticleLine prices a single line at the current price list like the
+// checkout does, archived articles can not be sold. On failure the error
+// response is already written and ok is false.
+func priceArticleLine(ctx *gin.Context, q *db.Queries, articleUuid uuid.UUID, amount int32, expected util.NullMoney) (line db.CheckoutLine, ok bool) {
+	article, err := q.GetArticleById(ctx, articleUuid)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Failed to find ArticleTransaction", Error: err.Error()})
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
 			return
 		}
-		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to update ArticleTransaction", Error: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, articleTransaction)
+	if article.ArchivedAt.Valid {
+		respondPriceItemsError(ctx, fmt.Errorf("%w: %s", db.ErrArticleArchived, article.Name))
+		return
+	}
+
+	prices, err := q.GetCurrentPriceList(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the price list", Error: err.Error()})
+		return
+	}
+
+	price, rule := prices.Price(article)
+	if err := checkPrice(expected, price); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.PriceMismatch, Message: "Price does not match the server side price", Error: err.Error()})
+		return
+	}
+
+	vatRate, err := q.GetVatRate(ctx, article)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the VAT rate", Error: err.Error()})
+		return
+	}
+
+	return db.CheckoutLine{ArticleUuid: article.Uuid, Amount: amount, Price: price, PricingRuleUuid: rule, Deposit: article.Deposit, VatRate: vatRate}, true
+}
+
+// transactionCredit prepares the credit check of the resident of the
+// transaction, guests are not checked. On failure the error response is
+// already written and ok is false.
+func (cc *ArticleTransactionController) transactionCredit(ctx *gin.Context, transactionUuid uuid.UUID) (credit *db.CheckCreditParams, ok bool) {
+	transaction, err := cc.db.GetTransactionById(ctx, transactionUuid)
+	if err != nil {
+		respondArticleTransactionError(ctx, err, "Failed to retrieve Transaction")
+		return nil, false
+	}
+
+	if !transaction.Resident.Valid {
+		return nil, true
+	}
+
+	resident, err := cc.db.GetUserById(ctx, transaction.Resident.String)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
+		return nil, false
+	}
+
+	backend, err := cc.payments.Get(transaction.PaymentBackend)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to resolve the payment backend", Error: err.Error()})
+		return nil, false
+	}
+
+	return creditCheck(ctx, backend, resident, transaction.Date)
+}
+
+func respondArticleTransactionError(ctx *gin.Context, err error, message string) {
+	if respondCreditError(ctx, err) {
+		return
+	}
+
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Failed to find ArticleTransaction or Transaction", Error: err.Error()})
+	case errors.Is(err, db.ErrTransactionNotPending):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.NotPending, Message: "Only lines of pending transactions can be changed, refund the transaction instead", Error: err.Error()})
+	case errors.Is(err, db.ErrTotalNotPositive):
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.TotalNotPositive, Message: "Transaction total has to be positive", Error: err.Error()})
+	case errors.Is(err, db.ErrNotSaleLine):
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.NotSaleLine, Message: "Only sale lines can be changed", Error: err.Error()})
+	case errors.Is(err, db.ErrOutOfStock):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.OutOfStock, Message: "Article is out of stock", Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: message, Error: err.Error()})
+	}
 }
 
 // @Summary Retrieve an article transaction
@@ -145,6 +282,16 @@ func (cc *ArticleTransactionController) GetAllArticleTransactions(ctx *gin.Conte
 	ctx.JSON(http.StatusOK, articleTransactions)
 }
 
+// @Summary Retrieve article transactions grouped by article
+// @Description Sum up the amount and revenue sold per article. Bundles are counted as their components,
+// @Description the revenue of a bundle is split in proportion to the resell price of its components at the time of the sale.
+// @Description The cost is the purchase price of the units at the time of their sale, the profit is the revenue minus the cost.
+// @Description Only charged transactions are counted.
+// @Tags ArticleTransactions
+// @Produce json
+// @Success 200 {array} db.GetArticleTransactionsGroupedByArticleRow "Amount, revenue, cost and profit per article"
+// @Failure 500 {object} e.ErrorResponse "Failed to retrieve ArticleTransactions"
+// @Router /article-transaction/grouped-by-article [get]
 func (cc *ArticleTransactionController) GetAllArticleTransactionsGroupedByArticle(ctx *gin.Context) {
 	articleTransactions, err := cc.db.GetArticleTransactionsGroupedByArticle(ctx)
 	if err != nil {
@@ -159,20 +306,57 @@ func (cc *ArticleTransactionController) GetAllArticleTransactionsGroupedByArticl
 	ctx.JSON(http.StatusOK, articleTransactions)
 }
 
+// @Summary Retrieve the outstanding deposits
+// @Description Sum up the deposits charged minus the deposits returned per article of charged transactions, these are liabilities and not part of the revenue
+// @Tags ArticleTransactions
+// @Produce json
+// @Success 200 {array} db.GetDepositLiabilitiesRow "Outstanding bottles and deposit per article"
+// @Failure 500 {object} e.ErrorResponse "Failed to retrieve ArticleTransactions"
+// @Router /article-transaction/deposits [get]
+func (cc *ArticleTransactionController) GetDepositLiabilities(ctx *gin.Context) {
+	deposits, err := cc.db.GetDepositLiabilities(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve ArticleTransactions", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deposits)
+}
+
+// @Summary Retrieve article transactions grouped by pricing rule
+// @Description Sum up the amount and revenue sold with every pricing rule in charged transactions, lines sold at the resell price have no rule
+// @Tags ArticleTransactions
+// @Produce json
+// @Success 200 {array} db.GetArticleTransactionsGroupedByPricingRuleRow "Amount and revenue per pricing rule"
+// @Failure 500 {object} e.ErrorResponse "Failed to retrieve ArticleTransactions"
+// @Router /article-transaction/grouped-by-pricing-rule [get]
+func (cc *ArticleTransactionController) GetAllArticleTransactionsGroupedByPricingRule(ctx *gin.Context) {
+	articleTransactions, err := cc.db.GetArticleTransactionsGroupedByPricingRule(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve ArticleTransactions", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, articleTransactions)
+}
+
 // @Summary Delete an article transaction
-// @Description Delete an article transaction by the provided ID
+// @Description Delete a sale line of a pending or failed transaction by the provided ID, the total of the transaction is updated.
+// @Description Lines of charged transactions can not be deleted, refund the transaction instead.
 // @Tags ArticleTransactions
 // @Accept json
 // @Produce json
 // @Param articleTransactionId path string true "Article Transaction ID"
 // @Success 204 "ArticleTransaction deleted successfully"
 // @Failure 404 {object} e.ErrorResponse "Article transaction not found"
+// @Failure 409 {object} e.ErrorResponse "Transaction is charged or its business day is closed"
+// @Failure 422 {object} e.ErrorResponse "Line is not a sale line or total not positive"
 // @Failure 500 {object} e.ErrorResponse "Failed to delete ArticleTransaction"
 // @Router /article-transaction/{articleTransactionId} [delete]
 func (cc *ArticleTransactionController) DeleteArticleTransactionById(ctx *gin.Context) {
 	articleTransactionId := ctx.Param("articleTransactionId")
 
-	_, err := cc.db.GetArticleTransactionById(ctx, uuid.MustParse(articleTransactionId))
+	articleTransaction, err := cc.db.GetArticleTransactionById(ctx, uuid.MustParse(articleTransactionId))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Failed to retrieve ArticleTransaction", Error: err.Error()})
@@ -182,9 +366,13 @@ func (cc *ArticleTransactionController) DeleteArticleTransactionById(ctx *gin.Co
 		return
 	}
 
-	err = cc.db.DeleteArticleTransaction(ctx, uuid.MustParse(articleTransactionId))
+	if !checkTransactionOpen(ctx, cc.db.Queries, articleTransaction.TransactionUuid) {
+		return
+	}
+
+	err = cc.db.DeleteArticleTransactionTx(ctx, uuid.MustParse(articleTransactionId))
 	if err != nil {
-		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete ArticleTransaction", Error: err.Error()})
+		respondArticleTransactionError(ctx, err, "Failed to delete ArticleTransaction")
 		return
 	}
 
@@ -3,13 +3,16 @@ package controllers
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
+	"time"
 
 	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
 	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
 	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
 )
 
 type ArticleTypeController struct {
@@ -21,6 +24,14 @@ func NewArticleTypeController(db *db.Queries, ctx context.Context) *ArticleTypeC
 	return &ArticleTypeController{db, ctx}
 }
 
+// validVatRate checks an optional VAT rate in whole percent
+func validVatRate(rate null.Int32) error {
+	if rate.Valid && (rate.Int32 < 0 || rate.Int32 > 100) {
+		return fmt.Errorf("vat_rate has to be between 0 and 100")
+	}
+	return nil
+}
+
 // CreateArticleType godoc
 // @Summary Create a new article type
 // @Description Create a new article type with the provided payload
@@ -40,11 +51,22 @@ func (cc *ArticleTypeController) CreateArticleType(ctx *gin.Context) {
 		return
 	}
 
+	if err := validVatRate(payload.VatRate); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	vatRate := db.DefaultVatRate
+	if payload.VatRate.Valid {
+		vatRate = payload.VatRate.Int32
+	}
+
 	args := &db.CreateArticleTypeParams{
 		Name:          payload.Name,
 		Desc:          payload.Desc,
 		IconCodepoint: payload.IconCodepoint,
 		Color:         payload.Color,
+		VatRate:       vatRate,
 	}
 
 	articleType, err := cc.db.CreateArticleType(ctx, *args)
@@ -78,12 +100,18 @@ func (cc *ArticleTypeController) UpdateArticleType(ctx *gin.Context) {
 		return
 	}
 
+	if err := validVatRate(payload.VatRate); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
 	args := &db.UpdateArticleTypeParams{
 		Uuid:          uuid.MustParse(articleTypeId),
 		Name:          payload.Name,
 		Desc:          payload.Desc,
 		IconCodepoint: payload.IconCodepoint,
 		Color:         payload.Color,
+		VatRate:       payload.VatRate,
 	}
 
 	articleType, err := cc.db.UpdateArticleType(ctx, *args)
@@ -127,14 +155,23 @@ func (cc *ArticleTypeController) GetArticleTypeById(ctx *gin.Context) {
 
 // GetAllArticleTypes godoc
 // @Summary Retrieve all article types
-// @Description Get a list of all article types
+// @Description Get a list of all article types, archived ones only on request
 // @Tags ArticleTypes
 // @Produce json
+// @Param archived query bool false "Include archived article types"
 // @Success 200 {array} db.ArticleType "Successfully retrieved all article types"
+// @Failure 400 {object} e.ErrorResponse "Invalid filter"
 // @Failure 500 {object} e.ErrorResponse "Failed to retrieve article types"
 // @Router /article-type [get]
 func (cc *ArticleTypeController) GetAllArticleTypes(ctx *gin.Context) {
-	articleTypes, err := cc.db.GetArticleTypes(ctx)
+	var filter schemas.ArchivedFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	articleTypes, err := cc.db.GetArticleTypes(ctx, filter.Archived)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve ArticleTypes", Error: err.Error()})
 		return
@@ -147,16 +184,31 @@ func (cc *ArticleTypeController) GetAllArticleTypes(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, articleTypes)
 }
 
-// GetAllArticleTypes godoc
-// @Summary Retrieve all article types
-// @Description Get a list of all article types
+// GetAllArticleTypesWithArticles godoc
+// @Summary Retrieve the menu
+// @Description Get all article types with their articles and the price they are sold at right now, archived ones are hidden.
+// @Description With at the menu is shown as it was at that moment, with the prices and articles of then.
 // @Tags ArticleTypes
 // @Produce json
-// @Success 200 {array} db.ArticleType "Successfully retrieved all article types"
+// @Param at query string false "RFC 3339 timestamp, defaults to now"
+// @Success 200 {array} schemas.ArticleTypeWithArticles "Successfully retrieved the menu"
+// @Failure 400 {object} e.ErrorResponse "Invalid filter"
 // @Failure 500 {object} e.ErrorResponse "Failed to retrieve article types"
-// @Router /article-type [get]
+// @Router /article-type/article [get]
 func (cc *ArticleTypeController) GetAllArticleTypesWithArticles(ctx *gin.Context) {
-	articleTypes, err := cc.db.GetArticleTypesWithArticles(ctx)
+	var filter schemas.MenuFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	at := filter.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	articleTypes, err := cc.db.GetArticleTypesWithArticles(ctx, at)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve ArticleTypes with Articles", Error: err.Error()})
@@ -170,6 +222,12 @@ func (cc *ArticleTypeController) GetAllArticleTypesWithArticles(ctx *gin.Context
 		return
 	}
 
+	prices, err := cc.db.GetPriceList(ctx, at)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the price list", Error: err.Error()})
+		return
+	}
+
 	// at this point the parsing begins
 
 	result := []schemas.ArticleTypeWithArticles{}
@@ -177,55 +235,77 @@ func (cc *ArticleTypeController) GetAllArticleTypesWithArticles(ctx *gin.Context
 	currentAtwa := schemas.ArticleTypeWithArticles{}
 
 	for _, articleType := range articleTypes {
-		if articleType.ArticleType.Uuid == currentAtwa.Uuid {
-			if articleType.Uuid.Valid {
-				currentAtwa.Articles = append(currentAtwa.Articles, db.Article{Uuid: articleType.Uuid.UUID, Name: articleType.Name.String, Desc: articleType.Desc, PurchasePrice: articleType.PurchasePrice.Float64, ResellPrice: articleType.ResellPrice.Float64, ArticleTypeUuid: articleType.Uuid.UUID})
-			}
-
-		} else {
+		if articleType.ArticleType.Uuid != currentAtwa.Uuid {
 			// add currentatwa to result, and make new article type to current atwa
 			if currentAtwa.Uuid != uuid.Nil {
 				result = append(result, currentAtwa)
 			}
-			currentAtwa = schemas.ArticleTypeWithArticles{ArticleType: articleType.ArticleType, Articles: []db.Article{}}
-			if articleType.Uuid.Valid {
-				currentAtwa.Articles = append(currentAtwa.Articles, db.Article{Uuid: articleType.Uuid.UUID, Name: articleType.Name.String, Desc: articleType.Desc, PurchasePrice: articleType.PurchasePrice.Float64, ResellPrice: articleType.ResellPrice.Float64, ArticleTypeUuid: articleType.Uuid.UUID})
-			}
+			currentAtwa = schemas.ArticleTypeWithArticles{ArticleType: articleType.ArticleType, Articles: []schemas.MenuArticle{}}
 		}
 
+		if articleType.Uuid.Valid {
+			article := db.Article{Uuid: articleType.Uuid.UUID, Name: articleType.Name.String, Desc: articleType.Desc, PurchasePrice: articleType.PurchasePrice.Money, ResellPrice: articleType.ResellPrice.Money, ArticleTypeUuid: articleType.ArticleTypeUuid.UUID, Deposit: articleType.Deposit.Money, VatRate: articleType.VatRate, Stock: articleType.Stock.Int32, StockPolicy: articleType.StockPolicy.String, ReorderThreshold: articleType.ReorderThreshold, ArchivedAt: articleType.ArchivedAt}
+			price, rule := prices.Price(article)
+			currentAtwa.Articles = append(currentAtwa.Articles, schemas.MenuArticle{Article: article, Price: price, PricingRuleUuid: rule})
+		}
+	}
+
+	if currentAtwa.Uuid != uuid.Nil {
+		result = append(result, currentAtwa)
 	}
 
 	ctx.JSON(http.StatusOK, result)
 }
 
-// DeleteArticleTypeById godoc
-// @Summary Delete an article type by ID
-// @Description Remove an article type using its ID
+// ArchiveArticleTypeById godoc
+// @Summary Archive an article type by ID
+// @Description Archive an article type instead of deleting it. Archived article types are hidden from the menu together with their articles,
+// @Description their sales stay in the reports and receipts.
 // @Tags ArticleTypes
 // @Produce json
 // @Param articleTypeId path string true "Article Type ID"
-// @Success 204 "Successfully deleted article type"
+// @Success 204 "Successfully archived article type"
 // @Failure 404 {object} e.ErrorResponse "Article type not found"
-// @Failure 500 {object} e.ErrorResponse "Failed to delete article type"
+// @Failure 500 {object} e.ErrorResponse "Failed to archive article type"
 // @Router /article-type/{articleTypeId} [delete]
-func (cc *ArticleTypeController) DeleteArticleTypeById(ctx *gin.Context) {
+func (cc *ArticleTypeController) ArchiveArticleTypeById(ctx *gin.Context) {
 	articleTypeId := ctx.Param("articleTypeId")
 
-	_, err := cc.db.GetArticleTypeById(ctx, uuid.MustParse(articleTypeId))
+	_, err := cc.db.ArchiveArticleType(ctx, uuid.MustParse(articleTypeId))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article type not found", Error: err.Error()})
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve ArticleType", Error: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to archive ArticleType", Error: err.Error()})
 		return
 	}
 
-	err = cc.db.DeleteArticleType(ctx, uuid.MustParse(articleTypeId))
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// RestoreArticleTypeById godoc
+// @Summary Restore an archived article type
+// @Description Put an archived article type back on the menu with its articles that are not archived themselves
+// @Tags ArticleTypes
+// @Produce json
+// @Param articleTypeId path string true "Article Type ID"
+// @Success 200 {object} db.ArticleType "Successfully restored article type"
+// @Failure 404 {object} e.ErrorResponse "Article type not found"
+// @Failure 500 {object} e.ErrorResponse "Failed to restore article type"
+// @Router /article-type/{articleTypeId}/restore [post]
+func (cc *ArticleTypeController) RestoreArticleTypeById(ctx *gin.Context) {
+	articleTypeId := ctx.Param("articleTypeId")
+
+	articleType, err := cc.db.RestoreArticleType(ctx, uuid.MustParse(articleTypeId))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete ArticleType", Error: err.Error()})
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article type not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to restore ArticleType", Error: err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusNoContent, nil)
+	ctx.JSON(http.StatusOK, articleType)
 }
@@ -0,0 +1,398 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/payment"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type CheckoutController struct {
+	db       *db.Store
+	payments *payment.Registry
+	ctx      context.Context
+}
+
+func NewCheckoutController(db *db.Store, payments *payment.Registry, ctx context.Context) *CheckoutController {
+	return &CheckoutController{db, payments, ctx}
+}
+
+// @Summary Checkout a cart
+// @Description Create a transaction with all of its article transactions in one step.
+// @Description Prices are calculated by the server, an optional total sent by the client has to match.
+// @Description The transaction starts as pending, poll its status until the resident has been charged.
+// @Description The payment backend is the one of the resident, else the one of the terminal. Guests without a resident pay cash.
+// @Description Sales of residents have to stay within their minimum balance and daily cap.
+// @Description Sold units are taken out of the stock, articles with the warn policy that are sold below zero are listed in stock_warnings.
+// @Tags Checkout
+// @Accept json
+// @Produce json
+// @Param payload body schemas.Checkout true "Checkout payload"
+// @Success 200 {object} db.CheckoutTxResult "Transaction with its article transactions"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 402 {object} e.InsufficientFundsResponse "Credit policy blocks the sale"
+// @Failure 404 {object} e.ErrorResponse "Resident, terminal or article not found"
+// @Failure 409 {object} e.ErrorResponse "Article is out of stock or archived"
+// @Failure 422 {object} e.ErrorResponse "Price mismatch"
+// @Router /checkout [post]
+func (cc *CheckoutController) Checkout(ctx *gin.Context) {
+	var payload *schemas.Checkout
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Payload is invalid", Error: err.Error()})
+		return
+	}
+
+	var resident *db.Resident
+	if payload.Resident != "" {
+		user, err := cc.db.GetUserById(ctx, payload.Resident)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Resident not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
+			return
+		}
+		resident = &user
+	}
+
+	result, ok := checkout(ctx, cc.db, cc.payments, resident, payload.TerminalUuid, payload.Items, payload.Total)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Split a bill
+// @Description Checkout a cart paid by several residents. Every resident is charged for a share with their own payment backend.
+// @Description In even mode the total is divided equally, the remaining cents go to the first shares.
+// @Description In amounts mode every share names its amount, they have to add up to the total.
+// @Description In items mode every share lists the indices of the items it pays, every item has to be assigned exactly once.
+// @Description The bill is charged once all shares are. If one share fails, the others are cancelled or paid back.
+// @Tags Checkout
+// @Accept json
+// @Produce json
+// @Param payload body schemas.SplitCheckout true "Split checkout payload"
+// @Success 200 {object} db.SplitCheckoutTxResult "Bill with its article transactions and shares"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 402 {object} e.InsufficientFundsResponse "Credit policy blocks the share of a resident"
+// @Failure 404 {object} e.ErrorResponse "Resident, terminal or article not found"
+// @Failure 409 {object} e.ErrorResponse "Article is out of stock or archived"
+// @Failure 422 {object} e.ErrorResponse "Price or split mismatch"
+// @Router /checkout/split [post]
+func (cc *CheckoutController) SplitCheckout(ctx *gin.Context) {
+	var payload *schemas.SplitCheckout
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Payload is invalid", Error: err.Error()})
+		return
+	}
+
+	date := time.Now()
+
+	lines, err := priceItems(ctx, cc.db.Queries, payload.Items)
+	if err != nil {
+		respondPriceItemsError(ctx, err)
+		return
+	}
+
+	total := linesTotal(lines)
+	if err := checkPrice(payload.Total, total); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.PriceMismatch, Message: "Price does not match the server side price", Error: err.Error()})
+		return
+	}
+
+	amounts, err := splitAmounts(payload.Mode, payload.Shares, lines, total)
+	if err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.SplitMismatch, Message: "Shares do not match the bill", Error: err.Error()})
+		return
+	}
+
+	shares := make([]db.SplitShare, 0, len(payload.Shares))
+	seen := make(map[string]bool, len(payload.Shares))
+	for i, share := range payload.Shares {
+		if seen[share.Resident] {
+			ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.SplitMismatch, Message: "Shares do not match the bill", Error: "resident " + share.Resident + " has more than one share"})
+			return
+		}
+		seen[share.Resident] = true
+
+		resident, err := cc.db.GetUserById(ctx, share.Resident)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Resident not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
+			return
+		}
+
+		backend, ok := resolvePaymentBackend(ctx, cc.db.Queries, cc.payments, &resident, payload.TerminalUuid)
+		if !ok {
+			return
+		}
+
+		credit, ok := creditCheck(ctx, backend, resident, date)
+		if !ok {
+			return
+		}
+
+		shares = append(shares, db.SplitShare{Resident: resident.Name, PaymentBackend: backend.Name(), Amount: amounts[i], Credit: credit})
+	}
+
+	result, err := cc.db.SplitCheckoutTx(ctx, db.SplitCheckoutTxParams{
+		TerminalUuid: payload.TerminalUuid,
+		Date:         date,
+		Lines:        lines,
+		Shares:       shares,
+	})
+	if err != nil {
+		if respondCreditError(ctx, err) {
+			return
+		}
+		if errors.Is(err, db.ErrOutOfStock) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.OutOfStock, Message: "Article is out of stock", Error: err.Error()})
+			return
+		}
+		if errors.Is(err, db.ErrPeriodClosed) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "The business day is already closed", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to checkout", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Return deposit
+// @Description Pay back the deposit of returned bottles or crates. The returned items are written as negative deposit lines of a transaction and credited to the resident.
+// @Description The payment backend is resolved like for a sale, guests without a resident are paid out in cash.
+// @Tags Checkout
+// @Accept json
+// @Produce json
+// @Param payload body schemas.DepositReturn true "Deposit return payload"
+// @Success 200 {object} db.CheckoutTxResult "Transaction with its deposit return lines"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload or article without deposit"
+// @Failure 404 {object} e.ErrorResponse "Resident, terminal or article not found"
+// @Router /checkout/deposit-return [post]
+func (cc *CheckoutController) DepositReturn(ctx *gin.Context) {
+	var payload *schemas.DepositReturn
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Payload is invalid", Error: err.Error()})
+		return
+	}
+
+	var resident *db.Resident
+	var residentName null.String
+	if payload.Resident != "" {
+		user, err := cc.db.GetUserById(ctx, payload.Resident)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Resident not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
+			return
+		}
+		resident = &user
+		residentName = null.StringFrom(user.Name)
+	}
+
+	backend, ok := resolvePaymentBackend(ctx, cc.db.Queries, cc.payments, resident, payload.TerminalUuid)
+	if !ok {
+		return
+	}
+
+	lines := make([]db.CheckoutLine, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		article, err := cc.db.GetArticleById(ctx, item.ArticleUuid)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
+			return
+		}
+
+		if article.Deposit == 0 {
+			ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Article has no deposit", Error: "article " + article.Name + " has no deposit"})
+			return
+		}
+
+		vatRate, err := cc.db.GetVatRate(ctx, article)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the VAT rate", Error: err.Error()})
+			return
+		}
+
+		lines = append(lines, db.CheckoutLine{ArticleUuid: article.Uuid, Amount: -item.Amount, Price: article.Deposit, Kind: db.ArticleTransactionDepositReturn, VatRate: vatRate})
+	}
+
+	result, err := cc.db.CheckoutTx(ctx, db.CheckoutTxParams{
+		Resident:       residentName,
+		TerminalUuid:   payload.TerminalUuid,
+		PaymentBackend: backend.Name(),
+		Date:           time.Now(),
+		Lines:          lines,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to return the deposit", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// checkout prices the items, writes the transaction with its article
+// transactions and queues the payment with the backend of the resident or
+// terminal. A nil resident is a guest paying cash. On failure the error
+// response is already written and ok is false.
+func checkout(ctx *gin.Context, store *db.Store, payments *payment.Registry, resident *db.Resident, terminalUuid uuid.NullUUID, items []schemas.CheckoutItem, total util.NullMoney) (result db.CheckoutTxResult, ok bool) {
+	backend, ok := resolvePaymentBackend(ctx, store.Queries, payments, resident, terminalUuid)
+	if !ok {
+		return
+	}
+
+	var residentName null.String
+	if resident != nil {
+		residentName = null.StringFrom(resident.Name)
+	}
+
+	// sales are always booked at the time they are made
+	date := time.Now()
+
+	lines, err := priceItems(ctx, store.Queries, items)
+	if err != nil {
+		respondPriceItemsError(ctx, err)
+		return
+	}
+
+	if err := checkPrice(total, linesTotal(lines)); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.PriceMismatch, Message: "Price does not match the server side price", Error: err.Error()})
+		return
+	}
+
+	var credit *db.CheckCreditParams
+	if resident != nil {
+		if credit, ok = creditCheck(ctx, backend, *resident, date); !ok {
+			return
+		}
+	}
+
+	result, err = store.CheckoutTx(ctx, db.CheckoutTxParams{
+		Resident:       residentName,
+		TerminalUuid:   terminalUuid,
+		PaymentBackend: backend.Name(),
+		Date:           date,
+		Lines:          lines,
+		Credit:         credit,
+	})
+
+	if err != nil {
+		if respondCreditError(ctx, err) {
+			return
+		}
+		if errors.Is(err, db.ErrOutOfStock) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.OutOfStock, Message: "Article is out of stock", Error: err.Error()})
+			return
+		}
+		if errors.Is(err, db.ErrPeriodClosed) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "The business day is already closed", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to checkout", Error: err.Error()})
+		return
+	}
+
+	return result, true
+}
+
+// resolvePaymentBackend returns the backend of the resident, else the one of
+// the terminal, else the configured default. Guests without a resident pay
+// cash. On failure the error response is already written and ok is false.
+func resolvePaymentBackend(ctx *gin.Context, q *db.Queries, payments *payment.Registry, resident *db.Resident, terminalUuid uuid.NullUUID) (backend payment.PaymentBackend, ok bool) {
+	if resident == nil {
+		backend, err := payments.Get(payment.Cash)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to resolve the payment backend", Error: err.Error()})
+			return nil, false
+		}
+		return backend, true
+	}
+
+	var terminalBackend null.String
+	if terminalUuid.Valid {
+		terminal, err := q.GetTerminalById(ctx, terminalUuid.UUID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Terminal not found", Error: err.Error()})
+				return nil, false
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Terminal", Error: err.Error()})
+			return nil, false
+		}
+		terminalBackend = terminal.PaymentBackend
+	}
+
+	backend, err := payments.Resolve(resident.PaymentBackend, terminalBackend)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to resolve the payment backend", Error: err.Error()})
+		return nil, false
+	}
+
+	return backend, true
+}
+
+// creditCheck prepares the check of a sale against the credit policy of the
+// resident and the live balance of the backend. The store transaction writing
+// the sale runs it with the amount of the sale. On failure the error response
+// is already written and ok is false.
+func creditCheck(ctx *gin.Context, backend payment.PaymentBackend, resident db.Resident, date time.Time) (credit *db.CheckCreditParams, ok bool) {
+	var balance util.NullMoney
+	current, err := backend.Balance(ctx, resident.Name)
+	switch {
+	case err == nil:
+		balance = util.NullMoneyFrom(current)
+	case !errors.Is(err, payment.ErrNoBalance):
+		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the balance", Error: err.Error()})
+		return nil, false
+	}
+
+	return &db.CheckCreditParams{
+		Resident:       resident,
+		PaymentBackend: backend.Name(),
+		Balance:        balance,
+		Prepaid:        backend.Name() == payment.Wallet,
+		Date:           date,
+	}, true
+}
+
+// respondCreditError writes the response of a sale blocked by the credit
+// policy of the resident and reports whether the error was one
+func respondCreditError(ctx *gin.Context, err error) bool {
+	var insufficient *db.InsufficientFundsError
+	if !errors.As(err, &insufficient) {
+		return false
+	}
+
+	ctx.JSON(http.StatusPaymentRequired, e.InsufficientFundsResponse{
+		ErrorResponse: e.ErrorResponse{Code: e.InsufficientFunds, Message: "Sale exceeds the credit of the resident", Error: err.Error()},
+		Shortfall:     insufficient.Shortfall,
+	})
+	return true
+}
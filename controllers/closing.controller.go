@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ClosingController struct {
+	db  *db.Store
+	ctx context.Context
+}
+
+func NewClosingController(db *db.Store, ctx context.Context) *ClosingController {
+	return &ClosingController{db, ctx}
+}
+
+// checkPeriodOpen writes a conflict if the date lies inside a closed
+// business day, the bookings of closed days can not be changed anymore
+func checkPeriodOpen(ctx *gin.Context, q *db.Queries, date time.Time) bool {
+	err := q.CheckPeriodOpen(ctx, date)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, db.ErrPeriodClosed):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "The business day is already closed, refund the transaction instead", Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to check the closed periods", Error: err.Error()})
+	}
+	return false
+}
+
+// checkTransactionOpen checks the period of the transaction a change belongs to
+func checkTransactionOpen(ctx *gin.Context, q *db.Queries, transactionUuid uuid.UUID) bool {
+	transaction, err := q.GetTransactionById(ctx, transactionUuid)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Transaction not found", Error: err.Error()})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Transaction", Error: err.Error()})
+		return false
+	}
+
+	return checkPeriodOpen(ctx, q, transaction.Date)
+}
+
+// @Summary Close the business day
+// @Description Close everything since the last closing and write the Z-report: revenue, transactions, refunds, totals per article type,
+// @Description totals per payment backend and deposits. Only charged transactions are counted. The report can not be changed afterwards,
+// @Description transactions dated inside the closed period can no longer be updated or deleted.
+// @Description The day can not be closed while transactions in it are pending, reconcile them first.
+// @Tags Closings
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CloseDay false "CloseDay payload"
+// @Success 200 {object} db.ClosingReport "Z-report"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 409 {object} e.ErrorResponse "Period is already closed or transactions are pending"
+// @Router /closing [post]
+func (cc *ClosingController) CloseDay(ctx *gin.Context) {
+	var payload schemas.CloseDay
+
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&payload); err != nil {
+			ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+			return
+		}
+	}
+
+	now := time.Now()
+	until := now
+	if payload.Until.Valid {
+		until = payload.Until.Time
+	}
+
+	// sales are always booked now, a closing in the future would lock them
+	if until.After(now) {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "until can not be in the future"})
+		return
+	}
+
+	report, err := cc.db.CloseDayTx(ctx, until)
+	if err != nil {
+		if errors.Is(err, db.ErrPeriodClosed) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "Period is already closed", Error: err.Error()})
+			return
+		}
+		if errors.Is(err, db.ErrTransactionsPending) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.Pending, Message: "Transactions of the period are pending", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to close the day", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// @Summary Retrieve all closings
+// @Description Retrieve the closings without their totals, the latest first
+// @Tags Closings
+// @Produce json
+// @Success 200 {array} db.Closing "List of closings"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /closing [get]
+func (cc *ClosingController) GetAllClosings(ctx *gin.Context) {
+	closings, err := cc.db.GetClosings(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Closings", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, closings)
+}
+
+// @Summary Retrieve a Z-report
+// @Description Retrieve a closing with its totals per article type and per payment backend
+// @Tags Closings
+// @Produce json
+// @Param closingId path string true "Closing ID"
+// @Success 200 {object} db.ClosingReport "Z-report"
+// @Failure 404 {object} e.ErrorResponse "Closing not found"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /closing/{closingId} [get]
+func (cc *ClosingController) GetClosingById(ctx *gin.Context) {
+	closingId := ctx.Param("closingId")
+
+	report, err := cc.db.GetClosingReport(ctx, uuid.MustParse(closingId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Closing not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the Closing", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
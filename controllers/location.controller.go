@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LocationController struct {
+	db  *db.Store
+	ctx context.Context
+}
+
+func NewLocationController(db *db.Store, ctx context.Context) *LocationController {
+	return &LocationController{db, ctx}
+}
+
+// findLocation responds with an error and returns false if an optional location does not exist
+func findLocation(ctx *gin.Context, q *db.Queries, location uuid.NullUUID) bool {
+	if !location.Valid {
+		return true
+	}
+
+	if _, err := q.GetLocationById(ctx, location.UUID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Location not found", Error: err.Error()})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Location", Error: err.Error()})
+		return false
+	}
+	return true
+}
+
+// @Summary Create a new storage location
+// @Description Create a new location stock is stored at, e.g. a cellar or a fridge
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CreateLocation true "CreateLocation payload"
+// @Success 200 {object} db.Location "Location data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Router /location [post]
+func (cc *LocationController) CreateLocation(ctx *gin.Context) {
+	var payload *schemas.CreateLocation
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	Location, err := cc.db.CreateLocation(ctx, payload.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create Location", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Location)
+}
+
+// @Summary Update a storage location
+// @Description Update a location by the provided id and details
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param locationId path string true "Location ID"
+// @Param payload body schemas.UpdateLocation true "UpdateLocation payload"
+// @Success 200 {object} db.Location "Location data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Location not found"
+// @Router /location/{locationId} [patch]
+func (cc *LocationController) UpdateLocation(ctx *gin.Context) {
+	var payload *schemas.UpdateLocation
+	LocationId := ctx.Param("locationId")
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	Location, err := cc.db.UpdateLocation(ctx, db.UpdateLocationParams{Uuid: uuid.MustParse(LocationId), Name: payload.Name})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Location not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to update Location", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Location)
+}
+
+// @Summary Make a storage location the default
+// @Description The default location is used by sales of terminals without a location and by deliveries, corrections and stocktakes without one
+// @Tags Locations
+// @Produce json
+// @Param locationId path string true "Location ID"
+// @Success 200 {object} db.Location "Location data"
+// @Failure 404 {object} e.ErrorResponse "Location not found"
+// @Router /location/{locationId}/default [post]
+func (cc *LocationController) SetDefaultLocation(ctx *gin.Context) {
+	LocationId := ctx.Param("locationId")
+
+	Location, err := cc.db.SetDefaultLocationTx(ctx, uuid.MustParse(LocationId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Location not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to update Location", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Location)
+}
+
+// @Summary Retrieve a storage location by ID
+// @Description Retrieve a location by the provided ID
+// @Tags Locations
+// @Produce json
+// @Param locationId path string true "Location ID"
+// @Success 200 {object} db.Location "Location data"
+// @Failure 404 {object} e.ErrorResponse "Location not found"
+// @Router /location/{locationId} [get]
+func (cc *LocationController) GetLocationById(ctx *gin.Context) {
+	LocationId := ctx.Param("locationId")
+
+	Location, err := cc.db.GetLocationById(ctx, uuid.MustParse(LocationId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Location not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Location", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Location)
+}
+
+// @Summary Retrieve all storage locations
+// @Description Retrieve a list of all locations ordered by name
+// @Tags Locations
+// @Produce json
+// @Success 200 {array} db.Location "List of locations"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /location [get]
+func (cc *LocationController) GetAllLocations(ctx *gin.Context) {
+	Locations, err := cc.db.GetLocations(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Locations", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Locations)
+}
+
+// @Summary Delete a storage location by ID
+// @Description Delete a location that stores no stock, the default location can not be deleted
+// @Tags Locations
+// @Produce json
+// @Param locationId path string true "Location ID"
+// @Success 204 "Location deleted successfully"
+// @Failure 404 {object} e.ErrorResponse "Location not found"
+// @Failure 409 {object} e.ErrorResponse "Location is the default or stores stock"
+// @Router /location/{locationId} [delete]
+func (cc *LocationController) DeleteLocationById(ctx *gin.Context) {
+	LocationId := ctx.Param("locationId")
+
+	Location, err := cc.db.GetLocationById(ctx, uuid.MustParse(LocationId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Location not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Location", Error: err.Error()})
+		return
+	}
+
+	if Location.IsDefault {
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.NotDeletable, Message: "Location is the default location", Error: "location " + LocationId + " is the default location"})
+		return
+	}
+
+	// the stock would be lost, it has to be transferred first
+	hasStock, err := cc.db.HasLocationStock(ctx, Location.Uuid)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Stock", Error: err.Error()})
+		return
+	}
+	if hasStock {
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.NotDeletable, Message: "Location stores stock", Error: "location " + LocationId + " stores stock"})
+		return
+	}
+
+	err = cc.db.DeleteLocation(ctx, Location.Uuid)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Location", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Transfer stock between storage locations
+// @Description Move units of an article from one location to another, e.g. from the cellar to a bar fridge.
+// @Description The total stock of the article does not change. A location can not give more units than it stores.
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param payload body schemas.TransferStock true "TransferStock payload"
+// @Success 200 {object} db.StockTransfer "Stock transfer"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Article or location not found"
+// @Failure 422 {object} e.ErrorResponse "Transfer exceeds the stock at the location"
+// @Router /location/transfer [post]
+func (cc *LocationController) TransferStock(ctx *gin.Context) {
+	var payload *schemas.TransferStock
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if payload.FromLocationUuid == payload.ToLocationUuid {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "the locations of a transfer have to differ"})
+		return
+	}
+
+	if _, err := cc.db.GetArticleById(ctx, payload.ArticleUuid); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
+		return
+	}
+
+	for _, location := range []uuid.UUID{payload.FromLocationUuid, payload.ToLocationUuid} {
+		if !findLocation(ctx, cc.db.Queries, uuid.NullUUID{UUID: location, Valid: true}) {
+			return
+		}
+	}
+
+	result, err := cc.db.TransferStockTx(ctx, db.TransferStockTxParams{
+		ArticleUuid:      payload.ArticleUuid,
+		FromLocationUuid: payload.FromLocationUuid,
+		ToLocationUuid:   payload.ToLocationUuid,
+		Quantity:         payload.Quantity,
+		Note:             payload.Note,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrTransferExceedsStock) {
+			ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.TransferExceeded, Message: "Transfer exceeds the stock at the location", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to transfer Stock", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Retrieve the stock transfers
+// @Description Retrieve a list of all stock transfers, newest first, optionally only those of an article or from or to a location
+// @Tags Locations
+// @Produce json
+// @Param article_uuid query string false "Article ID"
+// @Param location_uuid query string false "Location ID"
+// @Success 200 {array} db.StockTransfer "List of stock transfers"
+// @Failure 400 {object} e.ErrorResponse "Invalid Filter"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /location/transfer [get]
+func (cc *LocationController) GetStockTransfers(ctx *gin.Context) {
+	var filter schemas.StockTransferFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	var args db.GetStockTransfersParams
+	if filter.ArticleUuid != "" {
+		args.ArticleUuid = uuid.NullUUID{UUID: uuid.MustParse(filter.ArticleUuid), Valid: true}
+	}
+	if filter.LocationUuid != "" {
+		args.LocationUuid = uuid.NullUUID{UUID: uuid.MustParse(filter.LocationUuid), Valid: true}
+	}
+
+	Transfers, err := cc.db.GetStockTransfers(ctx, args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Stock Transfers", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Transfers)
+}
+
+// @Summary Retrieve the stock report
+// @Description Retrieve the stock of every article broken down by the locations that store it. Bundles have no stock of their own.
+// @Tags Locations
+// @Produce json
+// @Success 200 {array} db.ArticleStockReport "Stock per article and location"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /location/stock [get]
+func (cc *LocationController) GetStockReport(ctx *gin.Context) {
+	result, err := cc.db.GetStockReport(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Stock", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
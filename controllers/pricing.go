@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/gin-gonic/gin"
+)
+
+// priceItems looks up every article of the cart and returns the lines with
+// their server side unit price. Clients never decide what something costs,
+// prices are resolved by the current price list. Archived articles are off
+// the menu and can not be sold.
+func priceItems(ctx context.Context, q *db.Queries, items []schemas.CheckoutItem) ([]db.CheckoutLine, error) {
+	prices, err := q.GetCurrentPriceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]db.CheckoutLine, 0, len(items))
+	for _, item := range items {
+		article, err := q.GetArticleById(ctx, item.ArticleUuid)
+		if err != nil {
+			return nil, err
+		}
+
+		if article.ArchivedAt.Valid {
+			return nil, fmt.Errorf("%w: %s", db.ErrArticleArchived, article.Name)
+		}
+
+		vatRate, err := q.GetVatRate(ctx, article)
+		if err != nil {
+			return nil, err
+		}
+
+		price, rule := prices.Price(article)
+		lines = append(lines, db.CheckoutLine{ArticleUuid: article.Uuid, Amount: item.Amount, Price: price, PricingRuleUuid: rule, Deposit: article.Deposit, VatRate: vatRate})
+	}
+
+	return lines, nil
+}
+
+// respondPriceItemsError writes the response for an error of priceItems
+func respondPriceItemsError(ctx *gin.Context, err error) {
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+	case errors.Is(err, db.ErrArticleArchived):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.ArticleArchived, Message: "Article is archived", Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
+	}
+}
+
+// linesTotal sums up the price of all lines including their deposits
+func linesTotal(lines []db.CheckoutLine) util.Money {
+	var total util.Money
+	for _, line := range lines {
+		total += line.Total()
+	}
+	return total
+}
+
+// checkPrice compares a price sent by the client with the one calculated by
+// the server. Clients may omit the price, but if they send one it has to match.
+func checkPrice(expected util.NullMoney, actual util.Money) error {
+	if !expected.Valid {
+		return nil
+	}
+
+	if expected.Money != actual {
+		return fmt.Errorf("price mismatch: client sent %s, server calculated %s", expected.Money, actual)
+	}
+
+	return nil
+}
+
+// splitAmounts returns the amount every share of a split bill pays
+func splitAmounts(mode string, shares []schemas.SplitShare, lines []db.CheckoutLine, total util.Money) ([]util.Money, error) {
+	amounts := make([]util.Money, len(shares))
+
+	switch mode {
+	case "even":
+		count := util.Money(len(shares))
+		for i := range shares {
+			amounts[i] = total / count
+			if util.Money(i) < total%count {
+				amounts[i]++
+			}
+		}
+	case "amounts":
+		var sum util.Money
+		for i, share := range shares {
+			if !share.Amount.Valid || share.Amount.Money <= 0 {
+				return nil, fmt.Errorf("share %d has no positive amount", i)
+			}
+			amounts[i] = share.Amount.Money
+			sum += share.Amount.Money
+		}
+		if sum != total {
+			return nil, fmt.Errorf("shares add up to %s, the total is %s", sum, total)
+		}
+	case "items":
+		assigned := make([]bool, len(lines))
+		for i, share := range shares {
+			for _, item := range share.Items {
+				if item < 0 || item >= len(lines) {
+					return nil, fmt.Errorf("share %d references unknown item %d", i, item)
+				}
+				if assigned[item] {
+					return nil, fmt.Errorf("item %d is assigned twice", item)
+				}
+				assigned[item] = true
+				amounts[i] += lines[item].Total()
+			}
+		}
+		for item, ok := range assigned {
+			if !ok {
+				return nil, fmt.Errorf("item %d is not assigned", item)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown split mode %q", mode)
+	}
+
+	for i, amount := range amounts {
+		if amount <= 0 {
+			return nil, fmt.Errorf("share %d pays nothing", i)
+		}
+	}
+
+	return amounts, nil
+}
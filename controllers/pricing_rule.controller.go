@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type PricingRuleController struct {
+	db  *db.Queries
+	ctx context.Context
+}
+
+func NewPricingRuleController(db *db.Queries, ctx context.Context) *PricingRuleController {
+	return &PricingRuleController{db, ctx}
+}
+
+// validPricingRule checks the ranges of the optional window and discount of a rule
+func validPricingRule(weekdays, startsAt, endsAt, percentOff null.Int32, fixedPrice util.NullMoney) error {
+	switch {
+	case weekdays.Valid && (weekdays.Int32 < 1 || weekdays.Int32 > 127):
+		return fmt.Errorf("weekdays has to be a bitmask between 1 and 127")
+	case startsAt.Valid && (startsAt.Int32 < 0 || startsAt.Int32 > 24*60):
+		return fmt.Errorf("starts_at has to be between 0 and 1440 minutes")
+	case endsAt.Valid && (endsAt.Int32 < 0 || endsAt.Int32 > 24*60):
+		return fmt.Errorf("ends_at has to be between 0 and 1440 minutes")
+	case percentOff.Valid && (percentOff.Int32 < 0 || percentOff.Int32 > 100):
+		return fmt.Errorf("percent_off has to be between 0 and 100")
+	case fixedPrice.Valid && fixedPrice.Money < 0:
+		return fmt.Errorf("fixed_price can not be negative")
+	case fixedPrice.Valid && percentOff.Valid:
+		return fmt.Errorf("a rule is either a fixed price or a discount")
+	}
+	return nil
+}
+
+// @Summary Create a new pricing rule
+// @Description Create a rule overriding the resell price, scoped to an article, an article type and/or an event.
+// @Description The rule can be limited to weekdays and a time window and is either a fixed price or a discount in percent.
+// @Description Of all matching rules the one with the highest priority wins, then the most specific one, then the cheapest.
+// @Tags PricingRules
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CreatePricingRule true "CreatePricingRule payload"
+// @Success 200 {object} db.PricingRule "PricingRule data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Router /pricing-rule [post]
+func (cc *PricingRuleController) CreatePricingRule(ctx *gin.Context) {
+	var payload *schemas.CreatePricingRule
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if err := validPricingRule(payload.Weekdays, payload.StartsAt, payload.EndsAt, payload.PercentOff, payload.FixedPrice); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+	if !payload.FixedPrice.Valid && !payload.PercentOff.Valid {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "either fixed_price or percent_off is required"})
+		return
+	}
+
+	args := &db.CreatePricingRuleParams{
+		Name:            payload.Name,
+		ArticleUuid:     payload.ArticleUuid,
+		ArticleTypeUuid: payload.ArticleTypeUuid,
+		EventUuid:       payload.EventUuid,
+		Weekdays:        payload.Weekdays,
+		StartsAt:        payload.StartsAt,
+		EndsAt:          payload.EndsAt,
+		FixedPrice:      payload.FixedPrice,
+		PercentOff:      payload.PercentOff,
+		Priority:        payload.Priority,
+	}
+
+	PricingRule, err := cc.db.CreatePricingRule(ctx, *args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create PricingRule", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PricingRule)
+}
+
+// @Summary Update a pricing rule
+// @Description Update a pricing rule by the provided id and details, setting a fixed price clears the discount and the other way round
+// @Tags PricingRules
+// @Accept json
+// @Produce json
+// @Param id path string true "PricingRule ID"
+// @Param payload body schemas.UpdatePricingRule true "UpdatePricingRule payload"
+// @Success 200 {object} db.PricingRule "PricingRule data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "PricingRule not found"
+// @Router /pricing-rule/{id} [patch]
+func (cc *PricingRuleController) UpdatePricingRule(ctx *gin.Context) {
+	var payload *schemas.UpdatePricingRule
+	PricingRuleId := ctx.Param("pricingRuleId")
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if err := validPricingRule(payload.Weekdays, payload.StartsAt, payload.EndsAt, payload.PercentOff, payload.FixedPrice); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	args := &db.UpdatePricingRuleParams{
+		Uuid:       uuid.MustParse(PricingRuleId),
+		Name:       payload.Name,
+		Weekdays:   payload.Weekdays,
+		StartsAt:   payload.StartsAt,
+		EndsAt:     payload.EndsAt,
+		FixedPrice: payload.FixedPrice,
+		PercentOff: payload.PercentOff,
+		Priority:   payload.Priority,
+	}
+
+	PricingRule, err := cc.db.UpdatePricingRule(ctx, *args)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "PricingRule not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to update PricingRule", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PricingRule)
+}
+
+// @Summary Retrieve a pricing rule by ID
+// @Description Retrieve a pricing rule by the provided ID
+// @Tags PricingRules
+// @Accept json
+// @Produce json
+// @Param id path string true "PricingRule ID"
+// @Success 200 {object} db.PricingRule "PricingRule data"
+// @Failure 404 {object} e.ErrorResponse "PricingRule not found"
+// @Router /pricing-rule/{id} [get]
+func (cc *PricingRuleController) GetPricingRuleById(ctx *gin.Context) {
+	PricingRuleId := ctx.Param("pricingRuleId")
+
+	PricingRule, err := cc.db.GetPricingRuleById(ctx, uuid.MustParse(PricingRuleId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "PricingRule not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve PricingRule", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PricingRule)
+}
+
+// @Summary Retrieve all pricing rules
+// @Description Retrieve a list of all pricing rules, the highest priority first
+// @Tags PricingRules
+// @Accept json
+// @Produce json
+// @Success 200 {array} db.PricingRule "List of pricing rules"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /pricing-rule [get]
+func (cc *PricingRuleController) GetAllPricingRules(ctx *gin.Context) {
+	PricingRules, err := cc.db.GetPricingRules(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve PricingRules", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PricingRules)
+}
+
+// @Summary Delete a pricing rule by ID
+// @Description Delete a pricing rule by the provided ID, past sales keep their price
+// @Tags PricingRules
+// @Accept json
+// @Produce json
+// @Param id path string true "PricingRule ID"
+// @Success 204 "PricingRule deleted successfully"
+// @Failure 404 {object} e.ErrorResponse "PricingRule not found"
+// @Router /pricing-rule/{id} [delete]
+func (cc *PricingRuleController) DeletePricingRuleById(ctx *gin.Context) {
+	PricingRuleId := ctx.Param("pricingRuleId")
+
+	_, err := cc.db.GetPricingRuleById(ctx, uuid.MustParse(PricingRuleId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "PricingRule not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve PricingRule", Error: err.Error()})
+		return
+	}
+
+	err = cc.db.DeletePricingRule(ctx, uuid.MustParse(PricingRuleId))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete PricingRule", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+func TestSplitAmounts(t *testing.T) {
+	lines := []db.CheckoutLine{
+		{Amount: 2, Price: 250},              // 5.00
+		{Amount: 1, Price: 300, Deposit: 15}, // 3.15
+	}
+	total := linesTotal(lines)
+
+	tests := []struct {
+		name   string
+		mode   string
+		shares []schemas.SplitShare
+		total  util.Money
+		want   []util.Money
+		err    bool
+	}{
+		{
+			name:   "even without remainder",
+			mode:   "even",
+			shares: make([]schemas.SplitShare, 2),
+			total:  1000,
+			want:   []util.Money{500, 500},
+		},
+		{
+			name:   "even remainder goes to the first shares",
+			mode:   "even",
+			shares: make([]schemas.SplitShare, 3),
+			total:  1001,
+			want:   []util.Money{334, 334, 333},
+		},
+		{
+			name:   "even with more shares than cents",
+			mode:   "even",
+			shares: make([]schemas.SplitShare, 3),
+			total:  2,
+			err:    true,
+		},
+		{
+			name:   "amounts adding up",
+			mode:   "amounts",
+			shares: []schemas.SplitShare{{Amount: util.NullMoneyFrom(600)}, {Amount: util.NullMoneyFrom(215)}},
+			total:  total,
+			want:   []util.Money{600, 215},
+		},
+		{
+			name:   "amounts not adding up",
+			mode:   "amounts",
+			shares: []schemas.SplitShare{{Amount: util.NullMoneyFrom(600)}, {Amount: util.NullMoneyFrom(200)}},
+			total:  total,
+			err:    true,
+		},
+		{
+			name:   "amounts without amount",
+			mode:   "amounts",
+			shares: []schemas.SplitShare{{Amount: util.NullMoneyFrom(815)}, {}},
+			total:  total,
+			err:    true,
+		},
+		{
+			name:   "items with deposit",
+			mode:   "items",
+			shares: []schemas.SplitShare{{Items: []int{0}}, {Items: []int{1}}},
+			total:  total,
+			want:   []util.Money{500, 315},
+		},
+		{
+			name:   "items assigned twice",
+			mode:   "items",
+			shares: []schemas.SplitShare{{Items: []int{0, 1}}, {Items: []int{1}}},
+			total:  total,
+			err:    true,
+		},
+		{
+			name:   "items not assigned",
+			mode:   "items",
+			shares: []schemas.SplitShare{{Items: []int{0}}, {Items: []int{}}},
+			total:  total,
+			err:    true,
+		},
+		{
+			name:   "unknown mode",
+			mode:   "random",
+			shares: make([]schemas.SplitShare, 2),
+			total:  total,
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitAmounts(tt.mode, tt.shares, lines, tt.total)
+			if tt.err {
+				if err == nil {
+					t.Errorf("splitAmounts() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitAmounts() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type PurchaseOrderController struct {
+	db  *db.Store
+	ctx context.Context
+}
+
+func NewPurchaseOrderController(db *db.Store, ctx context.Context) *PurchaseOrderController {
+	return &PurchaseOrderController{db, ctx}
+}
+
+// @Summary Create a purchase order
+// @Description Order articles from a supplier at an agreed cost per unit. The order is open until all of its lines are received.
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CreatePurchaseOrder true "CreatePurchaseOrder payload"
+// @Success 200 {object} db.PurchaseOrderReport "Purchase order with its lines"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Supplier or article not found"
+// @Router /purchase-order [post]
+func (cc *PurchaseOrderController) CreatePurchaseOrder(ctx *gin.Context) {
+	var payload *schemas.CreatePurchaseOrder
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if _, err := cc.db.GetSupplierById(ctx, payload.SupplierUuid); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Supplier not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Supplier", Error: err.Error()})
+		return
+	}
+
+	lines := make([]db.CreatePurchaseOrderLineParams, 0, len(payload.Lines))
+	for _, line := range payload.Lines {
+		if line.UnitCost < 0 {
+			ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "unit_cost can not be negative"})
+			return
+		}
+
+		if _, err := cc.db.GetArticleById(ctx, line.ArticleUuid); err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Article not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Article", Error: err.Error()})
+			return
+		}
+
+		lines = append(lines, db.CreatePurchaseOrderLineParams{ArticleUuid: line.ArticleUuid, Quantity: line.Quantity, UnitCost: line.UnitCost})
+	}
+
+	result, err := cc.db.CreatePurchaseOrderTx(ctx, db.CreatePurchaseOrderTxParams{
+		SupplierUuid: payload.SupplierUuid,
+		Note:         payload.Note,
+		Lines:        lines,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create Purchase Order", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Retrieve all purchase orders
+// @Description Retrieve a list of all purchase orders, newest first, optionally only those of a supplier or in a status
+// @Tags PurchaseOrders
+// @Produce json
+// @Param supplier_uuid query string false "Supplier ID"
+// @Param status query string false "open, partially_received, received or cancelled"
+// @Success 200 {array} db.PurchaseOrder "List of purchase orders"
+// @Failure 400 {object} e.ErrorResponse "Invalid Filter"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /purchase-order [get]
+func (cc *PurchaseOrderController) GetAllPurchaseOrders(ctx *gin.Context) {
+	var filter schemas.PurchaseOrderFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	var args db.GetPurchaseOrdersParams
+	if filter.SupplierUuid != "" {
+		args.SupplierUuid = uuid.NullUUID{UUID: uuid.MustParse(filter.SupplierUuid), Valid: true}
+	}
+	if filter.Status != "" {
+		args.Status = null.StringFrom(filter.Status)
+	}
+
+	PurchaseOrders, err := cc.db.GetPurchaseOrders(ctx, args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Purchase Orders", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, PurchaseOrders)
+}
+
+// @Summary Retrieve a purchase order by ID
+// @Description Retrieve a purchase order with its lines and the goods received for it
+// @Tags PurchaseOrders
+// @Produce json
+// @Param purchaseOrderId path string true "Purchase Order ID"
+// @Success 200 {object} db.PurchaseOrderReport "Purchase order with its lines and receipts"
+// @Failure 404 {object} e.ErrorResponse "Purchase order not found"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /purchase-order/{purchaseOrderId} [get]
+func (cc *PurchaseOrderController) GetPurchaseOrderById(ctx *gin.Context) {
+	PurchaseOrderId := uuid.MustParse(ctx.Param("purchaseOrderId"))
+
+	result, err := cc.db.GetPurchaseOrderReport(ctx, PurchaseOrderId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Purchase Order not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Purchase Order", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Receive goods for a purchase order
+// @Description Book the goods that arrived for an open purchase order, a delivery may cover only part of the order.
+// @Description The units are added to the stock at the location. The purchase price of every article becomes the weighted average
+// @Description of the units in stock and the received units at the cost actually paid, past sales keep their cost.
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Param purchaseOrderId path string true "Purchase Order ID"
+// @Param payload body schemas.ReceiveGoods true "ReceiveGoods payload"
+// @Success 200 {object} db.GoodsReceiptReport "Goods receipt with its lines"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Purchase order or location not found"
+// @Failure 409 {object} e.ErrorResponse "Purchase order is not open"
+// @Failure 422 {object} e.ErrorResponse "Receipt exceeds the ordered quantity"
+// @Router /purchase-order/{purchaseOrderId}/receipt [post]
+func (cc *PurchaseOrderController) ReceiveGoods(ctx *gin.Context) {
+	var payload *schemas.ReceiveGoods
+	PurchaseOrderId := uuid.MustParse(ctx.Param("purchaseOrderId"))
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if !findLocation(ctx, cc.db.Queries, payload.LocationUuid) {
+		return
+	}
+
+	lines := make([]db.ReceiveGoodsLine, 0, len(payload.Lines))
+	for _, line := range payload.Lines {
+		if line.UnitCost.Valid && line.UnitCost.Money < 0 {
+			ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "unit_cost can not be negative"})
+			return
+		}
+		lines = append(lines, db.ReceiveGoodsLine{PurchaseOrderLineUuid: line.PurchaseOrderLineUuid, Quantity: line.Quantity, UnitCost: line.UnitCost})
+	}
+
+	result, err := cc.db.ReceiveGoodsTx(ctx, db.ReceiveGoodsTxParams{
+		PurchaseOrderUuid: PurchaseOrderId,
+		LocationUuid:      payload.LocationUuid,
+		Note:              payload.Note,
+		Lines:             lines,
+	})
+	if err != nil {
+		cc.respondPurchaseOrderError(ctx, err, "Failed to receive goods")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Cancel a purchase order
+// @Description Cancel an open purchase order, goods that were received already stay in the stock
+// @Tags PurchaseOrders
+// @Produce json
+// @Param purchaseOrderId path string true "Purchase Order ID"
+// @Success 200 {object} db.PurchaseOrder "Cancelled purchase order"
+// @Failure 404 {object} e.ErrorResponse "Purchase order not found"
+// @Failure 409 {object} e.ErrorResponse "Purchase order is not open"
+// @Router /purchase-order/{purchaseOrderId}/cancel [post]
+func (cc *PurchaseOrderController) CancelPurchaseOrder(ctx *gin.Context) {
+	PurchaseOrderId := uuid.MustParse(ctx.Param("purchaseOrderId"))
+
+	result, err := cc.db.CancelPurchaseOrderTx(ctx, PurchaseOrderId)
+	if err != nil {
+		cc.respondPurchaseOrderError(ctx, err, "Failed to cancel Purchase Order")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (cc *PurchaseOrderController) respondPurchaseOrderError(ctx *gin.Context, err error, message string) {
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Purchase Order not found", Error: err.Error()})
+	case errors.Is(err, db.ErrPurchaseOrderNotOpen):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PurchaseOrderNotOpen, Message: "Purchase Order is not open", Error: err.Error()})
+	case errors.Is(err, db.ErrReceiptExceedsOrder):
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.ReceiptExceeded, Message: "Receipt exceeds the ordered quantity", Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: message, Error: err.Error()})
+	}
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+)
+
+type ResidentGroupController struct {
+	db  *db.Queries
+	ctx context.Context
+}
+
+func NewResidentGroupController(db *db.Queries, ctx context.Context) *ResidentGroupController {
+	return &ResidentGroupController{db, ctx}
+}
+
+// @Summary Create a new resident group
+// @Description Create a group of residents sharing a credit policy, the minimum balance and daily cap are optional
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CreateResidentGroup true "CreateResidentGroup payload"
+// @Success 200 {object} db.ResidentGroup "Group data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Router /group [post]
+func (cc *ResidentGroupController) CreateResidentGroup(ctx *gin.Context) {
+	var payload *schemas.CreateResidentGroup
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	args := &db.CreateResidentGroupParams{
+		Name:       payload.Name,
+		MinBalance: payload.MinBalance,
+		DailyCap:   payload.DailyCap,
+	}
+
+	Group, err := cc.db.CreateResidentGroup(ctx, *args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create Group", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Group)
+}
+
+// @Summary Update a resident group
+// @Description Update the credit policy of a group
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param name path string true "Group name"
+// @Param payload body schemas.UpdateResidentGroup true "UpdateResidentGroup payload"
+// @Success 200 {object} db.ResidentGroup "Group data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Group not found"
+// @Router /group/{name} [patch]
+func (cc *ResidentGroupController) UpdateResidentGroup(ctx *gin.Context) {
+	var payload *schemas.UpdateResidentGroup
+	GroupName := ctx.Param("name")
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	args := &db.UpdateResidentGroupParams{
+		Name:       GroupName,
+		MinBalance: payload.MinBalance,
+		DailyCap:   payload.DailyCap,
+	}
+
+	Group, err := cc.db.UpdateResidentGroup(ctx, *args)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Group not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to update Group", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Group)
+}
+
+// @Summary Retrieve a resident group by name
+// @Description Retrieve a resident group by the provided name
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param name path string true "Group name"
+// @Success 200 {object} db.ResidentGroup "Group data"
+// @Failure 404 {object} e.ErrorResponse "Group not found"
+// @Router /group/{name} [get]
+func (cc *ResidentGroupController) GetResidentGroupById(ctx *gin.Context) {
+	GroupName := ctx.Param("name")
+
+	Group, err := cc.db.GetResidentGroupById(ctx, GroupName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Group not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Group", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Group)
+}
+
+// @Summary Retrieve all resident groups
+// @Description Retrieve a list of all resident groups
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Success 200 {array} db.ResidentGroup "List of groups"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /group [get]
+func (cc *ResidentGroupController) GetAllResidentGroups(ctx *gin.Context) {
+	Groups, err := cc.db.GetResidentGroups(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Groups", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Groups)
+}
+
+// @Summary Delete a resident group by name
+// @Description Delete a resident group, its residents keep only their own limits
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param name path string true "Group name"
+// @Success 204 "Group deleted successfully"
+// @Failure 404 {object} e.ErrorResponse "Group not found"
+// @Router /group/{name} [delete]
+func (cc *ResidentGroupController) DeleteResidentGroupById(ctx *gin.Context) {
+	GroupName := ctx.Param("name")
+
+	_, err := cc.db.GetResidentGroupById(ctx, GroupName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Group not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Group", Error: err.Error()})
+		return
+	}
+
+	err = cc.db.DeleteResidentGroup(ctx, GroupName)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Group", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ShiftController struct {
+	db  *db.Store
+	ctx context.Context
+}
+
+func NewShiftController(db *db.Store, ctx context.Context) *ShiftController {
+	return &ShiftController{db, ctx}
+}
+
+// @Summary Open a shift
+// @Description Open a bartender shift with the cash float put into the drawer. The drawer belongs to the terminal,
+// @Description every drawer has at most one open shift. The shift is linked to the given event or the one running now.
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param payload body schemas.OpenShift true "OpenShift payload"
+// @Success 200 {object} db.Shift "Shift data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Terminal or event not found"
+// @Failure 409 {object} e.ErrorResponse "Drawer already has an open shift"
+// @Router /shift/open [post]
+func (cc *ShiftController) OpenShift(ctx *gin.Context) {
+	var payload *schemas.OpenShift
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if payload.OpeningFloat < 0 {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "opening_float can not be negative"})
+		return
+	}
+
+	if payload.TerminalUuid.Valid {
+		if _, err := cc.db.GetTerminalById(ctx, payload.TerminalUuid.UUID); err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Terminal not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Terminal", Error: err.Error()})
+			return
+		}
+	}
+
+	if payload.EventUuid.Valid {
+		if _, err := cc.db.GetEventById(ctx, payload.EventUuid.UUID); err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Event not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Event", Error: err.Error()})
+			return
+		}
+	}
+
+	existing, err := cc.db.GetOpenShiftByTerminal(ctx, payload.TerminalUuid)
+	if err == nil {
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.ShiftAlreadyOpen, Message: "Drawer already has an open shift", Error: "shift " + existing.Uuid.String() + " of " + existing.Bartender + " is open"})
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Shift", Error: err.Error()})
+		return
+	}
+
+	Shift, err := cc.db.OpenShift(ctx, db.OpenShiftParams{
+		Bartender:    payload.Bartender,
+		TerminalUuid: payload.TerminalUuid,
+		EventUuid:    payload.EventUuid,
+		OpeningFloat: payload.OpeningFloat,
+		Date:         time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to open Shift", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Shift)
+}
+
+// @Summary Close a shift
+// @Description Close a shift with the cash counted in the drawer per denomination. The expected cash is the opening float
+// @Description plus the cash transactions of the terminal during the shift, the difference is counted minus expected.
+// @Tags Shifts
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CloseShift true "CloseShift payload"
+// @Success 200 {object} db.ShiftReport "Closed shift with its counts"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload or denomination"
+// @Failure 404 {object} e.ErrorResponse "Shift not found"
+// @Failure 409 {object} e.ErrorResponse "Shift is not open"
+// @Router /shift/close [post]
+func (cc *ShiftController) CloseShift(ctx *gin.Context) {
+	var payload *schemas.CloseShift
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	// the same note may be counted in several batches
+	counts := make([]db.CreateShiftCountParams, 0, len(payload.Counts))
+	merged := make(map[util.Money]int)
+	for _, count := range payload.Counts {
+		if i, ok := merged[count.Denomination]; ok {
+			counts[i].Count += count.Count
+			continue
+		}
+		merged[count.Denomination] = len(counts)
+		counts = append(counts, db.CreateShiftCountParams{Denomination: count.Denomination, Count: count.Count})
+	}
+
+	result, err := cc.db.CloseShiftTx(ctx, db.CloseShiftTxParams{
+		ShiftUuid: payload.ShiftUuid,
+		Counts:    counts,
+		Date:      time.Now(),
+	})
+	switch {
+	case err == nil:
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Shift not found", Error: err.Error()})
+		return
+	case errors.Is(err, db.ErrInvalidDenomination):
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid denomination", Error: err.Error()})
+		return
+	case errors.Is(err, db.ErrShiftNotOpen):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.ShiftNotOpen, Message: "Shift is not open", Error: err.Error()})
+		return
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to close Shift", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Retrieve all shifts
+// @Description Retrieve a list of all shifts, optionally only those of an event or only the open ones
+// @Tags Shifts
+// @Produce json
+// @Param event_uuid query string false "Event ID"
+// @Param open query bool false "Only open shifts"
+// @Success 200 {array} db.Shift "List of shifts"
+// @Failure 400 {object} e.ErrorResponse "Invalid Filter"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /shift [get]
+func (cc *ShiftController) GetAllShifts(ctx *gin.Context) {
+	var filter schemas.ShiftFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	args := db.GetShiftsParams{Open: filter.Open}
+	if filter.EventUuid != "" {
+		args.EventUuid = uuid.NullUUID{UUID: uuid.MustParse(filter.EventUuid), Valid: true}
+	}
+
+	Shifts, err := cc.db.GetShifts(ctx, args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Shifts", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Shifts)
+}
+
+// @Summary Retrieve a shift by ID
+// @Description Retrieve a shift with its counted denominations, for open shifts the expected cash is the drawer as of now
+// @Tags Shifts
+// @Produce json
+// @Param shiftId path string true "Shift ID"
+// @Success 200 {object} db.ShiftReport "Shift with its counts"
+// @Failure 404 {object} e.ErrorResponse "Shift not found"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /shift/{shiftId} [get]
+func (cc *ShiftController) GetShiftById(ctx *gin.Context) {
+	ShiftId := uuid.MustParse(ctx.Param("shiftId"))
+
+	result, err := cc.db.GetShiftReport(ctx, ShiftId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Shift not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Shift", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+)
+
+type StockAlertController struct {
+	db  *db.Queries
+	ctx context.Context
+}
+
+func NewStockAlertController(db *db.Queries, ctx context.Context) *StockAlertController {
+	return &StockAlertController{db, ctx}
+}
+
+// @Summary Retrieve the low stock alerts
+// @Description Retrieve the latest alerts raised when the stock of an article fell below its reorder threshold, newest first.
+// @Description An alert is processed once it was delivered to all channels or its delivery was given up, see last_error.
+// @Tags StockAlerts
+// @Produce json
+// @Param limit query int false "Number of alerts, defaults to 50"
+// @Success 200 {array} db.StockAlert "List of stock alerts"
+// @Failure 400 {object} e.ErrorResponse "Invalid Filter"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /stock-alert [get]
+func (cc *StockAlertController) GetAllStockAlerts(ctx *gin.Context) {
+	var filter schemas.StockAlertFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = 50
+	}
+
+	StockAlerts, err := cc.db.GetStockAlerts(ctx, filter.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Stock Alerts", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StockAlerts)
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StocktakeController struct {
+	db  *db.Store
+	ctx context.Context
+}
+
+func NewStocktakeController(db *db.Store, ctx context.Context) *StocktakeController {
+	return &StocktakeController{db, ctx}
+}
+
+// @Summary Open a stocktake
+// @Description Open a stocktake, the stock and purchase price of every article are snapshot as the expected stock.
+// @Description A stocktake of a location expects the stock at the location, otherwise the total stock of all locations.
+// @Description Bundles have no stock of their own and are not counted. Only one stocktake can be open at a time.
+// @Tags Stocktakes
+// @Accept json
+// @Produce json
+// @Param payload body schemas.OpenStocktake true "OpenStocktake payload"
+// @Success 200 {object} db.StocktakeReport "Opened stocktake with the expected stock"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Location not found"
+// @Failure 409 {object} e.ErrorResponse "A stocktake is already open"
+// @Router /stocktake [post]
+func (cc *StocktakeController) OpenStocktake(ctx *gin.Context) {
+	var payload *schemas.OpenStocktake
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if !findLocation(ctx, cc.db.Queries, payload.LocationUuid) {
+		return
+	}
+
+	existing, err := cc.db.GetOpenStocktake(ctx)
+	if err == nil {
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.StocktakeAlreadyOpen, Message: "A stocktake is already open", Error: "stocktake " + existing.Uuid.String() + " is open"})
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Stocktake", Error: err.Error()})
+		return
+	}
+
+	result, err := cc.db.OpenStocktakeTx(ctx, db.CreateStocktakeParams{Note: payload.Note, LocationUuid: payload.LocationUuid})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to open Stocktake", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Submit counted stock
+// @Description Submit the counted stock of articles, a stocktake can be counted in several partial submissions.
+// @Description A count is added to earlier counts of the article, e.g. for articles stored in several places, unless replace is set.
+// @Tags Stocktakes
+// @Accept json
+// @Produce json
+// @Param stocktakeId path string true "Stocktake ID"
+// @Param payload body schemas.CountStocktake true "CountStocktake payload"
+// @Success 200 {object} db.StocktakeReport "Stocktake with the variance counted so far"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Stocktake or article not found"
+// @Failure 409 {object} e.ErrorResponse "Stocktake is not open"
+// @Router /stocktake/{stocktakeId}/count [post]
+func (cc *StocktakeController) CountStocktake(ctx *gin.Context) {
+	var payload *schemas.CountStocktake
+	StocktakeId := uuid.MustParse(ctx.Param("stocktakeId"))
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	counts := make([]db.StocktakeCount, 0, len(payload.Counts))
+	for _, count := range payload.Counts {
+		counts = append(counts, db.StocktakeCount{ArticleUuid: count.ArticleUuid, Counted: count.Counted, Replace: count.Replace})
+	}
+
+	result, err := cc.db.CountStocktakeTx(ctx, StocktakeId, counts)
+	if err != nil {
+		cc.respondStocktakeError(ctx, err, "Failed to count Stocktake")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Description Close a stocktake and set the stock of every counted article to the counted units, articles that were not counted keep their stock.
+// @Description The count is taken as the stock at closing, units sold while the stocktake was open are not taken out again.
+// @Description Close a stocktake and correct the stock of every counted article by its variance, articles that were not counted keep their stock.
+// @Description The corrections are booked at the location of the stocktake, or at the default location.
+// @Description The report shows the variance in units and at purchase price per article, per article type and in total.
+// @Tags Stocktakes
+// @Produce json
+// @Param stocktakeId path string true "Stocktake ID"
+// @Success 200 {object} db.StocktakeReport "Variance report of the closed stocktake"
+// @Failure 404 {object} e.ErrorResponse "Stocktake not found"
+// @Failure 409 {object} e.ErrorResponse "Stocktake is not open"
+// @Router /stocktake/{stocktakeId}/close [post]
+func (cc *StocktakeController) CloseStocktake(ctx *gin.Context) {
+	StocktakeId := uuid.MustParse(ctx.Param("stocktakeId"))
+
+	result, err := cc.db.CloseStocktakeTx(ctx, StocktakeId)
+	if err != nil {
+		cc.respondStocktakeError(ctx, err, "Failed to close Stocktake")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Retrieve all stocktakes
+// @Description Retrieve a list of all stocktakes, newest first
+// @Tags Stocktakes
+// @Produce json
+// @Success 200 {array} db.Stocktake "List of stocktakes"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /stocktake [get]
+func (cc *StocktakeController) GetAllStocktakes(ctx *gin.Context) {
+	Stocktakes, err := cc.db.GetStocktakes(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Stocktakes", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Stocktakes)
+}
+
+// @Summary Retrieve a stocktake by ID
+// @Description Retrieve a stocktake with its variance report, for open stocktakes the variance counted so far
+// @Tags Stocktakes
+// @Produce json
+// @Param stocktakeId path string true "Stocktake ID"
+// @Success 200 {object} db.StocktakeReport "Stocktake with its variance report"
+// @Failure 404 {object} e.ErrorResponse "Stocktake not found"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /stocktake/{stocktakeId} [get]
+func (cc *StocktakeController) GetStocktakeById(ctx *gin.Context) {
+	StocktakeId := uuid.MustParse(ctx.Param("stocktakeId"))
+
+	result, err := cc.db.GetStocktakeReport(ctx, StocktakeId)
+	if err != nil {
+		cc.respondStocktakeError(ctx, err, "Failed to retrieve Stocktake")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (cc *StocktakeController) respondStocktakeError(ctx *gin.Context, err error, message string) {
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Stocktake or Article not found", Error: err.Error()})
+	case errors.Is(err, db.ErrStocktakeNotOpen):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.StocktakeNotOpen, Message: "Stocktake is not open", Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: message, Error: err.Error()})
+	}
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SupplierController struct {
+	db  *db.Queries
+	ctx context.Context
+}
+
+func NewSupplierController(db *db.Queries, ctx context.Context) *SupplierController {
+	return &SupplierController{db, ctx}
+}
+
+// @Summary Create a new supplier
+// @Description Create a new supplier that articles are ordered from
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CreateSupplier true "CreateSupplier payload"
+// @Success 200 {object} db.Supplier "Supplier data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Router /supplier [post]
+func (cc *SupplierController) CreateSupplier(ctx *gin.Context) {
+	var payload *schemas.CreateSupplier
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	args := &db.CreateSupplierParams{
+		Name:    payload.Name,
+		Contact: payload.Contact,
+		Email:   payload.Email,
+		Phone:   payload.Phone,
+	}
+
+	Supplier, err := cc.db.CreateSupplier(ctx, *args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create Supplier", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Supplier)
+}
+
+// @Summary Update a supplier
+// @Description Update a supplier by the provided id and details
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Param supplierId path string true "Supplier ID"
+// @Param payload body schemas.UpdateSupplier true "UpdateSupplier payload"
+// @Success 200 {object} db.Supplier "Supplier data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Supplier not found"
+// @Router /supplier/{supplierId} [patch]
+func (cc *SupplierController) UpdateSupplier(ctx *gin.Context) {
+	var payload *schemas.UpdateSupplier
+	SupplierId := ctx.Param("supplierId")
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	args := &db.UpdateSupplierParams{
+		Uuid:    uuid.MustParse(SupplierId),
+		Name:    payload.Name,
+		Contact: payload.Contact,
+		Email:   payload.Email,
+		Phone:   payload.Phone,
+	}
+
+	Supplier, err := cc.db.UpdateSupplier(ctx, *args)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Supplier not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to update Supplier", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Supplier)
+}
+
+// @Summary Retrieve a supplier by ID
+// @Description Retrieve a supplier by the provided ID
+// @Tags Suppliers
+// @Produce json
+// @Param supplierId path string true "Supplier ID"
+// @Success 200 {object} db.Supplier "Supplier data"
+// @Failure 404 {object} e.ErrorResponse "Supplier not found"
+// @Router /supplier/{supplierId} [get]
+func (cc *SupplierController) GetSupplierById(ctx *gin.Context) {
+	SupplierId := ctx.Param("supplierId")
+
+	Supplier, err := cc.db.GetSupplierById(ctx, uuid.MustParse(SupplierId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Supplier not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Supplier", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Supplier)
+}
+
+// @Summary Retrieve all suppliers
+// @Description Retrieve a list of all suppliers ordered by name
+// @Tags Suppliers
+// @Produce json
+// @Success 200 {array} db.Supplier "List of suppliers"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /supplier [get]
+func (cc *SupplierController) GetAllSuppliers(ctx *gin.Context) {
+	Suppliers, err := cc.db.GetSuppliers(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Suppliers", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Suppliers)
+}
+
+// @Summary Delete a supplier by ID
+// @Description Delete a supplier that has no purchase orders
+// @Tags Suppliers
+// @Produce json
+// @Param supplierId path string true "Supplier ID"
+// @Success 204 "Supplier deleted successfully"
+// @Failure 404 {object} e.ErrorResponse "Supplier not found"
+// @Failure 409 {object} e.ErrorResponse "Supplier has purchase orders"
+// @Router /supplier/{supplierId} [delete]
+func (cc *SupplierController) DeleteSupplierById(ctx *gin.Context) {
+	SupplierId := ctx.Param("supplierId")
+
+	_, err := cc.db.GetSupplierById(ctx, uuid.MustParse(SupplierId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Supplier not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Supplier", Error: err.Error()})
+		return
+	}
+
+	// the orders are the purchasing history of the articles
+	hasOrders, err := cc.db.HasSupplierPurchaseOrders(ctx, uuid.MustParse(SupplierId))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Purchase Orders", Error: err.Error()})
+		return
+	}
+	if hasOrders {
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.NotDeletable, Message: "Supplier has purchase orders", Error: "supplier " + SupplierId + " has purchase orders"})
+		return
+	}
+
+	err = cc.db.DeleteSupplier(ctx, uuid.MustParse(SupplierId))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Supplier", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/payment"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type TabController struct {
+	db       *db.Store
+	payments *payment.Registry
+	ctx      context.Context
+}
+
+func NewTabController(db *db.Store, payments *payment.Registry, ctx context.Context) *TabController {
+	return &TabController{db, payments, ctx}
+}
+
+// @Summary Open a tab
+// @Description Open a tab for a resident, the orders of the evening are collected on it and settled at once when it is closed.
+// @Description The tab belongs to the event running when it is opened and is closed automatically at the end of the event.
+// @Tags Tabs
+// @Accept json
+// @Produce json
+// @Param payload body schemas.OpenTab true "OpenTab payload"
+// @Success 200 {object} db.Tab "Tab data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Resident or terminal not found"
+// @Failure 409 {object} e.ErrorResponse "Resident already has a tab"
+// @Router /tab [post]
+func (cc *TabController) OpenTab(ctx *gin.Context) {
+	var payload *schemas.OpenTab
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	resident, err := cc.db.GetUserById(ctx, payload.Resident)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Resident not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
+		return
+	}
+
+	existing, err := cc.db.GetUnclosedTabByResident(ctx, resident.Name)
+	if err == nil {
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.TabAlreadyOpen, Message: "Resident already has a tab", Error: "tab " + existing.Uuid.String() + " is " + existing.Status})
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Tab", Error: err.Error()})
+		return
+	}
+
+	backend, ok := resolvePaymentBackend(ctx, cc.db.Queries, cc.payments, &resident, payload.TerminalUuid)
+	if !ok {
+		return
+	}
+
+	Tab, err := cc.db.OpenTab(ctx, db.OpenTabParams{
+		Resident:       resident.Name,
+		TerminalUuid:   payload.TerminalUuid,
+		PaymentBackend: backend.Name(),
+		Date:           time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to open Tab", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Tab)
+}
+
+// @Summary Retrieve all tabs
+// @Description Retrieve a list of all tabs, optionally only those with the given status
+// @Tags Tabs
+// @Produce json
+// @Param status query string false "open, flagged or closed"
+// @Success 200 {array} db.Tab "List of tabs"
+// @Failure 400 {object} e.ErrorResponse "Invalid Filter"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /tab [get]
+func (cc *TabController) GetAllTabs(ctx *gin.Context) {
+	var filter schemas.TabFilter
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
+		return
+	}
+
+	Tabs, err := cc.db.GetTabs(ctx, null.NewString(filter.Status, filter.Status != ""))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Tabs", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Tabs)
+}
+
+// @Summary Retrieve a tab by ID
+// @Description Retrieve a tab with all of its items
+// @Tags Tabs
+// @Produce json
+// @Param tabId path string true "Tab ID"
+// @Success 200 {object} db.TabTxResult "Tab with its items"
+// @Failure 404 {object} e.ErrorResponse "Tab not found"
+// @Router /tab/{tabId} [get]
+func (cc *TabController) GetTabById(ctx *gin.Context) {
+	TabId := uuid.MustParse(ctx.Param("tabId"))
+
+	Tab, err := cc.db.GetTabById(ctx, TabId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Tab not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Tab", Error: err.Error()})
+		return
+	}
+
+	Items, err := cc.db.GetTabItemsByTab(ctx, TabId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Tab items", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, db.TabTxResult{Tab: Tab, Items: Items})
+}
+
+// @Summary Add items to a tab
+// @Description Order items on an open tab. Their current price is reserved until the tab is closed.
+// @Description The items count against the credit of the resident right away.
+// @Tags Tabs
+// @Accept json
+// @Produce json
+// @Param tabId path string true "Tab ID"
+// @Param payload body schemas.AddTabItems true "AddTabItems payload"
+// @Success 200 {object} db.TabTxResult "Tab with its items"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 402 {object} e.InsufficientFundsResponse "Credit policy blocks the items"
+// @Failure 404 {object} e.ErrorResponse "Tab or article not found"
+// @Failure 409 {object} e.ErrorResponse "Tab is not open or article is out of stock or archived"
+// @Failure 422 {object} e.ErrorResponse "Price mismatch"
+// @Router /tab/{tabId}/items [post]
+func (cc *TabController) AddTabItems(ctx *gin.Context) {
+	var payload *schemas.AddTabItems
+	TabId := uuid.MustParse(ctx.Param("tabId"))
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	lines, err := priceItems(ctx, cc.db.Queries, payload.Items)
+	if err != nil {
+		respondPriceItemsError(ctx, err)
+		return
+	}
+
+	if err := checkPrice(payload.Total, linesTotal(lines)); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.PriceMismatch, Message: "Price does not match the server side price", Error: err.Error()})
+		return
+	}
+
+	Tab, err := cc.db.GetTabById(ctx, TabId)
+	if err != nil {
+		cc.respondTabError(ctx, err, "Failed to retrieve Tab")
+		return
+	}
+
+	resident, err := cc.db.GetUserById(ctx, Tab.Resident)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
+		return
+	}
+
+	backend, err := cc.payments.Get(Tab.PaymentBackend)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to resolve the payment backend", Error: err.Error()})
+		return
+	}
+
+	credit, ok := creditCheck(ctx, backend, resident, time.Now())
+	if !ok {
+		return
+	}
+
+	result, err := cc.db.AddTabItemsTx(ctx, TabId, lines, credit)
+	if err != nil {
+		cc.respondTabError(ctx, err, "Failed to add items to Tab")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary Close a tab
+// @Description Settle all items of an open or flagged tab with a single transaction and a single debit
+// @Tags Tabs
+// @Produce json
+// @Param tabId path string true "Tab ID"
+// @Success 200 {object} db.TabTxResult "Closed tab with its items, the tab references the transaction"
+// @Failure 404 {object} e.ErrorResponse "Tab not found"
+// @Failure 409 {object} e.ErrorResponse "Tab is already closed or its business day is closed"
+// @Router /tab/{tabId}/close [post]
+func (cc *TabController) CloseTab(ctx *gin.Context) {
+	TabId := uuid.MustParse(ctx.Param("tabId"))
+
+	result, err := cc.db.CloseTabTx(ctx, db.CloseTabTxParams{TabUuid: TabId, Date: time.Now()})
+	if err != nil {
+		cc.respondTabError(ctx, err, "Failed to close Tab")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (cc *TabController) respondTabError(ctx *gin.Context, err error, message string) {
+	if respondCreditError(ctx, err) {
+		return
+	}
+
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Tab not found", Error: err.Error()})
+	case errors.Is(err, db.ErrTabNotOpen):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.TabNotOpen, Message: "Tab is not open", Error: err.Error()})
+	case errors.Is(err, db.ErrOutOfStock):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.OutOfStock, Message: "Article is out of stock", Error: err.Error()})
+	case errors.Is(err, db.ErrPeriodClosed):
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "The business day is already closed", Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: message, Error: err.Error()})
+	}
+}
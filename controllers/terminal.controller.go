@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/payment"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type TerminalController struct {
+	db  *db.Queries
+	ctx context.Context
+}
+
+func NewTerminalController(db *db.Queries, ctx context.Context) *TerminalController {
+	return &TerminalController{db, ctx}
+}
+
+// validPaymentBackend reports whether an optional backend name is empty or known
+func validPaymentBackend(name null.String) bool {
+	return !name.Valid || payment.IsBackend(name.String)
+}
+
+// @Summary Create a new terminal
+// @Description Create a new bar terminal, an optional payment backend is used for the sales of residents without their own.
+// @Description The sales of the terminal draw from its location, or from the default location.
+// @Tags Terminals
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CreateTerminal true "CreateTerminal payload"
+// @Success 200 {object} db.Terminal "Terminal data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Location not found"
+// @Router /terminal [post]
+func (cc *TerminalController) CreateTerminal(ctx *gin.Context) {
+	var payload *schemas.CreateTerminal
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if !validPaymentBackend(payload.PaymentBackend) {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "unknown payment backend " + payload.PaymentBackend.String})
+		return
+	}
+
+	if !findLocation(ctx, cc.db, payload.LocationUuid) {
+		return
+	}
+
+	args := &db.CreateTerminalParams{
+		Name:           payload.Name,
+		PaymentBackend: payload.PaymentBackend,
+		LocationUuid:   payload.LocationUuid,
+	}
+
+	Terminal, err := cc.db.CreateTerminal(ctx, *args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create Terminal", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Terminal)
+}
+
+// @Summary Update a terminal
+// @Description Update a terminal by the provided id and details
+// @Tags Terminals
+// @Accept json
+// @Produce json
+// @Param id path string true "Terminal ID"
+// @Param payload body schemas.UpdateTerminal true "UpdateTerminal payload"
+// @Success 200 {object} db.Terminal "Terminal data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Terminal or location not found"
+// @Router /terminal/{id} [patch]
+func (cc *TerminalController) UpdateTerminal(ctx *gin.Context) {
+	var payload *schemas.UpdateTerminal
+	TerminalId := ctx.Param("terminalId")
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	if !validPaymentBackend(payload.PaymentBackend) {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "unknown payment backend " + payload.PaymentBackend.String})
+		return
+	}
+
+	if !findLocation(ctx, cc.db, payload.LocationUuid) {
+		return
+	}
+
+	args := &db.UpdateTerminalParams{
+		Uuid:           uuid.MustParse(TerminalId),
+		Name:           payload.Name,
+		PaymentBackend: payload.PaymentBackend,
+		LocationUuid:   payload.LocationUuid,
+	}
+
+	Terminal, err := cc.db.UpdateTerminal(ctx, *args)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Terminal not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to update Terminal", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Terminal)
+}
+
+// @Summary Retrieve a terminal by ID
+// @Description Retrieve a terminal by the provided ID
+// @Tags Terminals
+// @Accept json
+// @Produce json
+// @Param id path string true "Terminal ID"
+// @Success 200 {object} db.Terminal "Terminal data"
+// @Failure 404 {object} e.ErrorResponse "Terminal not found"
+// @Router /terminal/{id} [get]
+func (cc *TerminalController) GetTerminalById(ctx *gin.Context) {
+	TerminalId := ctx.Param("terminalId")
+
+	Terminal, err := cc.db.GetTerminalById(ctx, uuid.MustParse(TerminalId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Terminal not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Terminal", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Terminal)
+}
+
+// @Summary Retrieve all terminals
+// @Description Retrieve a list of all terminals
+// @Tags Terminals
+// @Accept json
+// @Produce json
+// @Success 200 {array} db.Terminal "List of terminals"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /terminal [get]
+func (cc *TerminalController) GetAllTerminals(ctx *gin.Context) {
+	Terminals, err := cc.db.GetTerminals(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Terminals", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Terminals)
+}
+
+// @Summary Delete a terminal by ID
+// @Description Delete a terminal by the provided ID
+// @Tags Terminals
+// @Accept json
+// @Produce json
+// @Param id path string true "Terminal ID"
+// @Success 204 "Terminal deleted successfully"
+// @Failure 404 {object} e.ErrorResponse "Terminal not found"
+// @Router /terminal/{id} [delete]
+func (cc *TerminalController) DeleteTerminalById(ctx *gin.Context) {
+	TerminalId := ctx.Param("terminalId")
+
+	_, err := cc.db.GetTerminalById(ctx, uuid.MustParse(TerminalId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Terminal not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Terminal", Error: err.Error()})
+		return
+	}
+
+	err = cc.db.DeleteTerminal(ctx, uuid.MustParse(TerminalId))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Terminal", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
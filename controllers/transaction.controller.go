@@ -3,27 +3,31 @@ package controllers
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"time"
 
 	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
 	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/payment"
+	"github.com/KevinGruber2001/rupay-bar-backend/receipt"
 	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
 	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
 )
 
 type TransactionController struct {
-	db  *db.Queries
-	ctx context.Context
+	db       *db.Store
+	payments *payment.Registry
+	receipt  receipt.Header
+	ctx      context.Context
 }
 
-func NewTransactionController(db *db.Queries, ctx context.Context) *TransactionController {
-	return &TransactionController{db, ctx}
+func NewTransactionController(db *db.Store, payments *payment.Registry, receipt receipt.Header, ctx context.Context) *TransactionController {
+	return &TransactionController{db, payments, receipt, ctx}
 }
 
 func (cc *TransactionController) GetSavaPageUser(ctx *gin.Context) {
@@ -42,15 +46,27 @@ func (cc *TransactionController) GetSavaPageUser(ctx *gin.Context) {
 		return
 	}
 
-	// Get user balance from SavaPage
-	balance, err := cc.getSavaPageBalance(user)
+	// Get user balance from the payment backend of the user
+	backend, err := cc.payments.Resolve(user.PaymentBackend, null.String{})
 	if err != nil {
-		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to reach Savapage", Error: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to resolve the payment backend", Error: err.Error()})
 		return
 	}
 
+	res := schemas.SavaUser{Name: user.Name, PaymentBackend: backend.Name()}
+
+	balance, err := backend.Balance(ctx, user.Name)
+	switch {
+	case errors.Is(err, payment.ErrNoBalance):
+		// cash has no balance, it stays null
+	case err != nil:
+		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the balance", Error: err.Error()})
+		return
+	default:
+		res.Balance = util.NullMoneyFrom(balance)
+	}
+
 	// Return the result
-	res := schemas.SavaUser{Name: user.Name, Balance: balance}
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -77,136 +93,87 @@ func (cc *TransactionController) getUserByCode(ctx *gin.Context, code string) (d
 	return user, nil
 }
 
-// getSavaPageBalance makes an HTTP request to SavaPage to retrieve the user's balance
-func (cc *TransactionController) getSavaPageBalance(user db.Resident) (float64, error) {
-	config, err := util.LoadConfig("../.")
-	if err != nil {
-		return 0, fmt.Errorf("Failed to load config: %v", err)
-	}
-
-	url := config.SavaPageUrl + fmt.Sprintf("financial/account/balance?type=USER&name=%s", user.Name)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return 0, fmt.Errorf("Failed to create HTTP request: %v", err)
-	}
-
-	req.SetBasicAuth(config.SavaPageAdmin, config.SavaPagePassword)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("Failed to make HTTP request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("Failed to reach SavaPage, status code: %d", resp.StatusCode)
-	}
-
-	// Read and parse the response body
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return 0, fmt.Errorf("Error reading response body: %v", err)
-	}
-
-	var response schemas.SavaResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return 0, fmt.Errorf("Error decoding JSON response: %v", err)
-	}
-
-	if !response.Success {
-		return 0, fmt.Errorf("SavaPage returned unsuccessful response")
-	}
-
-	if response.Result.Valid {
-		return response.Result.Float64, nil
-	}
-
-	return 0, nil
-}
-
 // @Summary Create a new transaction
-// @Description Create a new transaction with the provided date and price
+// @Description Deprecated, transactions with a price sent by the client are no longer accepted. The endpoint still takes the date and price
+// @Description of the old contract and answers with 410, use POST /transaction/checkout with the items of the sale instead.
 // @Tags Transactions
 // @Accept json
 // @Produce json
+// @Param username path string true "SavaPage user name"
 // @Param payload body schemas.CreateTransaction true "CreateTransaction payload"
-// @Success 200 {object} db.Transaction "Transaction data"
 // @Failure 400 {object} e.ErrorResponse "Invalid Payload"
-// @Router /transaction [post]
+// @Failure 410 {object} e.ErrorResponse "Endpoint is deprecated"
+// @Deprecated
+// @Router /transaction/{username} [post]
 func (cc *TransactionController) CreateTransaction(ctx *gin.Context) {
 	var payload *schemas.CreateTransaction
 
-	UserName := ctx.Param("username")
+	// gin needs the same wildcard name for every POST route of the group, the
+	// segment still carries the username
+	UserName := ctx.Param("transactionId")
 
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
 		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Payload is invalid", Error: err.Error()})
 		return
 	}
 
-	args := &db.CreateTransactionParams{
-		Date:  payload.Date,
-		Price: payload.Price,
-	}
-
-	// TODO change balance in savapage
-
-	// start savapage request
-
-	config, err := util.LoadConfig("../.")
-
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to Reach SavaPage", Error: err.Error()})
-		return
-	}
-
-	url := config.SavaPageUrl + fmt.Sprintf("financial/account/balance?type=USER&name=%s&amount=-%.2f&adjust=true&details=rupay_transaction", UserName, payload.Price)
-
-	print(url)
+	ctx.JSON(http.StatusGone, e.ErrorResponse{
+		Code:    e.EndpointDeprecated,
+		Message: "Transactions are priced by the server, use POST /transaction/checkout with the items of the sale",
+		Error:   fmt.Sprintf("transaction of %s for %s was not created", UserName, payload.Price),
+	})
+}
 
-	req, err := http.NewRequest("POST", url, nil)
+// @Summary Checkout a transaction
+// @Description Create a new transaction for the given items of the resident and queue the payment. The transaction is priced and dated at the time it is made.
+// @Description Prices are calculated by the server, an optional price sent by the client has to match.
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CheckoutTransaction true "CheckoutTransaction payload"
+// @Success 200 {object} db.TransactionWithVat "Transaction data with its VAT breakdown"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 402 {object} e.InsufficientFundsResponse "Credit policy blocks the sale"
+// @Failure 404 {object} e.ErrorResponse "Resident or article not found"
+// @Failure 409 {object} e.ErrorResponse "Article is out of stock or archived"
+// @Failure 422 {object} e.ErrorResponse "Price mismatch"
+// @Router /transaction/checkout [post]
+func (cc *TransactionController) CheckoutTransaction(ctx *gin.Context) {
+	var payload *schemas.CheckoutTransaction
 
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to Reach SavaPage", Error: err.Error()})
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Payload is invalid", Error: err.Error()})
 		return
 	}
-	println(config.SavaPageAdmin)
-	println(config.SavaPagePassword)
-
-	req.SetBasicAuth(config.SavaPageAdmin, config.SavaPagePassword)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resident, err := cc.db.GetUserById(ctx, payload.Resident)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to Reach SavaPage", Error: err.Error()})
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Resident not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		println(resp.StatusCode)
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to Reach SavaPage", Error: "Error"})
+	result, ok := checkout(ctx, cc.db, cc.payments, &resident, uuid.NullUUID{}, payload.Items, payload.Price)
+	if !ok {
 		return
 	}
 
-	Transaction, err := cc.db.CreateTransaction(ctx, *args)
-
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create the transaction", Error: err.Error()})
-		return
-	}
-
-	ctx.JSON(http.StatusOK, Transaction)
+	ctx.JSON(http.StatusOK, db.TransactionWithVat{Transaction: result.Transaction, Vat: result.Vat})
 }
 
 // @Summary Update a transaction
-// @Description Update a transaction with the provided date and price
+// @Description Update the date of a pending transaction whose payment did not start yet. The price is always the sum of its lines.
 // @Tags Transactions
 // @Accept json
 // @Produce json
 // @Param payload body schemas.UpdateTransaction true "UpdateTransaction payload"
 // @Success 200 {object} db.Transaction "Transaction data"
 // @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Transaction not found"
+// @Failure 409 {object} e.ErrorResponse "Transaction is not pending or business day is closed"
 // @Router /transaction [patch]
 func (cc *TransactionController) UpdateTransaction(ctx *gin.Context) {
 	var payload *schemas.UpdateTransaction
@@ -217,19 +184,29 @@ func (cc *TransactionController) UpdateTransaction(ctx *gin.Context) {
 		return
 	}
 
+	if !checkTransactionOpen(ctx, cc.db.Queries, uuid.MustParse(TransactionId)) {
+		return
+	}
+	if payload.Date.Valid && !checkPeriodOpen(ctx, cc.db.Queries, payload.Date.Time) {
+		return
+	}
+
 	args := &db.UpdateTransactionParams{
-		Uuid:  uuid.MustParse(TransactionId),
-		Date:  payload.Date,
-		Price: payload.Price,
+		Uuid: uuid.MustParse(TransactionId),
+		Date: payload.Date,
 	}
 
-	Transaction, err := cc.db.UpdateTransaction(ctx, *args)
+	Transaction, err := cc.db.UpdateTransactionTx(ctx, *args)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Failed to find Transaction", Error: err.Error()})
 			return
 		}
+		if errors.Is(err, db.ErrTransactionNotPending) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.NotPending, Message: "Only pending transactions can be changed, refund the transaction instead", Error: err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Transaction", Error: err.Error()})
 		return
 	}
@@ -238,19 +215,19 @@ func (cc *TransactionController) UpdateTransaction(ctx *gin.Context) {
 }
 
 // @Summary Retrieve a transaction
-// @Description Retrieve a transaction by the provided id
+// @Description Retrieve a transaction by the provided id together with its VAT breakdown per rate
 // @Tags Transactions
 // @Accept json
 // @Produce json
 // @Param id path string true "Transaction ID"
-// @Success 200 {object} db.Transaction "Transaction data"
+// @Success 200 {object} db.TransactionWithVat "Transaction data with its VAT breakdown"
 // @Failure 500 {object} e.ErrorResponse "Internal Server Error"
 // @Failure 404 {object} e.ErrorResponse "Transaction not found"
 // @Router /transaction/{id} [get]
 func (cc *TransactionController) GetTransactionById(ctx *gin.Context) {
 	TransactionId := ctx.Param("transactionId")
 
-	Transaction, err := cc.db.GetTransactionById(ctx, uuid.MustParse(TransactionId))
+	Transaction, err := cc.db.GetTransactionWithVat(ctx, uuid.MustParse(TransactionId))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Transaction not found", Error: err.Error()})
@@ -263,31 +240,85 @@ func (cc *TransactionController) GetTransactionById(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, Transaction)
 }
 
+// @Summary Retrieve the receipt of a transaction
+// @Description Render the receipt of a transaction with its lines, the VAT breakdown and the balance after the payment.
+// @Description The header holds the configured bar name and address.
+// @Tags Transactions
+// @Produce plain,html,application/pdf
+// @Param transactionId path string true "Transaction ID"
+// @Param format query string false "Format of the receipt, txt by default" Enums(txt, html, pdf)
+// @Success 200 {file} file "Receipt"
+// @Failure 400 {object} e.ErrorResponse "Invalid format"
+// @Failure 404 {object} e.ErrorResponse "Transaction not found"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /transaction/{transactionId}/receipt [get]
+func (cc *TransactionController) GetTransactionReceipt(ctx *gin.Context) {
+	var filter schemas.ReceiptFilter
+	TransactionId := ctx.Param("transactionId")
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Format is invalid", Error: err.Error()})
+		return
+	}
+
+	r, err := receipt.Load(ctx, cc.db.Queries, cc.receipt, uuid.MustParse(TransactionId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Transaction not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve the Receipt", Error: err.Error()})
+		return
+	}
+
+	body, contentType, err := receipt.Render(r, filter.Format)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to render the Receipt", Error: err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, body)
+}
+
 // @Summary Retrieve all transactions
-// @Description Retrieve a list of all transactions
+// @Description Retrieve a list of all transactions with their VAT breakdown, optionally only those of a resident or an event
 // @Tags Transactions
 // @Accept json
 // @Produce json
-// @Success 200 {array} db.Transaction "List of transactions"
+// @Param resident query string false "Name of the resident who paid"
+// @Param event_uuid query string false "Event ID"
+// @Success 200 {array} db.TransactionWithVat "List of transactions"
+// @Failure 400 {object} e.ErrorResponse "Invalid Filter"
 // @Failure 500 {object} e.ErrorResponse "Internal Server Error"
 // @Router /transaction [get]
 func (cc *TransactionController) GetAllTransactions(ctx *gin.Context) {
+	var filter schemas.TransactionFilter
 
-	Transactions, err := cc.db.GetTransactions(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Transactions", Error: err.Error()})
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Filter is invalid", Error: err.Error()})
 		return
 	}
 
-	if Transactions == nil {
-		Transactions = []db.Transaction{}
+	args := db.GetTransactionsParams{
+		Resident: null.NewString(filter.Resident, filter.Resident != ""),
+	}
+	if filter.EventUuid != "" {
+		args.EventUuid = uuid.NullUUID{UUID: uuid.MustParse(filter.EventUuid), Valid: true}
+	}
+
+	Transactions, err := cc.db.GetTransactionsWithVat(ctx, args)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Transactions", Error: err.Error()})
+		return
 	}
 
 	ctx.JSON(http.StatusOK, Transactions)
 }
 
 // @Summary Delete a transaction
-// @Description Delete a transaction by the provided id
+// @Description Delete a transaction by the provided id.
+// @Description Only failed transactions can be deleted together with their lines, refund charged ones instead.
+// @Description Split bills and the transactions of tabs can not be deleted.
 // @Tags Transactions
 // @Accept json
 // @Produce json
@@ -295,26 +326,168 @@ func (cc *TransactionController) GetAllTransactions(ctx *gin.Context) {
 // @Success 204 "Transaction deleted successfully"
 // @Failure 500 {object} e.ErrorResponse "Failed to delete Transaction"
 // @Failure 404 {object} e.ErrorResponse "Transaction not found"
+// @Failure 409 {object} e.ErrorResponse "Transaction is not failed, belongs to a split bill or tab, or its business day is closed"
 // @Router /transaction/{id} [delete]
 func (cc *TransactionController) DeleteTransactionById(ctx *gin.Context) {
 	TransactionId := ctx.Param("transactionId")
 
-	_, err := cc.db.GetTransactionById(ctx, uuid.MustParse(TransactionId))
+	err := cc.db.DeleteTransactionTx(ctx, uuid.MustParse(TransactionId))
 	if err != nil {
-		if err == sql.ErrNoRows {
+		switch {
+		case err == sql.ErrNoRows:
 			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Failed to retrieve Transaction", Error: err.Error()})
+		case errors.Is(err, db.ErrPeriodClosed):
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "The business day is already closed", Error: err.Error()})
+		case errors.Is(err, db.ErrTransactionNotDeletable), errors.Is(err, db.ErrTransactionNotPending):
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.NotDeletable, Message: "Transaction can not be deleted, refund it instead", Error: err.Error()})
+		default:
+			ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Transaction", Error: err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+
+}
+
+// @Summary Retrieve payments that need a reconciliation
+// @Description Retrieve all payments where SavaPage did not answer in time, so it is unknown whether the resident was charged
+// @Tags Transactions
+// @Produce json
+// @Success 200 {array} db.PaymentOutbox "List of open payments"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /transaction/reconciliation [get]
+func (cc *TransactionController) GetPaymentsNeedingReconciliation(ctx *gin.Context) {
+	payments, err := cc.db.GetPaymentOutboxNeedingReconciliation(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Payments", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, payments)
+}
+
+// @Summary Reconcile the payment of a transaction
+// @Description Resolve a failed or timed out payment after looking up its details marker in SavaPage.
+// @Description "pending" queues the payment again, "charged" and "failed" close it with that status.
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param transactionId path string true "Transaction ID"
+// @Param payload body schemas.ReconcileTransaction true "ReconcileTransaction payload"
+// @Success 200 {object} db.Transaction "Transaction data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Payment not found"
+// @Failure 409 {object} e.ErrorResponse "Transaction is already charged or its business day is closed"
+// @Router /transaction/{transactionId}/reconcile [post]
+func (cc *TransactionController) ReconcileTransaction(ctx *gin.Context) {
+	var payload *schemas.ReconcileTransaction
+	TransactionId := ctx.Param("transactionId")
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Payload is invalid", Error: err.Error()})
+		return
+	}
+
+	Transaction, err := cc.db.GetTransactionById(ctx, uuid.MustParse(TransactionId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Transaction not found", Error: err.Error()})
 			return
 		}
-		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Transaction", Error: err.Error()})
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Transaction", Error: err.Error()})
 		return
 	}
 
-	err = cc.db.DeleteTransaction(ctx, uuid.MustParse(TransactionId))
+	if Transaction.Status == db.TransactionStatusCharged {
+		ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.AlreadyCharged, Message: "Transaction is already charged", Error: "transaction " + TransactionId + " is already charged"})
+		return
+	}
+
+	payment, err := cc.db.GetPaymentOutboxByTransaction(ctx, Transaction.Uuid)
 	if err != nil {
-		ctx.JSON(http.StatusBadGateway, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Transaction", Error: err.Error()})
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Payment not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Payment", Error: err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusNoContent, nil)
+	if payload.Status == db.TransactionStatusPending {
+		Transaction, err = cc.db.RequeuePaymentTx(ctx, payment.Uuid)
+	} else {
+		Transaction, err = cc.db.CompletePaymentTx(ctx, db.CompletePaymentTxParams{
+			OutboxUuid:      payment.Uuid,
+			TransactionUuid: Transaction.Uuid,
+			Status:          payload.Status,
+			CheckPeriodOpen: true,
+		})
+	}
+
+	if err != nil {
+		if errors.Is(err, db.ErrPeriodClosed) {
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "The business day is already closed", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to reconcile Transaction", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Transaction)
+}
+
+// @Summary Refund a transaction
+// @Description Refund a charged transaction, either completely or only the given amounts of its article transactions.
+// @Description A reversing transaction linked to the original is written and the amount is credited back in SavaPage.
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param transactionId path string true "Transaction ID"
+// @Param payload body schemas.RefundTransaction false "RefundTransaction payload"
+// @Success 200 {object} db.CheckoutTxResult "Refund transaction with its article transactions"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Transaction not found"
+// @Failure 409 {object} e.ErrorResponse "Transaction can not be refunded or the business day is closed"
+// @Failure 422 {object} e.ErrorResponse "Refund exceeds the remaining amount"
+// @Router /transaction/{transactionId}/refund [post]
+func (cc *TransactionController) RefundTransaction(ctx *gin.Context) {
+	var payload schemas.RefundTransaction
+	TransactionId := ctx.Param("transactionId")
+
+	// the body is optional, without it the whole transaction is refunded
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&payload); err != nil {
+			ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Payload is invalid", Error: err.Error()})
+			return
+		}
+	}
+
+	lines := make([]db.RefundLine, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		lines = append(lines, db.RefundLine{ArticleTransactionUuid: item.ArticleTransactionUuid, Amount: item.Amount})
+	}
+
+	result, err := cc.db.RefundTx(ctx, db.RefundTxParams{
+		TransactionUuid: uuid.MustParse(TransactionId),
+		Date:            time.Now(),
+		Lines:           lines,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Transaction not found", Error: err.Error()})
+		case errors.Is(err, db.ErrPeriodClosed):
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.PeriodClosed, Message: "The business day is already closed", Error: err.Error()})
+		case errors.Is(err, db.ErrNotRefundable):
+			ctx.JSON(http.StatusConflict, e.ErrorResponse{Code: e.NotRefundable, Message: "Transaction can not be refunded", Error: err.Error()})
+		case errors.Is(err, db.ErrRefundExceedsAmount):
+			ctx.JSON(http.StatusUnprocessableEntity, e.ErrorResponse{Code: e.RefundExceeded, Message: "Refund exceeds the remaining amount", Error: err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to refund Transaction", Error: err.Error()})
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, result)
 }
@@ -54,9 +54,29 @@ func (cc *UserController) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
+	if !validPaymentBackend(payload.PaymentBackend) {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: "unknown payment backend " + payload.PaymentBackend.String})
+		return
+	}
+
+	if payload.GroupName.Valid {
+		if _, err := cc.db.GetResidentGroupById(ctx, payload.GroupName.String); err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Group not found", Error: err.Error()})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Group", Error: err.Error()})
+			return
+		}
+	}
+
 	args := &db.UpdateUserParams{
-		Name: null.StringFrom(name),
-		Code: payload.Code,
+		Name:           null.StringFrom(name),
+		Code:           payload.Code,
+		PaymentBackend: payload.PaymentBackend,
+		GroupName:      payload.GroupName,
+		MinBalance:     payload.MinBalance,
+		DailyCap:       payload.DailyCap,
 	}
 
 	user, err := cc.db.UpdateUser(ctx, *args)
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type WalletController struct {
+	db  *db.Store
+	ctx context.Context
+}
+
+func NewWalletController(db *db.Store, ctx context.Context) *WalletController {
+	return &WalletController{db, ctx}
+}
+
+// @Summary Retrieve the wallet of a resident
+// @Description Retrieve the prepaid wallet balance of a resident, it is the sum of all ledger entries of the wallet
+// @Tags Wallets
+// @Produce json
+// @Param username path string true "Resident name"
+// @Success 200 {object} schemas.Wallet "Wallet data"
+// @Failure 404 {object} e.ErrorResponse "Resident not found"
+// @Router /wallet/{username} [get]
+func (cc *WalletController) GetWallet(ctx *gin.Context) {
+	resident, ok := cc.getResident(ctx)
+	if !ok {
+		return
+	}
+
+	cc.respondWallet(ctx, resident.Name)
+}
+
+// @Summary Retrieve the ledger entries of a wallet
+// @Description Retrieve all ledger entries of the wallet of a resident, newest first
+// @Tags Wallets
+// @Produce json
+// @Param username path string true "Resident name"
+// @Success 200 {array} db.GetLedgerEntriesByAccountRow "List of entries"
+// @Failure 404 {object} e.ErrorResponse "Resident not found"
+// @Router /wallet/{username}/entries [get]
+func (cc *WalletController) GetWalletEntries(ctx *gin.Context) {
+	resident, ok := cc.getResident(ctx)
+	if !ok {
+		return
+	}
+
+	wallet, err := cc.db.GetWalletAccount(ctx, null.StringFrom(resident.Name))
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusOK, []db.GetLedgerEntriesByAccountRow{})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Wallet", Error: err.Error()})
+		return
+	}
+
+	entries, err := cc.db.GetLedgerEntriesByAccount(ctx, wallet.Uuid)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Entries", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// @Summary Top up a wallet
+// @Description Book cash paid at the bar onto the wallet of a resident. Guests get a resident with a card code and the wallet payment backend.
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param username path string true "Resident name"
+// @Param payload body schemas.TopUpWallet true "TopUpWallet payload"
+// @Success 200 {object} schemas.Wallet "Wallet data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Resident not found"
+// @Router /wallet/{username}/topup [post]
+func (cc *WalletController) TopUpWallet(ctx *gin.Context) {
+	var payload *schemas.TopUpWallet
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	cc.post(ctx, payload.Amount, db.LedgerAccountCash, "topup:"+uuid.NewString(), false)
+}
+
+// @Summary Correct a wallet
+// @Description Book a manual correction onto the wallet of a resident, a correction may take the wallet below zero
+// @Tags Wallets
+// @Accept json
+// @Produce json
+// @Param username path string true "Resident name"
+// @Param payload body schemas.CorrectWallet true "CorrectWallet payload"
+// @Success 200 {object} schemas.Wallet "Wallet data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Failure 404 {object} e.ErrorResponse "Resident not found"
+// @Router /wallet/{username}/correction [post]
+func (cc *WalletController) CorrectWallet(ctx *gin.Context) {
+	var payload *schemas.CorrectWallet
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	cc.post(ctx, payload.Amount, db.LedgerAccountCorrection, "correction:"+uuid.NewString()+" "+payload.Reason, true)
+}
+
+// post books the amount on the wallet of the resident in the path and responds with the new balance
+func (cc *WalletController) post(ctx *gin.Context, amount util.Money, counterKind string, details string, allowNegative bool) {
+	resident, ok := cc.getResident(ctx)
+	if !ok {
+		return
+	}
+
+	_, err := cc.db.WalletPostingTx(ctx, db.WalletPostingTxParams{
+		Resident:      resident.Name,
+		Amount:        amount,
+		CounterKind:   counterKind,
+		Details:       details,
+		AllowNegative: allowNegative,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to book on Wallet", Error: err.Error()})
+		return
+	}
+
+	cc.respondWallet(ctx, resident.Name)
+}
+
+func (cc *WalletController) respondWallet(ctx *gin.Context, resident string) {
+	balance, err := cc.db.WalletBalance(ctx, resident)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Wallet", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schemas.Wallet{Resident: resident, Balance: balance})
+}
+
+// getResident loads the resident of the path, on failure the error response is already written
+func (cc *WalletController) getResident(ctx *gin.Context) (db.Resident, bool) {
+	resident, err := cc.db.GetUserById(ctx, ctx.Param("username"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Resident not found", Error: err.Error()})
+			return resident, false
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Resident", Error: err.Error()})
+		return resident, false
+	}
+
+	return resident, true
+}
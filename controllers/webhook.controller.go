@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	e "github.com/KevinGruber2001/rupay-bar-backend/errors"
+	"github.com/KevinGruber2001/rupay-bar-backend/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookController struct {
+	db  *db.Queries
+	ctx context.Context
+}
+
+func NewWebhookController(db *db.Queries, ctx context.Context) *WebhookController {
+	return &WebhookController{db, ctx}
+}
+
+// @Summary Register a webhook
+// @Description Register an URL the low stock alerts are posted to as JSON. With a secret the body is signed
+// @Description with HMAC-SHA256, the signature is sent in the X-Rupay-Signature header as sha256=<hex>.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param payload body schemas.CreateWebhook true "CreateWebhook payload"
+// @Success 200 {object} db.Webhook "Webhook data"
+// @Failure 400 {object} e.ErrorResponse "Invalid Payload"
+// @Router /webhook [post]
+func (cc *WebhookController) CreateWebhook(ctx *gin.Context) {
+	var payload *schemas.CreateWebhook
+
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.JSON(http.StatusBadRequest, e.ErrorResponse{Code: e.InvalidPayload, Message: "Invalid Payload", Error: err.Error()})
+		return
+	}
+
+	Webhook, err := cc.db.CreateWebhook(ctx, db.CreateWebhookParams{Url: payload.Url, Secret: payload.Secret})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to create Webhook", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Webhook)
+}
+
+// @Summary Retrieve all webhooks
+// @Description Retrieve a list of all registered webhooks
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} db.Webhook "List of webhooks"
+// @Failure 500 {object} e.ErrorResponse "Internal Server Error"
+// @Router /webhook [get]
+func (cc *WebhookController) GetAllWebhooks(ctx *gin.Context) {
+	Webhooks, err := cc.db.GetWebhooks(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Webhooks", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, Webhooks)
+}
+
+// @Summary Delete a webhook by ID
+// @Description Delete a webhook, no more alerts are posted to it
+// @Tags Webhooks
+// @Produce json
+// @Param webhookId path string true "Webhook ID"
+// @Success 204 "Webhook deleted successfully"
+// @Failure 404 {object} e.ErrorResponse "Webhook not found"
+// @Router /webhook/{webhookId} [delete]
+func (cc *WebhookController) DeleteWebhookById(ctx *gin.Context) {
+	WebhookId := ctx.Param("webhookId")
+
+	_, err := cc.db.GetWebhookById(ctx, uuid.MustParse(WebhookId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, e.ErrorResponse{Code: e.NotFound, Message: "Webhook not found", Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to retrieve Webhook", Error: err.Error()})
+		return
+	}
+
+	err = cc.db.DeleteWebhook(ctx, uuid.MustParse(WebhookId))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, e.ErrorResponse{Code: e.InternalServerError, Message: "Failed to delete Webhook", Error: err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,9 @@
+ALTER TABLE "article_transaction"
+ALTER COLUMN "price" TYPE FLOAT USING "price"::float;
+
+ALTER TABLE "article"
+ALTER COLUMN "purchase_price" TYPE FLOAT USING "purchase_price"::float,
+ALTER COLUMN "resell_price" TYPE FLOAT USING "resell_price"::float;
+
+ALTER TABLE "transaction"
+ALTER COLUMN "price" TYPE FLOAT USING "price"::float;
@@ -0,0 +1,11 @@
+-- Store all amounts as exact decimals instead of FLOAT
+
+ALTER TABLE "transaction"
+ALTER COLUMN "price" TYPE NUMERIC(12,2) USING round("price"::numeric, 2);
+
+ALTER TABLE "article"
+ALTER COLUMN "purchase_price" TYPE NUMERIC(12,2) USING round("purchase_price"::numeric, 2),
+ALTER COLUMN "resell_price" TYPE NUMERIC(12,2) USING round("resell_price"::numeric, 2);
+
+ALTER TABLE "article_transaction"
+ALTER COLUMN "price" TYPE NUMERIC(12,2) USING round("price"::numeric, 2);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS payment_outbox;
+
+ALTER TABLE "transaction"
+DROP COLUMN "status";
@@ -0,0 +1,27 @@
+-- Existing transactions were charged before they were written
+ALTER TABLE "transaction"
+ADD COLUMN "status" VARCHAR NOT NULL DEFAULT 'charged'
+CHECK ("status" IN ('pending', 'charged', 'failed'));
+
+ALTER TABLE "transaction"
+ALTER COLUMN "status" SET DEFAULT 'pending';
+
+-- Balance adjustments that still have to be applied in SavaPage.
+-- Debits are negative, credits positive.
+CREATE TABLE "payment_outbox" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "transaction_uuid" UUID NOT NULL,
+    "username" VARCHAR NOT NULL,
+    "amount" NUMERIC(12,2) NOT NULL,
+    "details" VARCHAR NOT NULL,
+    "attempts" INT NOT NULL DEFAULT 0,
+    "last_error" VARCHAR,
+    "needs_reconciliation" BOOLEAN NOT NULL DEFAULT false,
+    "next_attempt_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "processed_at" TIMESTAMP,
+    FOREIGN KEY ("transaction_uuid") REFERENCES "transaction"("uuid") ON DELETE CASCADE
+);
+
+CREATE INDEX "payment_outbox_due_idx" ON "payment_outbox" ("next_attempt_at")
+WHERE "processed_at" IS NULL;
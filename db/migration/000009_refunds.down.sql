@@ -0,0 +1,5 @@
+ALTER TABLE "article_transaction"
+DROP COLUMN "refund_of";
+
+ALTER TABLE "transaction"
+DROP COLUMN "refund_of";
@@ -0,0 +1,9 @@
+-- A refund is a reversing transaction with negative amounts that points to
+-- the transaction and the article transactions it reverses
+ALTER TABLE "transaction"
+ADD COLUMN "refund_of" UUID REFERENCES "transaction"("uuid");
+
+ALTER TABLE "article_transaction"
+ADD COLUMN "refund_of" UUID REFERENCES "article_transaction"("uuid");
+
+CREATE INDEX "article_transaction_refund_of_idx" ON "article_transaction" ("refund_of");
@@ -0,0 +1,3 @@
+ALTER TABLE "transaction"
+DROP COLUMN "resident",
+DROP COLUMN "event_uuid";
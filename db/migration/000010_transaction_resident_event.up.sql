@@ -0,0 +1,16 @@
+-- The resident who paid and the event the transaction happened at
+ALTER TABLE "transaction"
+ADD COLUMN "resident" VARCHAR REFERENCES "resident"("name") ON UPDATE CASCADE ON DELETE SET NULL,
+ADD COLUMN "event_uuid" UUID REFERENCES "event"("uuid") ON DELETE SET NULL;
+
+-- Assign existing transactions to the event they happened at
+UPDATE "transaction" t
+SET "event_uuid" = (
+    SELECT e.uuid FROM "event" e
+    WHERE t.date BETWEEN e.from_date AND e.to_date
+    ORDER BY e.from_date DESC
+    LIMIT 1
+);
+
+CREATE INDEX "transaction_resident_idx" ON "transaction" ("resident");
+CREATE INDEX "transaction_event_uuid_idx" ON "transaction" ("event_uuid");
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS wallet;
+
+DELETE FROM "payment_outbox" WHERE "username" IS NULL;
+
+ALTER TABLE "payment_outbox"
+ALTER COLUMN "username" SET NOT NULL,
+DROP COLUMN "backend";
+
+ALTER TABLE "transaction"
+DROP COLUMN "payment_backend",
+DROP COLUMN "terminal_uuid";
+
+ALTER TABLE "resident"
+DROP COLUMN "payment_backend";
+
+DROP TABLE IF EXISTS terminal;
@@ -0,0 +1,34 @@
+-- Bar terminals, a terminal can force the payment backend used for its sales
+CREATE TABLE "terminal" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "name" VARCHAR NOT NULL,
+    "payment_backend" VARCHAR
+);
+
+-- Payment backend of a resident, the configured default is used when empty
+ALTER TABLE "resident"
+ADD COLUMN "payment_backend" VARCHAR;
+
+-- Existing transactions were all paid with SavaPage
+ALTER TABLE "transaction"
+ADD COLUMN "payment_backend" VARCHAR NOT NULL DEFAULT 'savapage',
+ADD COLUMN "terminal_uuid" UUID REFERENCES "terminal"("uuid") ON DELETE SET NULL;
+
+ALTER TABLE "transaction"
+ALTER COLUMN "payment_backend" DROP DEFAULT;
+
+ALTER TABLE "payment_outbox"
+ADD COLUMN "backend" VARCHAR NOT NULL DEFAULT 'savapage';
+
+ALTER TABLE "payment_outbox"
+ALTER COLUMN "backend" DROP DEFAULT;
+
+-- Guests without a SavaPage account pay cash and have no username
+ALTER TABLE "payment_outbox"
+ALTER COLUMN "username" DROP NOT NULL;
+
+-- Balances of the local prepaid wallet
+CREATE TABLE "wallet" (
+    "resident" VARCHAR NOT NULL PRIMARY KEY REFERENCES "resident"("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "balance" NUMERIC(12,2) NOT NULL DEFAULT 0
+);
@@ -0,0 +1,14 @@
+CREATE TABLE "wallet" (
+    "resident" VARCHAR NOT NULL PRIMARY KEY REFERENCES "resident"("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "balance" NUMERIC(12,2) NOT NULL DEFAULT 0
+);
+
+INSERT INTO "wallet" ("resident", "balance")
+SELECT a.resident, COALESCE(SUM(e.amount), 0) FROM "ledger_account" a
+LEFT JOIN "ledger_entry" e ON e.account_uuid = a.uuid
+WHERE a.kind = 'wallet'
+GROUP BY a.resident;
+
+DROP TABLE IF EXISTS ledger_entry;
+DROP TABLE IF EXISTS ledger_posting;
+DROP TABLE IF EXISTS ledger_account;
@@ -0,0 +1,56 @@
+-- Double-entry ledger of the prepaid wallets. Every posting consists of
+-- entries that sum up to zero, balances are the sum of the entries of an
+-- account and are never stored.
+CREATE TABLE "ledger_account" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "name" VARCHAR NOT NULL,
+    "kind" VARCHAR NOT NULL CHECK ("kind" IN ('wallet', 'revenue', 'cash', 'correction')),
+    "resident" VARCHAR UNIQUE REFERENCES "resident"("name") ON UPDATE CASCADE ON DELETE RESTRICT,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (("kind" = 'wallet') = ("resident" IS NOT NULL))
+);
+
+-- There is exactly one account of every other kind
+CREATE UNIQUE INDEX "ledger_account_system_kind_idx" ON "ledger_account" ("kind")
+WHERE "resident" IS NULL;
+
+-- The details are unique, booking the same payment twice is a no-op
+CREATE TABLE "ledger_posting" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "details" VARCHAR NOT NULL UNIQUE,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE "ledger_entry" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "posting_uuid" UUID NOT NULL REFERENCES "ledger_posting"("uuid"),
+    "account_uuid" UUID NOT NULL REFERENCES "ledger_account"("uuid"),
+    "amount" NUMERIC(12,2) NOT NULL
+);
+
+CREATE INDEX "ledger_entry_account_uuid_idx" ON "ledger_entry" ("account_uuid");
+
+INSERT INTO "ledger_account" ("name", "kind") VALUES
+    ('Sales', 'revenue'),
+    ('Cash', 'cash'),
+    ('Corrections', 'correction');
+
+-- Carry over the balances of the wallet table as opening postings
+INSERT INTO "ledger_account" ("name", "kind", "resident")
+SELECT "resident", 'wallet', "resident" FROM "wallet";
+
+INSERT INTO "ledger_posting" ("details")
+SELECT 'opening_balance:' || "resident" FROM "wallet"
+WHERE "balance" <> 0;
+
+INSERT INTO "ledger_entry" ("posting_uuid", "account_uuid", "amount")
+SELECT p.uuid, a.uuid, w.balance FROM "wallet" w
+JOIN "ledger_account" a ON a.resident = w.resident
+JOIN "ledger_posting" p ON p.details = 'opening_balance:' || w.resident
+UNION ALL
+SELECT p.uuid, c.uuid, -w.balance FROM "wallet" w
+JOIN "ledger_posting" p ON p.details = 'opening_balance:' || w.resident
+CROSS JOIN "ledger_account" c
+WHERE c.kind = 'correction';
+
+DROP TABLE "wallet";
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tab_item;
+DROP TABLE IF EXISTS tab;
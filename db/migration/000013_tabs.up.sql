@@ -0,0 +1,29 @@
+-- Tabs collect the orders of a resident during an evening, they are settled
+-- with a single transaction when closed
+CREATE TABLE "tab" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "resident" VARCHAR NOT NULL REFERENCES "resident"("name") ON UPDATE CASCADE,
+    "terminal_uuid" UUID REFERENCES "terminal"("uuid") ON DELETE SET NULL,
+    "event_uuid" UUID REFERENCES "event"("uuid") ON DELETE SET NULL,
+    "payment_backend" VARCHAR NOT NULL,
+    "status" VARCHAR NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'flagged', 'closed')),
+    "transaction_uuid" UUID REFERENCES "transaction"("uuid"),
+    "opened_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "closed_at" TIMESTAMP
+);
+
+-- A resident can only run one tab at a time
+CREATE UNIQUE INDEX "tab_resident_open_idx" ON "tab" ("resident")
+WHERE "status" <> 'closed';
+
+-- Items keep the price of the moment they were ordered
+CREATE TABLE "tab_item" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "tab_uuid" UUID NOT NULL REFERENCES "tab"("uuid") ON DELETE CASCADE,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid"),
+    "amount" INT NOT NULL,
+    "price" NUMERIC(12,2) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX "tab_item_tab_uuid_idx" ON "tab_item" ("tab_uuid");
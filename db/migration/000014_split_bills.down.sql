@@ -0,0 +1,5 @@
+ALTER TABLE "payment_outbox"
+DROP COLUMN "reversal";
+
+ALTER TABLE "transaction"
+DROP COLUMN "split_of";
@@ -0,0 +1,10 @@
+-- A split bill is a parent transaction with the article transactions of the
+-- cart and one share transaction per resident that pays a part of it
+ALTER TABLE "transaction"
+ADD COLUMN "split_of" UUID REFERENCES "transaction"("uuid");
+
+CREATE INDEX "transaction_split_of_idx" ON "transaction" ("split_of");
+
+-- Reversals pay back shares that were charged before another share failed
+ALTER TABLE "payment_outbox"
+ADD COLUMN "reversal" BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,6 @@
+ALTER TABLE "resident"
+DROP COLUMN "daily_cap",
+DROP COLUMN "min_balance",
+DROP COLUMN "group_name";
+
+DROP TABLE IF EXISTS "resident_group";
@@ -0,0 +1,11 @@
+-- Groups share a credit policy, residents can override it with their own
+CREATE TABLE "resident_group" (
+    "name" VARCHAR NOT NULL PRIMARY KEY,
+    "min_balance" NUMERIC(12,2),
+    "daily_cap" NUMERIC(12,2)
+);
+
+ALTER TABLE "resident"
+ADD COLUMN "group_name" VARCHAR REFERENCES "resident_group"("name") ON UPDATE CASCADE ON DELETE SET NULL,
+ADD COLUMN "min_balance" NUMERIC(12,2),
+ADD COLUMN "daily_cap" NUMERIC(12,2);
@@ -0,0 +1,7 @@
+ALTER TABLE "tab_item"
+DROP COLUMN "pricing_rule_uuid";
+
+ALTER TABLE "article_transaction"
+DROP COLUMN "pricing_rule_uuid";
+
+DROP TABLE IF EXISTS pricing_rule;
@@ -0,0 +1,27 @@
+-- Pricing rules override the resell price of articles, e.g. for happy hours
+-- or the price list of an event. A rule applies to every article matching
+-- all of its scopes during its weekdays and time window.
+CREATE TABLE "pricing_rule" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "name" VARCHAR NOT NULL,
+    "article_uuid" UUID REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "article_type_uuid" UUID REFERENCES "article_type"("uuid") ON DELETE CASCADE,
+    "event_uuid" UUID REFERENCES "event"("uuid") ON DELETE CASCADE,
+    -- bit n is set for weekday n, starting with sunday as 0
+    "weekdays" INT CHECK ("weekdays" BETWEEN 1 AND 127),
+    -- minutes since midnight, a window ending before it starts wraps midnight
+    "starts_at" INT CHECK ("starts_at" BETWEEN 0 AND 1440),
+    "ends_at" INT CHECK ("ends_at" BETWEEN 0 AND 1440),
+    "fixed_price" NUMERIC(12,2) CHECK ("fixed_price" >= 0),
+    "percent_off" INT CHECK ("percent_off" BETWEEN 0 AND 100),
+    "priority" INT NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (("fixed_price" IS NULL) <> ("percent_off" IS NULL))
+);
+
+-- The rule a line was sold with, lines without one were sold at the resell price
+ALTER TABLE "article_transaction"
+ADD COLUMN "pricing_rule_uuid" UUID REFERENCES "pricing_rule"("uuid") ON DELETE SET NULL;
+
+ALTER TABLE "tab_item"
+ADD COLUMN "pricing_rule_uuid" UUID REFERENCES "pricing_rule"("uuid") ON DELETE SET NULL;
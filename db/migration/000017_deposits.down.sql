@@ -0,0 +1,11 @@
+ALTER TABLE "tab_item"
+DROP COLUMN "deposit";
+
+DELETE FROM "article_transaction"
+WHERE "kind" <> 'sale';
+
+ALTER TABLE "article_transaction"
+DROP COLUMN "kind";
+
+ALTER TABLE "article"
+DROP COLUMN "deposit";
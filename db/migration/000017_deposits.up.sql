@@ -0,0 +1,11 @@
+-- The bottle deposit of an article is charged as a separate line with every
+-- sale and paid back when the bottles are returned
+ALTER TABLE "article"
+ADD COLUMN "deposit" NUMERIC(12,2) NOT NULL DEFAULT 0 CHECK ("deposit" >= 0);
+
+-- Deposit lines are liabilities, they are kept apart from the revenue
+ALTER TABLE "article_transaction"
+ADD COLUMN "kind" VARCHAR NOT NULL DEFAULT 'sale' CHECK ("kind" IN ('sale', 'deposit', 'deposit_return'));
+
+ALTER TABLE "tab_item"
+ADD COLUMN "deposit" NUMERIC(12,2) NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+DELETE FROM "article_transaction"
+WHERE "kind" = 'component';
+
+ALTER TABLE "article_transaction"
+DROP CONSTRAINT "article_transaction_kind_check";
+
+ALTER TABLE "article_transaction"
+ADD CONSTRAINT "article_transaction_kind_check" CHECK ("kind" IN ('sale', 'deposit', 'deposit_return'));
+
+ALTER TABLE "article_transaction"
+DROP COLUMN "bundle_of";
+
+DROP TABLE IF EXISTS article_component;
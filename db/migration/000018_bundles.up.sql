@@ -0,0 +1,22 @@
+-- A bundle is an article sold at its own price that consists of other articles
+CREATE TABLE "article_component" (
+    "bundle_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "component_uuid" UUID NOT NULL REFERENCES "article"("uuid"),
+    "amount" INT NOT NULL CHECK ("amount" > 0),
+    PRIMARY KEY ("bundle_uuid", "component_uuid"),
+    CHECK ("bundle_uuid" <> "component_uuid")
+);
+
+-- Every sold bundle writes a component line per component that counts the
+-- units, the price of a component line is the resell price of the component
+-- at the time of the sale and weights the split of the bundle revenue
+ALTER TABLE "article_transaction"
+ADD COLUMN "bundle_of" UUID REFERENCES "article_transaction"("uuid") ON DELETE CASCADE;
+
+ALTER TABLE "article_transaction"
+DROP CONSTRAINT "article_transaction_kind_check";
+
+ALTER TABLE "article_transaction"
+ADD CONSTRAINT "article_transaction_kind_check" CHECK ("kind" IN ('sale', 'deposit', 'deposit_return', 'component'));
+
+CREATE INDEX "article_transaction_bundle_of_idx" ON "article_transaction" ("bundle_of");
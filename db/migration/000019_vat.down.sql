@@ -0,0 +1,10 @@
+ALTER TABLE "article_transaction"
+DROP COLUMN "tax",
+DROP COLUMN "net",
+DROP COLUMN "vat_rate";
+
+ALTER TABLE "article"
+DROP COLUMN "vat_rate";
+
+ALTER TABLE "article_type"
+DROP COLUMN "vat_rate";
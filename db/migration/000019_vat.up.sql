@@ -0,0 +1,28 @@
+-- VAT rates in whole percent, the rate of an article overrides the one of its type
+ALTER TABLE "article_type"
+ADD COLUMN "vat_rate" INT NOT NULL DEFAULT 19 CHECK ("vat_rate" BETWEEN 0 AND 100);
+
+ALTER TABLE "article"
+ADD COLUMN "vat_rate" INT CHECK ("vat_rate" BETWEEN 0 AND 100);
+
+-- The rate and the net and tax amounts of a line are fixed at the time of the
+-- sale, prices are gross. Component lines carry no revenue and no VAT.
+ALTER TABLE "article_transaction"
+ADD COLUMN "vat_rate" INT NOT NULL DEFAULT 0,
+ADD COLUMN "net" NUMERIC(12,2) NOT NULL DEFAULT 0,
+ADD COLUMN "tax" NUMERIC(12,2) NOT NULL DEFAULT 0;
+
+UPDATE "article_transaction"
+SET "vat_rate" = COALESCE("article"."vat_rate", "article_type"."vat_rate")
+FROM "article"
+JOIN "article_type" ON "article_type"."uuid" = "article"."article_type_uuid"
+WHERE "article"."uuid" = "article_transaction"."article_uuid"
+AND "article_transaction"."kind" <> 'component';
+
+UPDATE "article_transaction"
+SET "net" = ROUND("amount" * "price" * 100 / (100 + "vat_rate"), 2)
+WHERE "kind" <> 'component';
+
+UPDATE "article_transaction"
+SET "tax" = "amount" * "price" - "net"
+WHERE "kind" <> 'component';
@@ -0,0 +1,4 @@
+-- Balance of the account right after the payment went through, printed on
+-- receipts. Empty for backends without a balance.
+ALTER TABLE "transaction"
+ADD COLUMN "balance_after" NUMERIC(12,2);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "closing_payment_backend";
+
+DROP TABLE IF EXISTS "closing_article_type";
+
+DROP TABLE IF EXISTS "closing";
+
+DROP FUNCTION IF EXISTS "closing_immutable";
@@ -0,0 +1,48 @@
+-- A closing ends a business day. Its report is written once and never
+-- changed, transactions dated inside a closed period are locked.
+CREATE TABLE "closing" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "period_start" TIMESTAMP,
+    "period_end" TIMESTAMP NOT NULL UNIQUE,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "transactions" INT NOT NULL,
+    "revenue" NUMERIC(12,2) NOT NULL,
+    "refunds" INT NOT NULL,
+    "refund_total" NUMERIC(12,2) NOT NULL,
+    "deposits_charged" NUMERIC(12,2) NOT NULL,
+    "deposits_returned" NUMERIC(12,2) NOT NULL,
+    "pending" INT NOT NULL,
+    CHECK ("period_start" IS NULL OR "period_start" < "period_end")
+);
+
+CREATE TABLE "closing_article_type" (
+    "closing_uuid" UUID NOT NULL REFERENCES "closing"("uuid"),
+    "article_type_uuid" UUID NOT NULL,
+    "name" VARCHAR NOT NULL,
+    "amount" INT NOT NULL,
+    "revenue" NUMERIC(12,2) NOT NULL,
+    PRIMARY KEY ("closing_uuid", "article_type_uuid")
+);
+
+CREATE TABLE "closing_payment_backend" (
+    "closing_uuid" UUID NOT NULL REFERENCES "closing"("uuid"),
+    "payment_backend" VARCHAR NOT NULL,
+    "transactions" INT NOT NULL,
+    "total" NUMERIC(12,2) NOT NULL,
+    PRIMARY KEY ("closing_uuid", "payment_backend")
+);
+
+CREATE FUNCTION "closing_immutable"() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'closings can not be changed';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "closing_immutable" BEFORE UPDATE OR DELETE ON "closing"
+FOR EACH ROW EXECUTE FUNCTION "closing_immutable"();
+
+CREATE TRIGGER "closing_article_type_immutable" BEFORE UPDATE OR DELETE ON "closing_article_type"
+FOR EACH ROW EXECUTE FUNCTION "closing_immutable"();
+
+CREATE TRIGGER "closing_payment_backend_immutable" BEFORE UPDATE OR DELETE ON "closing_payment_backend"
+FOR EACH ROW EXECUTE FUNCTION "closing_immutable"();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "shift_count";
+
+DROP TABLE IF EXISTS "shift";
@@ -0,0 +1,26 @@
+-- A shift is a bartender working a cash drawer. The drawer holds the opening
+-- float plus all cash payments taken in the shift, the counted cash is
+-- compared with that when the shift is closed.
+CREATE TABLE "shift" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "bartender" VARCHAR NOT NULL,
+    "terminal_uuid" UUID REFERENCES "terminal"("uuid") ON DELETE SET NULL,
+    "event_uuid" UUID REFERENCES "event"("uuid") ON DELETE SET NULL,
+    "opening_float" NUMERIC(12,2) NOT NULL CHECK ("opening_float" >= 0),
+    "opened_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "closed_at" TIMESTAMP,
+    "expected_cash" NUMERIC(12,2),
+    "counted_cash" NUMERIC(12,2),
+    "difference" NUMERIC(12,2)
+);
+
+-- every drawer has at most one open shift, shifts without a terminal share one drawer
+CREATE UNIQUE INDEX "shift_open_drawer" ON "shift" (COALESCE("terminal_uuid", '00000000-0000-0000-0000-000000000000'))
+WHERE "closed_at" IS NULL;
+
+CREATE TABLE "shift_count" (
+    "shift_uuid" UUID NOT NULL REFERENCES "shift"("uuid") ON DELETE CASCADE,
+    "denomination" NUMERIC(12,2) NOT NULL CHECK ("denomination" > 0),
+    "count" INT NOT NULL CHECK ("count" >= 0),
+    PRIMARY KEY ("shift_uuid", "denomination")
+);
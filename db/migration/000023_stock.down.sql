@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "stock_movement";
+
+ALTER TABLE "article"
+DROP COLUMN "stock_policy",
+DROP COLUMN "stock";
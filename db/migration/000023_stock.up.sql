@@ -0,0 +1,19 @@
+-- The stock of an article is the sum of its movements. Bundles have no stock
+-- of their own, their components are moved instead. The policy decides what
+-- happens when a sale takes the stock below zero.
+ALTER TABLE "article"
+ADD COLUMN "stock" INT NOT NULL DEFAULT 0,
+ADD COLUMN "stock_policy" VARCHAR NOT NULL DEFAULT 'allow' CHECK ("stock_policy" IN ('allow', 'warn', 'block'));
+
+CREATE TABLE "stock_movement" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "quantity" INT NOT NULL,
+    "stock_after" INT NOT NULL,
+    "reason" VARCHAR NOT NULL CHECK ("reason" IN ('sale', 'refund', 'delivery', 'correction')),
+    "article_transaction_uuid" UUID REFERENCES "article_transaction"("uuid") ON DELETE SET NULL,
+    "note" VARCHAR,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON "stock_movement" ("article_uuid", "created_at");
@@ -0,0 +1,15 @@
+ALTER TABLE "article_transaction"
+DROP COLUMN "unit_cost";
+
+ALTER TABLE "stock_movement"
+DROP COLUMN "goods_receipt_uuid";
+
+DROP TABLE IF EXISTS "goods_receipt_line";
+
+DROP TABLE IF EXISTS "goods_receipt";
+
+DROP TABLE IF EXISTS "purchase_order_line";
+
+DROP TABLE IF EXISTS "purchase_order";
+
+DROP TABLE IF EXISTS "supplier";
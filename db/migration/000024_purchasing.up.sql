@@ -0,0 +1,62 @@
+CREATE TABLE "supplier" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "name" VARCHAR NOT NULL UNIQUE,
+    "contact" VARCHAR,
+    "email" VARCHAR,
+    "phone" VARCHAR,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- A purchase order lists the articles ordered from a supplier, goods
+-- receipts book what actually arrived. An order is received once every line
+-- is received completely.
+CREATE TABLE "purchase_order" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "supplier_uuid" UUID NOT NULL REFERENCES "supplier"("uuid"),
+    "status" VARCHAR NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'partially_received', 'received', 'cancelled')),
+    "note" VARCHAR,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE "purchase_order_line" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "purchase_order_uuid" UUID NOT NULL REFERENCES "purchase_order"("uuid") ON DELETE CASCADE,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "quantity" INT NOT NULL CHECK ("quantity" > 0),
+    "unit_cost" NUMERIC(12,2) NOT NULL CHECK ("unit_cost" >= 0),
+    "received" INT NOT NULL DEFAULT 0 CHECK ("received" >= 0 AND "received" <= "quantity")
+);
+
+CREATE INDEX ON "purchase_order_line" ("purchase_order_uuid");
+
+CREATE TABLE "goods_receipt" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "purchase_order_uuid" UUID NOT NULL REFERENCES "purchase_order"("uuid") ON DELETE CASCADE,
+    "note" VARCHAR,
+    "received_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- the unit cost is the price actually paid, it can differ from the order
+CREATE TABLE "goods_receipt_line" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "goods_receipt_uuid" UUID NOT NULL REFERENCES "goods_receipt"("uuid") ON DELETE CASCADE,
+    "purchase_order_line_uuid" UUID NOT NULL REFERENCES "purchase_order_line"("uuid") ON DELETE CASCADE,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "quantity" INT NOT NULL CHECK ("quantity" > 0),
+    "unit_cost" NUMERIC(12,2) NOT NULL CHECK ("unit_cost" >= 0)
+);
+
+CREATE INDEX ON "goods_receipt_line" ("goods_receipt_uuid");
+
+ALTER TABLE "stock_movement"
+ADD COLUMN "goods_receipt_uuid" UUID REFERENCES "goods_receipt"("uuid") ON DELETE SET NULL;
+
+-- The cost of a unit at the time of its sale, later receipts change the
+-- purchase price of the article but not the profit of past sales.
+ALTER TABLE "article_transaction"
+ADD COLUMN "unit_cost" NUMERIC(12,2) NOT NULL DEFAULT 0;
+
+UPDATE "article_transaction"
+SET "unit_cost" = "article"."purchase_price"
+FROM "article"
+WHERE "article"."uuid" = "article_transaction"."article_uuid";
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "webhook";
+
+DROP TABLE IF EXISTS "stock_alert_delivery";
+
+DROP TABLE IF EXISTS "stock_alert";
+
+ALTER TABLE "article"
+DROP COLUMN "reorder_threshold";
@@ -0,0 +1,37 @@
+-- Articles without a reorder threshold raise no low stock alerts
+ALTER TABLE "article"
+ADD COLUMN "reorder_threshold" INT CHECK ("reorder_threshold" >= 0);
+
+-- Alerts are an outbox, the stock alert worker delivers them to every
+-- channel. A channel that received an alert is not sent it again when the
+-- delivery to another channel is retried.
+CREATE TABLE "stock_alert" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "stock" INT NOT NULL,
+    "reorder_threshold" INT NOT NULL,
+    "attempts" INT NOT NULL DEFAULT 0,
+    "last_error" VARCHAR,
+    "next_attempt_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "processed_at" TIMESTAMP
+);
+
+CREATE INDEX "stock_alert_due_idx" ON "stock_alert" ("next_attempt_at")
+WHERE "processed_at" IS NULL;
+
+CREATE INDEX ON "stock_alert" ("article_uuid", "created_at");
+
+CREATE TABLE "stock_alert_delivery" (
+    "alert_uuid" UUID NOT NULL REFERENCES "stock_alert"("uuid") ON DELETE CASCADE,
+    "channel" VARCHAR NOT NULL,
+    "delivered_at" TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY ("alert_uuid", "channel")
+);
+
+CREATE TABLE "webhook" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "url" VARCHAR NOT NULL,
+    "secret" VARCHAR,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
@@ -0,0 +1,13 @@
+-- the stock stays the sum of its movements
+UPDATE "stock_movement"
+SET "reason" = 'correction'
+WHERE "reason" = 'stocktake';
+
+ALTER TABLE "stock_movement"
+DROP COLUMN "stocktake_uuid",
+DROP CONSTRAINT "stock_movement_reason_check",
+ADD CONSTRAINT "stock_movement_reason_check" CHECK ("reason" IN ('sale', 'refund', 'delivery', 'correction'));
+
+DROP TABLE IF EXISTS "stocktake_line";
+
+DROP TABLE IF EXISTS "stocktake";
@@ -0,0 +1,27 @@
+-- A stocktake compares the counted stock with the stock expected when it
+-- was opened. At most one stocktake is open at a time.
+CREATE TABLE "stocktake" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "note" VARCHAR,
+    "opened_at" TIMESTAMP NOT NULL DEFAULT now(),
+    "closed_at" TIMESTAMP
+);
+
+CREATE UNIQUE INDEX "stocktake_open" ON "stocktake" ((true))
+WHERE "closed_at" IS NULL;
+
+-- The expected stock and the purchase price are snapshots taken when the
+-- stocktake is opened, the counted stock stays empty until the article is counted
+CREATE TABLE "stocktake_line" (
+    "stocktake_uuid" UUID NOT NULL REFERENCES "stocktake"("uuid") ON DELETE CASCADE,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "expected" INT NOT NULL,
+    "counted" INT CHECK ("counted" >= 0),
+    "unit_cost" NUMERIC(12,2) NOT NULL,
+    PRIMARY KEY ("stocktake_uuid", "article_uuid")
+);
+
+ALTER TABLE "stock_movement"
+ADD COLUMN "stocktake_uuid" UUID REFERENCES "stocktake"("uuid") ON DELETE SET NULL,
+DROP CONSTRAINT "stock_movement_reason_check",
+ADD CONSTRAINT "stock_movement_reason_check" CHECK ("reason" IN ('sale', 'refund', 'delivery', 'correction', 'stocktake'));
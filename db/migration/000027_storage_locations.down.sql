@@ -0,0 +1,20 @@
+DELETE FROM "stock_movement"
+WHERE "reason" = 'transfer';
+
+ALTER TABLE "stock_movement"
+DROP COLUMN "stock_transfer_uuid",
+DROP COLUMN "location_uuid",
+DROP CONSTRAINT "stock_movement_reason_check",
+ADD CONSTRAINT "stock_movement_reason_check" CHECK ("reason" IN ('sale', 'refund', 'delivery', 'correction', 'stocktake'));
+
+ALTER TABLE "stocktake"
+DROP COLUMN "location_uuid";
+
+ALTER TABLE "terminal"
+DROP COLUMN "location_uuid";
+
+DROP TABLE IF EXISTS "stock_transfer";
+
+DROP TABLE IF EXISTS "article_stock";
+
+DROP TABLE IF EXISTS "location";
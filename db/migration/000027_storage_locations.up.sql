@@ -0,0 +1,52 @@
+-- Stock is kept per storage location, the stock of the article stays the
+-- total of all locations. Sales draw from the location of the terminal,
+-- everything else without a location from the default location.
+CREATE TABLE "location" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "name" VARCHAR NOT NULL UNIQUE,
+    "is_default" BOOLEAN NOT NULL DEFAULT false,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX "location_default" ON "location" ("is_default")
+WHERE "is_default";
+
+CREATE TABLE "article_stock" (
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "location_uuid" UUID NOT NULL REFERENCES "location"("uuid") ON DELETE CASCADE,
+    "stock" INT NOT NULL DEFAULT 0,
+    PRIMARY KEY ("article_uuid", "location_uuid")
+);
+
+CREATE TABLE "stock_transfer" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "from_location_uuid" UUID REFERENCES "location"("uuid") ON DELETE SET NULL,
+    "to_location_uuid" UUID REFERENCES "location"("uuid") ON DELETE SET NULL,
+    "quantity" INT NOT NULL CHECK ("quantity" > 0),
+    "note" VARCHAR,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+ALTER TABLE "terminal"
+ADD COLUMN "location_uuid" UUID REFERENCES "location"("uuid") ON DELETE SET NULL;
+
+ALTER TABLE "stocktake"
+ADD COLUMN "location_uuid" UUID REFERENCES "location"("uuid") ON DELETE SET NULL;
+
+ALTER TABLE "stock_movement"
+ADD COLUMN "location_uuid" UUID REFERENCES "location"("uuid") ON DELETE SET NULL,
+ADD COLUMN "stock_transfer_uuid" UUID REFERENCES "stock_transfer"("uuid") ON DELETE SET NULL,
+DROP CONSTRAINT "stock_movement_reason_check",
+ADD CONSTRAINT "stock_movement_reason_check" CHECK ("reason" IN ('sale', 'refund', 'delivery', 'correction', 'stocktake', 'transfer'));
+
+-- the stock so far is all in the default location
+INSERT INTO "location" ("name", "is_default") VALUES ('Cellar', true);
+
+INSERT INTO "article_stock" ("article_uuid", "location_uuid", "stock")
+SELECT "uuid", (SELECT "uuid" FROM "location" WHERE "is_default"), "stock"
+FROM "article"
+WHERE "stock" <> 0;
+
+UPDATE "stock_movement"
+SET "location_uuid" = (SELECT "uuid" FROM "location" WHERE "is_default");
@@ -0,0 +1,12 @@
+ALTER TABLE "article_transaction"
+DROP CONSTRAINT IF EXISTS "article_transaction_article_uuid_fkey";
+
+ALTER TABLE "article_transaction"
+ADD CONSTRAINT "article_transaction_article_uuid_fkey"
+FOREIGN KEY ("article_uuid") REFERENCES "article"("uuid") ON DELETE CASCADE;
+
+ALTER TABLE "article_type"
+DROP COLUMN "archived_at";
+
+ALTER TABLE "article"
+DROP COLUMN "archived_at";
@@ -0,0 +1,15 @@
+-- Articles and article types are archived instead of deleted, archived
+-- ones are hidden from the menu but stay referenced by their sales
+ALTER TABLE "article"
+ADD COLUMN "archived_at" TIMESTAMP;
+
+ALTER TABLE "article_type"
+ADD COLUMN "archived_at" TIMESTAMP;
+
+-- deleting an article deleted all of its sales, see 000003_add_cascading
+ALTER TABLE "article_transaction"
+DROP CONSTRAINT IF EXISTS "article_transaction_article_uuid_fkey";
+
+ALTER TABLE "article_transaction"
+ADD CONSTRAINT "article_transaction_article_uuid_fkey"
+FOREIGN KEY ("article_uuid") REFERENCES "article"("uuid");
@@ -0,0 +1,18 @@
+CREATE TABLE "article_price" (
+    "uuid" UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+    "article_uuid" UUID NOT NULL REFERENCES "article"("uuid") ON DELETE CASCADE,
+    "purchase_price" NUMERIC(12,2) NOT NULL,
+    "resell_price" NUMERIC(12,2) NOT NULL,
+    "valid_from" TIMESTAMP NOT NULL,
+    "valid_to" TIMESTAMP,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE ("article_uuid", "valid_from"),
+    CHECK ("valid_to" IS NULL OR "valid_to" > "valid_from")
+);
+
+CREATE INDEX ON "article_price" ("valid_from", "valid_to");
+
+-- the prices before the history was recorded are valid since forever
+INSERT INTO "article_price" ("article_uuid", "purchase_price", "resell_price", "valid_from")
+SELECT "uuid", "purchase_price", "resell_price", '1970-01-01'
+FROM "article";
@@ -0,0 +1,8 @@
+-- A failed webhook is retried on its own, the webhooks that already received
+-- the alert are not posted it again
+CREATE TABLE "stock_alert_webhook_delivery" (
+    "alert_uuid" UUID NOT NULL REFERENCES "stock_alert"("uuid") ON DELETE CASCADE,
+    "webhook_uuid" UUID NOT NULL REFERENCES "webhook"("uuid") ON DELETE CASCADE,
+    "delivered_at" TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY ("alert_uuid", "webhook_uuid")
+);
@@ -0,0 +1,2 @@
+ALTER TABLE "article_transaction"
+DROP COLUMN "deposit_of";
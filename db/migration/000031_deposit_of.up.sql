@@ -0,0 +1,25 @@
+-- A deposit line is linked to the sale line it was charged for, the deposit
+-- goes away with its sale line
+ALTER TABLE "article_transaction"
+ADD COLUMN "deposit_of" UUID REFERENCES "article_transaction"("uuid") ON DELETE CASCADE;
+
+-- Link the existing deposit lines to the sale lines of the same article and
+-- amount in their transaction, one deposit line per sale line
+WITH "sale" AS (
+    SELECT "uuid", "transaction_uuid", "article_uuid", "amount",
+        ROW_NUMBER() OVER (PARTITION BY "transaction_uuid", "article_uuid", "amount" ORDER BY "uuid") AS "n"
+    FROM "article_transaction"
+    WHERE "kind" = 'sale' AND "refund_of" IS NULL
+), "deposit" AS (
+    SELECT "uuid", "transaction_uuid", "article_uuid", "amount",
+        ROW_NUMBER() OVER (PARTITION BY "transaction_uuid", "article_uuid", "amount" ORDER BY "uuid") AS "n"
+    FROM "article_transaction"
+    WHERE "kind" = 'deposit' AND "refund_of" IS NULL
+)
+UPDATE "article_transaction"
+SET "deposit_of" = "sale"."uuid"
+FROM "deposit"
+JOIN "sale" USING ("transaction_uuid", "article_uuid", "amount", "n")
+WHERE "article_transaction"."uuid" = "deposit"."uuid";
+
+CREATE INDEX "article_transaction_deposit_of_idx" ON "article_transaction" ("deposit_of");
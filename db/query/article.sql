@@ -4,17 +4,27 @@ INSERT INTO article (
     "desc",
     purchase_price,
     resell_price,
-    article_type_uuid
+    article_type_uuid,
+    deposit,
+    vat_rate,
+    stock_policy,
+    reorder_threshold
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetArticleById :one
 SELECT * FROM article
 WHERE uuid = $1 LIMIT 1;
 
+-- name: GetArticleByIdForUpdate :one
+SELECT * FROM article
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE;
+
 -- name: GetArticles :many
-SELECT * FROM article;
+SELECT * FROM article
+WHERE sqlc.arg('archived')::bool OR archived_at IS NULL;
 
 -- name: UpdateArticle :one
 UPDATE article
@@ -23,10 +33,22 @@ SET
     "desc" = COALESCE(sqlc.narg('desc'), "desc"),
     purchase_price = COALESCE(sqlc.narg('purchase_price'), purchase_price),
     resell_price = COALESCE(sqlc.narg('resell_price'), resell_price),
-    article_type_uuid = COALESCE(sqlc.narg('article_type_uuid'), article_type_uuid)
+    article_type_uuid = COALESCE(sqlc.narg('article_type_uuid'), article_type_uuid),
+    deposit = COALESCE(sqlc.narg('deposit'), deposit),
+    vat_rate = COALESCE(sqlc.narg('vat_rate'), vat_rate),
+    stock_policy = COALESCE(sqlc.narg('stock_policy'), stock_policy),
+    reorder_threshold = COALESCE(sqlc.narg('reorder_threshold'), reorder_threshold)
 WHERE uuid = sqlc.arg('uuid')
 RETURNING *;
 
--- name: DeleteArticle :exec
-DELETE FROM article
-WHERE uuid = $1;
+-- name: ArchiveArticle :one
+UPDATE article
+SET archived_at = COALESCE(archived_at, now())
+WHERE uuid = $1
+RETURNING *;
+
+-- name: RestoreArticle :one
+UPDATE article
+SET archived_at = NULL
+WHERE uuid = $1
+RETURNING *;
@@ -0,0 +1,20 @@
+-- name: CreateArticleComponent :one
+INSERT INTO article_component (
+    bundle_uuid,
+    component_uuid,
+    amount
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetArticleComponents :many
+SELECT * FROM article_component
+WHERE bundle_uuid = $1
+ORDER BY component_uuid;
+
+-- name: IsArticleComponent :one
+SELECT EXISTS (SELECT 1 FROM article_component WHERE component_uuid = $1)::bool AS is_component;
+
+-- name: DeleteArticleComponents :exec
+DELETE FROM article_component
+WHERE bundle_uuid = $1;
@@ -0,0 +1,68 @@
+-- name: CreateArticlePrice :one
+INSERT INTO article_price (
+    article_uuid,
+    purchase_price,
+    resell_price,
+    valid_from,
+    valid_to
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetArticlePriceById :one
+SELECT * FROM article_price
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetArticlePriceAt :one
+SELECT * FROM article_price
+WHERE article_uuid = sqlc.arg('article_uuid') AND valid_from <= sqlc.arg('at')::timestamp
+ORDER BY valid_from DESC
+LIMIT 1;
+
+-- name: GetArticlePrices :many
+SELECT * FROM article_price
+WHERE article_uuid = $1
+ORDER BY valid_from;
+
+-- name: GetArticlePricesAt :many
+SELECT * FROM article_price
+WHERE valid_from <= sqlc.arg('at')::timestamp AND (valid_to IS NULL OR valid_to > sqlc.arg('at')::timestamp);
+
+-- name: UpdateArticlePrice :one
+UPDATE article_price
+SET
+    purchase_price = $2,
+    resell_price = $3
+WHERE uuid = $1
+RETURNING *;
+
+-- name: EndArticlePrice :exec
+UPDATE article_price
+SET valid_to = sqlc.narg('valid_to')
+WHERE uuid = sqlc.arg('uuid');
+
+-- name: ExtendArticlePrice :exec
+UPDATE article_price
+SET valid_to = sqlc.narg('valid_to')
+WHERE article_uuid = sqlc.arg('article_uuid') AND valid_to = sqlc.arg('valid_from');
+
+-- name: SetLaterPurchasePrice :exec
+UPDATE article_price
+SET purchase_price = sqlc.arg('purchase_price')
+WHERE article_uuid = sqlc.arg('article_uuid') AND valid_from > sqlc.arg('valid_from');
+
+-- name: DeleteArticlePrice :exec
+DELETE FROM article_price
+WHERE uuid = $1;
+
+-- name: ApplyArticlePrices :many
+UPDATE article
+SET
+    purchase_price = p.purchase_price,
+    resell_price = p.resell_price
+FROM article_price p
+WHERE p.article_uuid = article.uuid
+    AND p.valid_from <= sqlc.arg('at')::timestamp AND (p.valid_to IS NULL OR p.valid_to > sqlc.arg('at')::timestamp)
+    AND (article.purchase_price <> p.purchase_price OR article.resell_price <> p.resell_price)
+    AND (sqlc.narg('article_uuid')::uuid IS NULL OR article.uuid = sqlc.narg('article_uuid'))
+RETURNING article.uuid;
@@ -3,9 +3,18 @@ INSERT INTO article_transaction (
     article_uuid,
     transaction_uuid,
     amount,
-    price
+    price,
+    pricing_rule_uuid,
+    kind,
+    bundle_of,
+    vat_rate,
+    net,
+    tax,
+    deposit_of,
+    unit_cost
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+    (SELECT purchase_price FROM article WHERE uuid = $1)
 ) RETURNING *;
 
 -- name: GetArticleTransactionById :one
@@ -18,17 +27,120 @@ SELECT * FROM article_transaction;
 -- name: UpdateArticleTransaction :one
 UPDATE article_transaction
 SET
-    article_uuid = COALESCE(sqlc.narg('article_uuid'), article_uuid),
-    transaction_uuid = COALESCE(sqlc.narg('transaction_uuid'), transaction_uuid),
-    amount = COALESCE(sqlc.narg('amount'), amount),
-    price = COALESCE(sqlc.narg('price'), price)
-WHERE uuid = sqlc.arg('uuid')
+    article_uuid = $2,
+    transaction_uuid = $3,
+    amount = $4,
+    price = $5,
+    pricing_rule_uuid = $6,
+    vat_rate = $7,
+    net = $8,
+    tax = $9,
+    unit_cost = (SELECT purchase_price FROM article WHERE article.uuid = $2)
+WHERE uuid = $1
 RETURNING *;
 
 -- name: DeleteArticleTransaction :exec
 DELETE FROM article_transaction
 WHERE uuid = $1;
 
+-- name: DeleteArticleTransactionsByTransaction :exec
+DELETE FROM article_transaction
+WHERE transaction_uuid = $1;
+
 -- name: GetArticleTransactionsGroupedByArticle :many
-select article_uuid, sum(amount) as amount from article_transaction
+select article_uuid, sum(amount)::bigint as amount, sum(revenue)::numeric(12,2) as revenue,
+    sum(cost)::numeric(12,2) as cost, (sum(revenue) - sum(cost))::numeric(12,2) as profit from (
+    select article_transaction.article_uuid, article_transaction.amount,
+        article_transaction.amount * article_transaction.price as revenue,
+        article_transaction.amount * article_transaction.unit_cost as cost
+    from article_transaction
+    join transaction on transaction.uuid = article_transaction.transaction_uuid
+    where article_transaction.kind = 'sale'
+    and transaction.status = 'charged'
+    and not exists (select 1 from article_transaction component where component.bundle_of = article_transaction.uuid)
+    union all
+    select component.article_uuid, component.amount, bundle.amount * bundle.price * coalesce(
+        component.amount * component.price / nullif(sum(component.amount * component.price) over (partition by component.bundle_of), 0),
+        1.0 / count(*) over (partition by component.bundle_of)
+    ) as revenue, component.amount * component.unit_cost as cost
+    from article_transaction component
+    join article_transaction bundle on bundle.uuid = component.bundle_of
+    join transaction on transaction.uuid = component.transaction_uuid
+    where component.kind = 'component'
+    and transaction.status = 'charged'
+) lines
 group by article_uuid;
+
+-- name: GetTransactionLinesTotal :one
+SELECT COALESCE(SUM(amount * price), 0)::numeric(12,2) AS total FROM article_transaction
+WHERE transaction_uuid = $1
+AND kind <> 'component';
+
+-- name: GetArticleTransactionsByTransaction :many
+SELECT * FROM article_transaction
+WHERE transaction_uuid = $1;
+
+-- name: CreateRefundArticleTransaction :one
+INSERT INTO article_transaction (
+    article_uuid,
+    transaction_uuid,
+    amount,
+    price,
+    refund_of,
+    pricing_rule_uuid,
+    kind,
+    bundle_of,
+    vat_rate,
+    net,
+    tax,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+    (SELECT original.unit_cost FROM article_transaction original WHERE original.uuid = $5)
+) RETURNING *;
+
+-- name: GetRefundedAmount :one
+SELECT COALESCE(-SUM(amount), 0)::int AS refunded FROM article_transaction
+WHERE refund_of = $1;
+
+-- name: GetArticleTransactionsGroupedByPricingRule :many
+select article_transaction.pricing_rule_uuid, sum(article_transaction.amount) as amount,
+    sum(article_transaction.amount * article_transaction.price)::numeric(12,2) as revenue
+from article_transaction
+join transaction on transaction.uuid = article_transaction.transaction_uuid
+where article_transaction.kind = 'sale'
+and transaction.status = 'charged'
+group by article_transaction.pricing_rule_uuid;
+
+-- name: GetDepositLiabilities :many
+select article_transaction.article_uuid, sum(article_transaction.amount) as bottles,
+    sum(article_transaction.amount * article_transaction.price)::numeric(12,2) as liability
+from article_transaction
+join transaction on transaction.uuid = article_transaction.transaction_uuid
+where article_transaction.kind in ('deposit', 'deposit_return')
+and transaction.status = 'charged'
+group by article_transaction.article_uuid;
+
+-- name: DeleteDepositLines :exec
+DELETE FROM article_transaction
+WHERE deposit_of = $1;
+
+-- name: GetComponentLines :many
+SELECT * FROM article_transaction
+WHERE bundle_of = $1;
+
+-- name: GetArticleTransactionsOfTransactions :many
+SELECT * FROM article_transaction
+WHERE transaction_uuid IN (
+    SELECT uuid FROM transaction
+    WHERE (sqlc.narg('resident')::varchar IS NULL OR resident = sqlc.narg('resident'))
+    AND (sqlc.narg('event_uuid')::uuid IS NULL OR event_uuid = sqlc.narg('event_uuid'))
+);
+
+-- name: GetReceiptLines :many
+SELECT article.name AS article_name, article_transaction.amount, article_transaction.price, article_transaction.kind, article_transaction.vat_rate
+FROM article_transaction
+JOIN article ON article.uuid = article_transaction.article_uuid
+WHERE article_transaction.transaction_uuid = $1
+AND article_transaction.kind <> 'component'
+ORDER BY article.name, article_transaction.kind DESC;
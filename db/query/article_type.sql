@@ -3,9 +3,10 @@ INSERT INTO article_type (
     "name",
     "desc",
     "icon_codepoint",
-    color
+    color,
+    vat_rate
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetArticleTypeById :one
@@ -13,10 +14,16 @@ SELECT * FROM article_type
 WHERE uuid = $1 LIMIT 1;
 
 -- name: GetArticleTypes :many
-SELECT * FROM article_type;
+SELECT * FROM article_type
+WHERE sqlc.arg('archived')::bool OR archived_at IS NULL;
 
 -- name: GetArticleTypesWithArticles :many
-select sqlc.embed(article_type), article.* from article_type left join article on article.article_type_uuid = article_type.uuid;
+select sqlc.embed(article_type), article.uuid, article.name, article."desc", article_price.purchase_price, article_price.resell_price, article.article_type_uuid, article.deposit, article.vat_rate, article.stock, article.stock_policy, article.reorder_threshold, article.archived_at
+from article_type
+left join (article join article_price on article_price.article_uuid = article.uuid and article_price.valid_from <= sqlc.arg('at')::timestamp and (article_price.valid_to is null or article_price.valid_to > sqlc.arg('at')::timestamp))
+    on article.article_type_uuid = article_type.uuid and (article.archived_at is null or article.archived_at > sqlc.arg('at')::timestamp)
+where article_type.archived_at is null or article_type.archived_at > sqlc.arg('at')::timestamp
+order by article_type.uuid;
 
 -- name: UpdateArticleType :one
 UPDATE article_type
@@ -24,10 +31,19 @@ SET
     "name" = COALESCE(sqlc.narg('name'), "name"),
     "desc" = COALESCE(sqlc.narg('desc'), "desc"),
     "icon_codepoint" = COALESCE(sqlc.narg('icon_codepoint'), "icon_codepoint"),
-    "color" = COALESCE(sqlc.narg('color'), "color")
+    "color" = COALESCE(sqlc.narg('color'), "color"),
+    vat_rate = COALESCE(sqlc.narg('vat_rate'), vat_rate)
 WHERE uuid = sqlc.arg('uuid')
 RETURNING *;
 
--- name: DeleteArticleType :exec
-DELETE FROM article_type
-WHERE uuid = $1;
+-- name: ArchiveArticleType :one
+UPDATE article_type
+SET archived_at = COALESCE(archived_at, now())
+WHERE uuid = $1
+RETURNING *;
+
+-- name: RestoreArticleType :one
+UPDATE article_type
+SET archived_at = NULL
+WHERE uuid = $1
+RETURNING *;
@@ -0,0 +1,114 @@
+-- name: LockClosings :exec
+LOCK TABLE closing IN SHARE ROW EXCLUSIVE MODE;
+
+-- name: LockClosingsForSale :exec
+LOCK TABLE closing IN ROW EXCLUSIVE MODE;
+
+-- name: GetLastClosing :one
+SELECT * FROM closing
+ORDER BY period_end DESC
+LIMIT 1;
+
+-- name: IsPeriodClosed :one
+SELECT EXISTS (
+    SELECT 1 FROM closing
+    WHERE period_end >= sqlc.arg('date')::timestamp
+) AS closed;
+
+-- name: GetClosings :many
+SELECT * FROM closing
+ORDER BY period_end DESC;
+
+-- name: GetClosingById :one
+SELECT * FROM closing
+WHERE uuid = $1 LIMIT 1;
+
+-- name: CreateClosing :one
+INSERT INTO closing (
+    period_start,
+    period_end,
+    transactions,
+    revenue,
+    refunds,
+    refund_total,
+    deposits_charged,
+    deposits_returned,
+    pending
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: CreateClosingArticleType :one
+INSERT INTO closing_article_type (
+    closing_uuid,
+    article_type_uuid,
+    name,
+    amount,
+    revenue
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: CreateClosingPaymentBackend :one
+INSERT INTO closing_payment_backend (
+    closing_uuid,
+    payment_backend,
+    transactions,
+    total
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetClosingArticleTypes :many
+SELECT * FROM closing_article_type
+WHERE closing_uuid = $1
+ORDER BY name;
+
+-- name: GetClosingPaymentBackends :many
+SELECT * FROM closing_payment_backend
+WHERE closing_uuid = $1
+ORDER BY payment_backend;
+
+-- name: GetClosingTransactionTotals :one
+SELECT
+    COUNT(*) FILTER (WHERE status = 'charged' AND refund_of IS NULL AND split_of IS NULL)::int AS transactions,
+    COUNT(*) FILTER (WHERE status = 'charged' AND refund_of IS NOT NULL AND split_of IS NULL)::int AS refunds,
+    COALESCE(-SUM(price) FILTER (WHERE status = 'charged' AND refund_of IS NOT NULL AND split_of IS NULL), 0)::numeric AS refund_total,
+    COUNT(*) FILTER (WHERE status = 'pending')::int AS pending
+FROM transaction
+WHERE (sqlc.narg('period_start')::timestamp IS NULL OR "date" > sqlc.narg('period_start'))
+AND "date" <= sqlc.arg('period_end');
+
+-- name: GetClosingArticleTotals :one
+SELECT
+    COALESCE(SUM(article_transaction.amount * article_transaction.price) FILTER (WHERE article_transaction.kind = 'sale'), 0)::numeric AS revenue,
+    COALESCE(SUM(article_transaction.amount * article_transaction.price) FILTER (WHERE article_transaction.kind = 'deposit'), 0)::numeric AS deposits_charged,
+    COALESCE(-SUM(article_transaction.amount * article_transaction.price) FILTER (WHERE article_transaction.kind = 'deposit_return'), 0)::numeric AS deposits_returned
+FROM article_transaction
+JOIN transaction ON transaction.uuid = article_transaction.transaction_uuid
+WHERE transaction.status = 'charged'
+AND (sqlc.narg('period_start')::timestamp IS NULL OR transaction."date" > sqlc.narg('period_start'))
+AND transaction."date" <= sqlc.arg('period_end');
+
+-- name: GetClosingArticleTypeTotals :many
+SELECT article_type.uuid AS article_type_uuid, article_type.name, SUM(article_transaction.amount)::int AS amount, SUM(article_transaction.amount * article_transaction.price)::numeric AS revenue
+FROM article_transaction
+JOIN transaction ON transaction.uuid = article_transaction.transaction_uuid
+JOIN article ON article.uuid = article_transaction.article_uuid
+JOIN article_type ON article_type.uuid = article.article_type_uuid
+WHERE article_transaction.kind = 'sale'
+AND transaction.status = 'charged'
+AND (sqlc.narg('period_start')::timestamp IS NULL OR transaction."date" > sqlc.narg('period_start'))
+AND transaction."date" <= sqlc.arg('period_end')
+GROUP BY article_type.uuid, article_type.name
+ORDER BY article_type.name;
+
+-- name: GetClosingPaymentBackendTotals :many
+SELECT payment_backend, COUNT(*)::int AS transactions, SUM(price)::numeric AS total
+FROM transaction
+WHERE status = 'charged'
+AND payment_backend <> 'split'
+AND (sqlc.narg('period_start')::timestamp IS NULL OR "date" > sqlc.narg('period_start'))
+AND "date" <= sqlc.arg('period_end')
+GROUP BY payment_backend
+ORDER BY payment_backend;
@@ -28,3 +28,10 @@ RETURNING *;
 -- name: DeleteEvent :exec
 DELETE FROM event
 WHERE uuid = $1;
+
+-- name: GetEventByDate :one
+SELECT * FROM event
+WHERE from_date <= sqlc.arg('date')::timestamp
+AND to_date >= sqlc.arg('date')::timestamp
+ORDER BY from_date DESC
+LIMIT 1;
@@ -0,0 +1,56 @@
+-- name: GetOrCreateWalletAccount :one
+INSERT INTO ledger_account (
+    "name",
+    kind,
+    resident
+) VALUES (
+    sqlc.arg('resident')::varchar, 'wallet', sqlc.arg('resident')::varchar
+)
+ON CONFLICT (resident) DO UPDATE
+SET "name" = ledger_account.name
+RETURNING *;
+
+-- name: GetWalletAccount :one
+SELECT * FROM ledger_account
+WHERE resident = $1 LIMIT 1;
+
+-- name: GetSystemLedgerAccount :one
+SELECT * FROM ledger_account
+WHERE kind = $1
+AND resident IS NULL
+LIMIT 1;
+
+-- name: LockLedgerAccount :one
+SELECT * FROM ledger_account
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetLedgerAccountBalance :one
+SELECT COALESCE(SUM(amount), 0)::numeric(12,2) AS balance FROM ledger_entry
+WHERE account_uuid = $1;
+
+-- name: GetLedgerPostingByDetails :one
+SELECT * FROM ledger_posting
+WHERE details = $1 LIMIT 1;
+
+-- name: CreateLedgerPosting :one
+INSERT INTO ledger_posting (
+    details
+) VALUES (
+    $1
+) RETURNING *;
+
+-- name: CreateLedgerEntry :one
+INSERT INTO ledger_entry (
+    posting_uuid,
+    account_uuid,
+    amount
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetLedgerEntriesByAccount :many
+SELECT ledger_entry.uuid, ledger_entry.amount, ledger_posting.details, ledger_posting.created_at FROM ledger_entry
+JOIN ledger_posting ON ledger_posting.uuid = ledger_entry.posting_uuid
+WHERE ledger_entry.account_uuid = sqlc.arg('account_uuid')::uuid
+ORDER BY ledger_posting.created_at DESC;
@@ -0,0 +1,87 @@
+-- name: CreateLocation :one
+INSERT INTO location (
+    "name"
+) VALUES (
+    $1
+) RETURNING *;
+
+-- name: GetLocationById :one
+SELECT * FROM location
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetDefaultLocation :one
+SELECT * FROM location
+WHERE is_default
+LIMIT 1;
+
+-- name: GetLocations :many
+SELECT * FROM location
+ORDER BY "name";
+
+-- name: UpdateLocation :one
+UPDATE location
+SET "name" = COALESCE(sqlc.narg('name'), "name")
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: ClearDefaultLocation :exec
+UPDATE location
+SET is_default = false
+WHERE is_default;
+
+-- name: SetDefaultLocation :one
+UPDATE location
+SET is_default = true
+WHERE uuid = $1
+RETURNING *;
+
+-- name: DeleteLocation :exec
+DELETE FROM location
+WHERE uuid = $1;
+
+-- name: HasLocationStock :one
+SELECT EXISTS (SELECT 1 FROM article_stock WHERE location_uuid = $1 AND stock <> 0)::bool AS has_stock;
+
+-- name: MoveLocationStock :one
+INSERT INTO article_stock (article_uuid, location_uuid, stock)
+VALUES (sqlc.arg('article_uuid'), sqlc.arg('location_uuid'), sqlc.arg('quantity')::int)
+ON CONFLICT (article_uuid, location_uuid) DO UPDATE
+SET stock = article_stock.stock + EXCLUDED.stock
+RETURNING stock;
+
+-- name: GetTransactionStockLocation :one
+SELECT COALESCE(
+    (SELECT te.location_uuid FROM transaction tr JOIN terminal te ON te.uuid = tr.terminal_uuid WHERE tr.uuid = $1),
+    (SELECT l.uuid FROM location l WHERE l.is_default)
+)::uuid AS location_uuid;
+
+-- name: GetStockByLocation :many
+SELECT
+    a.uuid AS article_uuid,
+    a.name AS article,
+    a.stock,
+    s.location_uuid,
+    l.name AS location,
+    s.stock AS location_stock
+FROM article a
+LEFT JOIN article_stock s ON s.article_uuid = a.uuid AND s.stock <> 0
+LEFT JOIN location l ON l.uuid = s.location_uuid
+WHERE NOT EXISTS (SELECT 1 FROM article_component c WHERE c.bundle_uuid = a.uuid)
+ORDER BY a.name, a.uuid, l.name;
+
+-- name: CreateStockTransfer :one
+INSERT INTO stock_transfer (
+    article_uuid,
+    from_location_uuid,
+    to_location_uuid,
+    quantity,
+    note
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetStockTransfers :many
+SELECT * FROM stock_transfer
+WHERE (sqlc.narg('article_uuid')::uuid IS NULL OR article_uuid = sqlc.narg('article_uuid'))
+AND (sqlc.narg('location_uuid')::uuid IS NULL OR from_location_uuid = sqlc.narg('location_uuid') OR to_location_uuid = sqlc.narg('location_uuid'))
+ORDER BY created_at DESC;
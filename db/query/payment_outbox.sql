@@ -0,0 +1,77 @@
+-- name: CreatePaymentOutbox :one
+INSERT INTO payment_outbox (
+    transaction_uuid,
+    username,
+    amount,
+    details,
+    backend,
+    reversal
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPaymentOutboxById :one
+SELECT * FROM payment_outbox
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetPaymentOutboxByTransaction :one
+SELECT * FROM payment_outbox
+WHERE transaction_uuid = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: UpdatePaymentOutboxAmount :exec
+UPDATE payment_outbox
+SET amount = $2
+WHERE transaction_uuid = $1
+AND NOT reversal
+AND (processed_at IS NULL OR EXISTS (
+    SELECT 1 FROM "transaction" t
+    WHERE t.uuid = payment_outbox.transaction_uuid
+    AND t.status = 'failed'
+));
+
+-- name: GetDuePaymentOutbox :many
+SELECT * FROM payment_outbox
+WHERE processed_at IS NULL
+AND NOT needs_reconciliation
+AND next_attempt_at <= now()
+ORDER BY created_at
+LIMIT $1;
+
+-- name: GetPaymentOutboxNeedingReconciliation :many
+SELECT * FROM payment_outbox
+WHERE processed_at IS NULL
+AND needs_reconciliation
+ORDER BY created_at;
+
+-- name: RecordPaymentOutboxAttempt :one
+UPDATE payment_outbox
+SET
+    attempts = attempts + 1,
+    last_error = sqlc.narg('last_error'),
+    needs_reconciliation = sqlc.arg('needs_reconciliation'),
+    next_attempt_at = sqlc.arg('next_attempt_at')
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: RequeuePaymentOutbox :one
+UPDATE payment_outbox
+SET
+    attempts = 0,
+    needs_reconciliation = false,
+    next_attempt_at = now(),
+    processed_at = NULL
+WHERE uuid = $1
+RETURNING *;
+
+-- name: MarkPaymentOutboxProcessed :exec
+UPDATE payment_outbox
+SET processed_at = now()
+WHERE uuid = $1;
+
+-- name: GetPendingPaymentAmount :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS pending FROM payment_outbox
+WHERE username = sqlc.arg('username')
+AND backend = sqlc.arg('backend')
+AND processed_at IS NULL;
@@ -0,0 +1,46 @@
+-- name: CreatePricingRule :one
+INSERT INTO pricing_rule (
+    "name",
+    article_uuid,
+    article_type_uuid,
+    event_uuid,
+    weekdays,
+    starts_at,
+    ends_at,
+    fixed_price,
+    percent_off,
+    priority
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetPricingRuleById :one
+SELECT * FROM pricing_rule
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetPricingRules :many
+SELECT * FROM pricing_rule
+ORDER BY priority DESC, created_at;
+
+-- name: GetPricingRulesForEvent :many
+SELECT * FROM pricing_rule
+WHERE event_uuid IS NULL OR event_uuid = sqlc.narg('event_uuid')::uuid
+ORDER BY priority DESC, created_at;
+
+-- name: UpdatePricingRule :one
+UPDATE pricing_rule
+SET
+    "name" = COALESCE(sqlc.narg('name'), "name"),
+    weekdays = COALESCE(sqlc.narg('weekdays'), weekdays),
+    starts_at = COALESCE(sqlc.narg('starts_at'), starts_at),
+    ends_at = COALESCE(sqlc.narg('ends_at'), ends_at),
+    -- a rule is either a fixed price or a discount, setting one clears the other
+    fixed_price = CASE WHEN sqlc.narg('percent_off')::int IS NULL THEN COALESCE(sqlc.narg('fixed_price'), fixed_price) END,
+    percent_off = CASE WHEN sqlc.narg('fixed_price')::numeric IS NULL THEN COALESCE(sqlc.narg('percent_off'), percent_off) END,
+    priority = COALESCE(sqlc.narg('priority'), priority)
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: DeletePricingRule :exec
+DELETE FROM pricing_rule
+WHERE uuid = $1;
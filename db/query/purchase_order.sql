@@ -0,0 +1,79 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_order (
+    supplier_uuid,
+    note
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetPurchaseOrderById :one
+SELECT * FROM purchase_order
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetPurchaseOrderByIdForUpdate :one
+SELECT * FROM purchase_order
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetPurchaseOrders :many
+SELECT * FROM purchase_order
+WHERE (sqlc.narg('supplier_uuid')::uuid IS NULL OR supplier_uuid = sqlc.narg('supplier_uuid'))
+AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC;
+
+-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_order
+SET status = $1
+WHERE uuid = $2
+RETURNING *;
+
+-- name: CreatePurchaseOrderLine :one
+INSERT INTO purchase_order_line (
+    purchase_order_uuid,
+    article_uuid,
+    quantity,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetPurchaseOrderLines :many
+SELECT * FROM purchase_order_line
+WHERE purchase_order_uuid = $1
+ORDER BY uuid;
+
+-- name: ReceivePurchaseOrderLine :one
+UPDATE purchase_order_line
+SET received = received + sqlc.arg('quantity')::int
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: CreateGoodsReceipt :one
+INSERT INTO goods_receipt (
+    purchase_order_uuid,
+    note
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetGoodsReceipts :many
+SELECT * FROM goods_receipt
+WHERE purchase_order_uuid = $1
+ORDER BY received_at;
+
+-- name: CreateGoodsReceiptLine :one
+INSERT INTO goods_receipt_line (
+    goods_receipt_uuid,
+    purchase_order_line_uuid,
+    article_uuid,
+    quantity,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetGoodsReceiptLinesOfOrder :many
+SELECT goods_receipt_line.* FROM goods_receipt_line
+JOIN goods_receipt ON goods_receipt.uuid = goods_receipt_line.goods_receipt_uuid
+WHERE goods_receipt.purchase_order_uuid = $1
+ORDER BY goods_receipt_line.uuid;
@@ -0,0 +1,28 @@
+-- name: CreateResidentGroup :one
+INSERT INTO resident_group (
+    "name",
+    min_balance,
+    daily_cap
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetResidentGroupById :one
+SELECT * FROM resident_group
+WHERE name = $1 LIMIT 1;
+
+-- name: GetResidentGroups :many
+SELECT * FROM resident_group
+ORDER BY name;
+
+-- name: UpdateResidentGroup :one
+UPDATE resident_group
+SET
+    min_balance = COALESCE(sqlc.narg('min_balance'), min_balance),
+    daily_cap = COALESCE(sqlc.narg('daily_cap'), daily_cap)
+WHERE name = sqlc.arg('name')
+RETURNING *;
+
+-- name: DeleteResidentGroup :exec
+DELETE FROM resident_group
+WHERE name = $1;
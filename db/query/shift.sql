@@ -0,0 +1,62 @@
+-- name: CreateShift :one
+INSERT INTO shift (
+    bartender,
+    terminal_uuid,
+    event_uuid,
+    opening_float
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetShiftById :one
+SELECT * FROM shift
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetShiftByIdForUpdate :one
+SELECT * FROM shift
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetOpenShiftByTerminal :one
+SELECT * FROM shift
+WHERE closed_at IS NULL
+AND terminal_uuid IS NOT DISTINCT FROM sqlc.narg('terminal_uuid')::uuid
+LIMIT 1;
+
+-- name: GetShifts :many
+SELECT * FROM shift
+WHERE (sqlc.narg('event_uuid')::uuid IS NULL OR event_uuid = sqlc.narg('event_uuid'))
+AND (NOT sqlc.arg('open')::bool OR closed_at IS NULL)
+ORDER BY opened_at DESC;
+
+-- name: CloseShift :one
+UPDATE shift
+SET
+    closed_at = sqlc.arg('closed_at')::timestamp,
+    expected_cash = sqlc.arg('expected_cash')::numeric,
+    counted_cash = sqlc.arg('counted_cash')::numeric,
+    difference = sqlc.arg('counted_cash')::numeric - sqlc.arg('expected_cash')::numeric
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: CreateShiftCount :one
+INSERT INTO shift_count (
+    shift_uuid,
+    denomination,
+    count
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetShiftCounts :many
+SELECT * FROM shift_count
+WHERE shift_uuid = $1
+ORDER BY denomination DESC;
+
+-- name: GetShiftCashTotal :one
+SELECT COALESCE(SUM(price), 0)::numeric AS total FROM transaction
+WHERE payment_backend = 'cash'
+AND status = 'charged'
+AND "date" >= sqlc.arg('from')::timestamp
+AND "date" <= sqlc.arg('until')::timestamp
+AND terminal_uuid IS NOT DISTINCT FROM sqlc.narg('terminal_uuid')::uuid;
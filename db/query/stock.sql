@@ -0,0 +1,93 @@
+-- name: MoveArticleStock :one
+UPDATE article
+SET stock = stock + sqlc.arg('quantity')::int
+WHERE uuid = sqlc.arg('uuid')
+RETURNING stock, stock_policy, reorder_threshold;
+
+-- name: CreateStockMovement :one
+INSERT INTO stock_movement (
+    article_uuid,
+    quantity,
+    stock_after,
+    reason,
+    article_transaction_uuid,
+    note,
+    goods_receipt_uuid,
+    stocktake_uuid,
+    location_uuid,
+    stock_transfer_uuid
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetStockMovementsByArticle :many
+SELECT * FROM stock_movement
+WHERE article_uuid = $1
+ORDER BY created_at DESC, uuid;
+
+-- name: ReceiveArticleStock :one
+UPDATE article
+SET
+    purchase_price = ROUND(
+        (GREATEST(stock, 0) * purchase_price + sqlc.arg('quantity')::int * sqlc.arg('unit_cost')::numeric)
+        / (GREATEST(stock, 0) + sqlc.arg('quantity')::int), 2),
+    stock = stock + sqlc.arg('quantity')::int
+WHERE uuid = sqlc.arg('uuid')
+RETURNING stock, purchase_price;
+
+-- name: CreateStockAlert :exec
+INSERT INTO stock_alert (article_uuid, stock, reorder_threshold)
+SELECT sqlc.arg('article_uuid')::uuid, sqlc.arg('stock')::int, sqlc.arg('reorder_threshold')::int
+WHERE NOT EXISTS (
+    SELECT 1 FROM stock_alert
+    WHERE article_uuid = sqlc.arg('article_uuid')::uuid
+    AND created_at > sqlc.arg('since')::timestamp
+);
+
+-- name: GetStockAlerts :many
+SELECT * FROM stock_alert
+ORDER BY created_at DESC
+LIMIT $1;
+
+-- name: GetDueStockAlerts :many
+SELECT * FROM stock_alert
+WHERE processed_at IS NULL
+AND next_attempt_at <= now()
+ORDER BY created_at
+LIMIT $1;
+
+-- name: RecordStockAlertAttempt :one
+UPDATE stock_alert
+SET
+    attempts = attempts + 1,
+    last_error = sqlc.narg('last_error'),
+    next_attempt_at = sqlc.arg('next_attempt_at')
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: MarkStockAlertProcessed :exec
+UPDATE stock_alert
+SET processed_at = now()
+WHERE uuid = $1;
+
+-- name: GetStockAlertDeliveries :many
+SELECT channel FROM stock_alert_delivery
+WHERE alert_uuid = $1;
+
+-- name: CreateStockAlertDelivery :exec
+INSERT INTO stock_alert_delivery (alert_uuid, channel)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: GetArticleStockForUpdate :one
+SELECT (CASE
+    WHEN sqlc.narg('location_uuid')::uuid IS NULL THEN a.stock
+    ELSE COALESCE((
+        SELECT s.stock FROM article_stock s
+        WHERE s.article_uuid = a.uuid
+        AND s.location_uuid = sqlc.narg('location_uuid')::uuid
+    ), 0)
+END)::int AS stock
+FROM article a
+WHERE a.uuid = sqlc.arg('article_uuid')::uuid
+FOR UPDATE OF a;
@@ -0,0 +1,63 @@
+-- name: CreateStocktake :one
+INSERT INTO stocktake (
+    note,
+    location_uuid
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetStocktakeById :one
+SELECT * FROM stocktake
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetStocktakeByIdForUpdate :one
+SELECT * FROM stocktake
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetOpenStocktake :one
+SELECT * FROM stocktake
+WHERE closed_at IS NULL
+LIMIT 1;
+
+-- name: GetStocktakes :many
+SELECT * FROM stocktake
+ORDER BY opened_at DESC;
+
+-- name: CloseStocktake :one
+UPDATE stocktake
+SET closed_at = now()
+WHERE uuid = $1
+RETURNING *;
+
+-- name: CreateStocktakeLines :exec
+INSERT INTO stocktake_line (stocktake_uuid, article_uuid, expected, unit_cost)
+SELECT st.uuid, a.uuid, CASE WHEN st.location_uuid IS NULL THEN a.stock ELSE COALESCE(s.stock, 0) END, a.purchase_price
+FROM stocktake st
+JOIN article a ON sqlc.narg('article_uuid')::uuid IS NULL OR a.uuid = sqlc.narg('article_uuid')
+LEFT JOIN article_stock s ON s.article_uuid = a.uuid AND s.location_uuid = st.location_uuid
+WHERE st.uuid = sqlc.arg('stocktake_uuid')::uuid
+AND NOT EXISTS (SELECT 1 FROM article_component c WHERE c.bundle_uuid = a.uuid)
+ON CONFLICT DO NOTHING;
+
+-- name: CountStocktakeLine :one
+UPDATE stocktake_line
+SET counted = CASE WHEN sqlc.arg('replace')::bool THEN 0 ELSE COALESCE(counted, 0) END + sqlc.arg('counted')::int
+WHERE stocktake_uuid = sqlc.arg('stocktake_uuid')
+AND article_uuid = sqlc.arg('article_uuid')
+RETURNING *;
+
+-- name: GetStocktakeLines :many
+SELECT
+    l.article_uuid,
+    a.name AS article,
+    a.article_type_uuid,
+    t.name AS article_type,
+    l.expected,
+    l.counted,
+    l.unit_cost
+FROM stocktake_line l
+JOIN article a ON a.uuid = l.article_uuid
+JOIN article_type t ON t.uuid = a.article_type_uuid
+WHERE l.stocktake_uuid = $1
+ORDER BY t.name, a.name;
@@ -0,0 +1,34 @@
+-- name: CreateSupplier :one
+INSERT INTO supplier (
+    "name",
+    contact,
+    email,
+    phone
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetSupplierById :one
+SELECT * FROM supplier
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetSuppliers :many
+SELECT * FROM supplier
+ORDER BY "name";
+
+-- name: UpdateSupplier :one
+UPDATE supplier
+SET
+    "name" = COALESCE(sqlc.narg('name'), "name"),
+    contact = COALESCE(sqlc.narg('contact'), contact),
+    email = COALESCE(sqlc.narg('email'), email),
+    phone = COALESCE(sqlc.narg('phone'), phone)
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: DeleteSupplier :exec
+DELETE FROM supplier
+WHERE uuid = $1;
+
+-- name: HasSupplierPurchaseOrders :one
+SELECT EXISTS (SELECT 1 FROM purchase_order WHERE supplier_uuid = $1) AS has_orders;
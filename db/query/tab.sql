@@ -0,0 +1,74 @@
+-- name: CreateTab :one
+INSERT INTO tab (
+    resident,
+    terminal_uuid,
+    event_uuid,
+    payment_backend
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetTabById :one
+SELECT * FROM tab
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetTabByIdForUpdate :one
+SELECT * FROM tab
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetTabByTransaction :one
+SELECT * FROM tab
+WHERE transaction_uuid = $1 LIMIT 1;
+
+-- name: GetUnclosedTabByResident :one
+SELECT * FROM tab
+WHERE resident = $1
+AND status <> 'closed'
+LIMIT 1;
+
+-- name: GetTabs :many
+SELECT * FROM tab
+WHERE (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
+ORDER BY opened_at DESC;
+
+-- name: GetOpenTabsOfEndedEvents :many
+SELECT * FROM tab
+WHERE status = 'open'
+AND event_uuid IN (SELECT uuid FROM event WHERE to_date < now());
+
+-- name: CloseTab :one
+UPDATE tab
+SET
+    status = 'closed',
+    transaction_uuid = sqlc.narg('transaction_uuid'),
+    closed_at = now()
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: FlagTab :one
+UPDATE tab
+SET status = 'flagged'
+WHERE uuid = $1
+RETURNING *;
+
+-- name: CreateTabItem :one
+INSERT INTO tab_item (
+    tab_uuid,
+    article_uuid,
+    amount,
+    price,
+    pricing_rule_uuid,
+    deposit
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetTabItemsByTab :many
+SELECT * FROM tab_item
+WHERE tab_uuid = $1
+ORDER BY created_at;
+
+-- name: GetUnclosedTabTotal :one
+SELECT COALESCE(SUM(amount * (price + deposit)), 0)::numeric AS total FROM tab_item
+WHERE tab_uuid IN (SELECT uuid FROM tab WHERE resident = sqlc.arg('resident')::varchar AND status <> 'closed');
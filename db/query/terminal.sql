@@ -0,0 +1,28 @@
+-- name: CreateTerminal :one
+INSERT INTO terminal (
+    "name",
+    payment_backend,
+    location_uuid
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetTerminalById :one
+SELECT * FROM terminal
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetTerminals :many
+SELECT * FROM terminal;
+
+-- name: UpdateTerminal :one
+UPDATE terminal
+SET
+    "name" = COALESCE(sqlc.narg('name'), "name"),
+    payment_backend = COALESCE(sqlc.narg('payment_backend'), payment_backend),
+    location_uuid = COALESCE(sqlc.narg('location_uuid'), location_uuid)
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: DeleteTerminal :exec
+DELETE FROM terminal
+WHERE uuid = $1;
@@ -1,9 +1,14 @@
 -- name: CreateTransaction :one
 INSERT INTO transaction (
     "date",
-    price
+    price,
+    resident,
+    event_uuid,
+    payment_backend,
+    terminal_uuid,
+    split_of
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: GetTransactionById :one
@@ -11,7 +16,10 @@ SELECT * FROM transaction
 WHERE uuid = $1 LIMIT 1;
 
 -- name: GetTransactions :many
-SELECT * FROM transaction;
+SELECT * FROM transaction
+WHERE (sqlc.narg('resident')::varchar IS NULL OR resident = sqlc.narg('resident'))
+AND (sqlc.narg('event_uuid')::uuid IS NULL OR event_uuid = sqlc.narg('event_uuid'))
+ORDER BY "date";
 
 -- name: UpdateTransaction :one
 UPDATE transaction
@@ -24,3 +32,46 @@ RETURNING *;
 -- name: DeleteTransaction :exec
 DELETE FROM transaction
 WHERE uuid = $1;
+
+-- name: UpdateTransactionStatus :one
+UPDATE transaction
+SET
+    status = sqlc.arg('status'),
+    balance_after = COALESCE(sqlc.narg('balance_after'), balance_after)
+WHERE uuid = sqlc.arg('uuid')
+RETURNING *;
+
+-- name: GetTransactionByIdForUpdate :one
+SELECT * FROM transaction
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: CreateRefundTransaction :one
+INSERT INTO transaction (
+    "date",
+    price,
+    refund_of,
+    resident,
+    event_uuid,
+    payment_backend,
+    terminal_uuid,
+    split_of
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetRefundsByTransaction :many
+SELECT * FROM transaction
+WHERE refund_of = $1
+ORDER BY "date";
+
+-- name: GetSplitShares :many
+SELECT * FROM transaction
+WHERE split_of = $1
+ORDER BY "date", uuid;
+
+-- name: GetResidentSpending :one
+SELECT COALESCE(SUM(price), 0)::numeric AS spent FROM transaction
+WHERE resident = sqlc.arg('resident')
+AND status <> 'failed'
+AND "date" >= sqlc.arg('since')::timestamp;
@@ -10,6 +10,11 @@ INSERT INTO resident (
 SELECT * FROM resident
 WHERE name = $1 LIMIT 1;
 
+-- name: GetUserByIdForUpdate :one
+SELECT * FROM resident
+WHERE name = $1 LIMIT 1
+FOR UPDATE;
+
 -- name: GetUserByCode :one
 SELECT * FROM resident
 WHERE code = $1 LIMIT 1;
@@ -21,7 +26,11 @@ SELECT * FROM resident;
 UPDATE resident
 SET
     name = COALESCE(sqlc.narg('name'), "name"),
-    code = COALESCE(sqlc.narg('code'), code)
+    code = COALESCE(sqlc.narg('code'), code),
+    payment_backend = COALESCE(sqlc.narg('payment_backend'), payment_backend),
+    group_name = COALESCE(sqlc.narg('group_name'), group_name),
+    min_balance = COALESCE(sqlc.narg('min_balance'), min_balance),
+    daily_cap = COALESCE(sqlc.narg('daily_cap'), daily_cap)
 WHERE name = sqlc.arg('name')
 RETURNING *;
 
@@ -0,0 +1,32 @@
+-- name: CreateWebhook :one
+INSERT INTO webhook (
+    url,
+    secret
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetWebhookById :one
+SELECT * FROM webhook
+WHERE uuid = $1 LIMIT 1;
+
+-- name: GetWebhooks :many
+SELECT * FROM webhook
+ORDER BY created_at;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhook
+WHERE uuid = $1;
+
+-- name: GetUndeliveredWebhooks :many
+SELECT * FROM webhook
+WHERE uuid NOT IN (
+    SELECT webhook_uuid FROM stock_alert_webhook_delivery
+    WHERE alert_uuid = $1
+)
+ORDER BY created_at;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO stock_alert_webhook_delivery (alert_uuid, webhook_uuid)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
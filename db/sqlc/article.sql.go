@@ -8,28 +8,64 @@ package db
 import (
 	"context"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/google/uuid"
 	null "github.com/guregu/null/v5"
 )
 
+const archiveArticle = `-- name: ArchiveArticle :one
+UPDATE article
+SET archived_at = COALESCE(archived_at, now())
+WHERE uuid = $1
+RETURNING uuid, name, "desc", purchase_price, resell_price, article_type_uuid, deposit, vat_rate, stock, stock_policy, reorder_threshold, archived_at
+`
+
+func (q *Queries) ArchiveArticle(ctx context.Context, argUuid uuid.UUID) (Article, error) {
+	row := q.queryRow(ctx, q.archiveArticleStmt, archiveArticle, argUuid)
+	var i Article
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.Desc,
+		&i.PurchasePrice,
+		&i.ResellPrice,
+		&i.ArticleTypeUuid,
+		&i.Deposit,
+		&i.VatRate,
+		&i.Stock,
+		&i.StockPolicy,
+		&i.ReorderThreshold,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
 const createArticle = `-- name: CreateArticle :one
 INSERT INTO article (
     "name",
     "desc",
     purchase_price,
     resell_price,
-    article_type_uuid
+    article_type_uuid,
+    deposit,
+    vat_rate,
+    stock_policy,
+    reorder_threshold
 ) VALUES (
-    $1, $2, $3, $4, $5
-) RETURNING uuid, name, "desc", purchase_price, resell_price, article_type_uuid
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING uuid, name, "desc", purchase_price, resell_price, article_type_uuid, deposit, vat_rate, stock, stock_policy, reorder_threshold, archived_at
 `
 
 type CreateArticleParams struct {
-	Name            string      `json:"name"`
-	Desc            null.String `json:"desc"`
-	PurchasePrice   float64     `json:"purchase_price"`
-	ResellPrice     float64     `json:"resell_price"`
-	ArticleTypeUuid uuid.UUID   `json:"article_type_uuid"`
+	Name             string      `json:"name"`
+	Desc             null.String `json:"desc"`
+	PurchasePrice    util.Money  `json:"purchase_price"`
+	ResellPrice      util.Money  `json:"resell_price"`
+	ArticleTypeUuid  uuid.UUID   `json:"article_type_uuid"`
+	Deposit          util.Money  `json:"deposit"`
+	VatRate          null.Int32  `json:"vat_rate"`
+	StockPolicy      string      `json:"stock_policy"`
+	ReorderThreshold null.Int32  `json:"reorder_threshold"`
 }
 
 func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (Article, error) {
@@ -39,6 +75,10 @@ func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (A
 		arg.PurchasePrice,
 		arg.ResellPrice,
 		arg.ArticleTypeUuid,
+		arg.Deposit,
+		arg.VatRate,
+		arg.StockPolicy,
+		arg.ReorderThreshold,
 	)
 	var i Article
 	err := row.Scan(
@@ -48,27 +88,49 @@ func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (A
 		&i.PurchasePrice,
 		&i.ResellPrice,
 		&i.ArticleTypeUuid,
+		&i.Deposit,
+		&i.VatRate,
+		&i.Stock,
+		&i.StockPolicy,
+		&i.ReorderThreshold,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
-const deleteArticle = `-- name: DeleteArticle :exec
-DELETE FROM article
-WHERE uuid = $1
+const getArticleById = `-- name: GetArticleById :one
+SELECT uuid, name, "desc", purchase_price, resell_price, article_type_uuid, deposit, vat_rate, stock, stock_policy, reorder_threshold, archived_at FROM article
+WHERE uuid = $1 LIMIT 1
 `
 
-func (q *Queries) DeleteArticle(ctx context.Context, argUuid uuid.UUID) error {
-	_, err := q.exec(ctx, q.deleteArticleStmt, deleteArticle, argUuid)
-	return err
+func (q *Queries) GetArticleById(ctx context.Context, argUuid uuid.UUID) (Article, error) {
+	row := q.queryRow(ctx, q.getArticleByIdStmt, getArticleById, argUuid)
+	var i Article
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.Desc,
+		&i.PurchasePrice,
+		&i.ResellPrice,
+		&i.ArticleTypeUuid,
+		&i.Deposit,
+		&i.VatRate,
+		&i.Stock,
+		&i.StockPolicy,
+		&i.ReorderThreshold,
+		&i.ArchivedAt,
+	)
+	return i, err
 }
 
-const getArticleById = `-- name: GetArticleById :one
-SELECT uuid, name, "desc", purchase_price, resell_price, article_type_uuid FROM article
+const getArticleByIdForUpdate = `-- name: GetArticleByIdForUpdate :one
+SELECT uuid, name, "desc", purchase_price, resell_price, article_type_uuid, deposit, vat_rate, stock, stock_policy, reorder_threshold, archived_at FROM article
 WHERE uuid = $1 LIMIT 1
+FOR UPDATE
 `
 
-func (q *Queries) GetArticleById(ctx context.Context, argUuid uuid.UUID) (Article, error) {
-	row := q.queryRow(ctx, q.getArticleByIdStmt, getArticleById, argUuid)
+func (q *Queries) GetArticleByIdForUpdate(ctx context.Context, argUuid uuid.UUID) (Article, error) {
+	row := q.queryRow(ctx, q.getArticleByIdForUpdateStmt, getArticleByIdForUpdate, argUuid)
 	var i Article
 	err := row.Scan(
 		&i.Uuid,
@@ -77,16 +139,23 @@ func (q *Queries) GetArticleById(ctx context.Context, argUuid uuid.UUID) (Articl
 		&i.PurchasePrice,
 		&i.ResellPrice,
 		&i.ArticleTypeUuid,
+		&i.Deposit,
+		&i.VatRate,
+		&i.Stock,
+		&i.StockPolicy,
+		&i.ReorderThreshold,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
 const getArticles = `-- name: GetArticles :many
-SELECT uuid, name, "desc", purchase_price, resell_price, article_type_uuid FROM article
+SELECT uuid, name, "desc", purchase_price, resell_price, article_type_uuid, deposit, vat_rate, stock, stock_policy, reorder_threshold, archived_at FROM article
+WHERE $1::bool OR archived_at IS NULL
 `
 
-func (q *Queries) GetArticles(ctx context.Context) ([]Article, error) {
-	rows, err := q.query(ctx, q.getArticlesStmt, getArticles)
+func (q *Queries) GetArticles(ctx context.Context, archived bool) ([]Article, error) {
+	rows, err := q.query(ctx, q.getArticlesStmt, getArticles, archived)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +170,12 @@ func (q *Queries) GetArticles(ctx context.Context) ([]Article, error) {
 			&i.PurchasePrice,
 			&i.ResellPrice,
 			&i.ArticleTypeUuid,
+			&i.Deposit,
+			&i.VatRate,
+			&i.Stock,
+			&i.StockPolicy,
+			&i.ReorderThreshold,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -115,6 +190,33 @@ func (q *Queries) GetArticles(ctx context.Context) ([]Article, error) {
 	return items, nil
 }
 
+const restoreArticle = `-- name: RestoreArticle :one
+UPDATE article
+SET archived_at = NULL
+WHERE uuid = $1
+RETURNING uuid, name, "desc", purchase_price, resell_price, article_type_uuid, deposit, vat_rate, stock, stock_policy, reorder_threshold, archived_at
+`
+
+func (q *Queries) RestoreArticle(ctx context.Context, argUuid uuid.UUID) (Article, error) {
+	row := q.queryRow(ctx, q.restoreArticleStmt, restoreArticle, argUuid)
+	var i Article
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.Desc,
+		&i.PurchasePrice,
+		&i.ResellPrice,
+		&i.ArticleTypeUuid,
+		&i.Deposit,
+		&i.VatRate,
+		&i.Stock,
+		&i.StockPolicy,
+		&i.ReorderThreshold,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
 const updateArticle = `-- name: UpdateArticle :one
 UPDATE article
 SET
@@ -122,18 +224,26 @@ SET
     "desc" = COALESCE($2, "desc"),
     purchase_price = COALESCE($3, purchase_price),
     resell_price = COALESCE($4, resell_price),
-    article_type_uuid = COALESCE($5, article_type_uuid)
-WHERE uuid = $6
-RETURNING uuid, name, "desc", purchase_price, resell_price, article_type_uuid
+    article_type_uuid = COALESCE($5, article_type_uuid),
+    deposit = COALESCE($6, deposit),
+    vat_rate = COALESCE($7, vat_rate),
+    stock_policy = COALESCE($8, stock_policy),
+    reorder_threshold = COALESCE($9, reorder_threshold)
+WHERE uuid = $10
+RETURNING uuid, name, "desc", purchase_price, resell_price, article_type_uuid, deposit, vat_rate, stock, stock_policy, reorder_threshold, archived_at
 `
 
 type UpdateArticleParams struct {
-	Name            null.String   `json:"name"`
-	Desc            null.String   `json:"desc"`
-	PurchasePrice   null.Float    `json:"purchase_price"`
-	ResellPrice     null.Float    `json:"resell_price"`
-	ArticleTypeUuid uuid.NullUUID `json:"article_type_uuid"`
-	Uuid            uuid.UUID     `json:"uuid"`
+	Name             null.String    `json:"name"`
+	Desc             null.String    `json:"desc"`
+	PurchasePrice    util.NullMoney `json:"purchase_price"`
+	ResellPrice      util.NullMoney `json:"resell_price"`
+	ArticleTypeUuid  uuid.NullUUID  `json:"article_type_uuid"`
+	Deposit          util.NullMoney `json:"deposit"`
+	VatRate          null.Int32     `json:"vat_rate"`
+	StockPolicy      null.String    `json:"stock_policy"`
+	ReorderThreshold null.Int32     `json:"reorder_threshold"`
+	Uuid             uuid.UUID      `json:"uuid"`
 }
 
 func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (Article, error) {
@@ -143,6 +253,10 @@ func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (A
 		arg.PurchasePrice,
 		arg.ResellPrice,
 		arg.ArticleTypeUuid,
+		arg.Deposit,
+		arg.VatRate,
+		arg.StockPolicy,
+		arg.ReorderThreshold,
 		arg.Uuid,
 	)
 	var i Article
@@ -153,6 +267,12 @@ func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (A
 		&i.PurchasePrice,
 		&i.ResellPrice,
 		&i.ArticleTypeUuid,
+		&i.Deposit,
+		&i.VatRate,
+		&i.Stock,
+		&i.StockPolicy,
+		&i.ReorderThreshold,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
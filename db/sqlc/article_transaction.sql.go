@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/google/uuid"
 	null "github.com/guregu/null/v5"
 )
@@ -17,17 +18,33 @@ INSERT INTO article_transaction (
     article_uuid,
     transaction_uuid,
     amount,
-    price
+    price,
+    pricing_rule_uuid,
+    kind,
+    bundle_of,
+    vat_rate,
+    net,
+    tax,
+    deposit_of,
+    unit_cost
 ) VALUES (
-    $1, $2, $3, $4
-) RETURNING uuid, article_uuid, transaction_uuid, amount, price
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+    (SELECT purchase_price FROM article WHERE uuid = $1)
+) RETURNING uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of
 `
 
 type CreateArticleTransactionParams struct {
-	ArticleUuid     uuid.UUID `json:"article_uuid"`
-	TransactionUuid uuid.UUID `json:"transaction_uuid"`
-	Amount          int32     `json:"amount"`
-	Price           float64   `json:"price"`
+	ArticleUuid     uuid.UUID     `json:"article_uuid"`
+	TransactionUuid uuid.UUID     `json:"transaction_uuid"`
+	Amount          int32         `json:"amount"`
+	Price           util.Money    `json:"price"`
+	PricingRuleUuid uuid.NullUUID `json:"pricing_rule_uuid"`
+	Kind            string        `json:"kind"`
+	BundleOf        uuid.NullUUID `json:"bundle_of"`
+	VatRate         int32         `json:"vat_rate"`
+	Net             util.Money    `json:"net"`
+	Tax             util.Money    `json:"tax"`
+	DepositOf       uuid.NullUUID `json:"deposit_of"`
 }
 
 func (q *Queries) CreateArticleTransaction(ctx context.Context, arg CreateArticleTransactionParams) (ArticleTransaction, error) {
@@ -36,6 +53,13 @@ func (q *Queries) CreateArticleTransaction(ctx context.Context, arg CreateArticl
 		arg.TransactionUuid,
 		arg.Amount,
 		arg.Price,
+		arg.PricingRuleUuid,
+		arg.Kind,
+		arg.BundleOf,
+		arg.VatRate,
+		arg.Net,
+		arg.Tax,
+		arg.DepositOf,
 	)
 	var i ArticleTransaction
 	err := row.Scan(
@@ -44,6 +68,83 @@ func (q *Queries) CreateArticleTransaction(ctx context.Context, arg CreateArticl
 		&i.TransactionUuid,
 		&i.Amount,
 		&i.Price,
+		&i.RefundOf,
+		&i.PricingRuleUuid,
+		&i.Kind,
+		&i.BundleOf,
+		&i.VatRate,
+		&i.Net,
+		&i.Tax,
+		&i.UnitCost,
+		&i.DepositOf,
+	)
+	return i, err
+}
+
+const createRefundArticleTransaction = `-- name: CreateRefundArticleTransaction :one
+INSERT INTO article_transaction (
+    article_uuid,
+    transaction_uuid,
+    amount,
+    price,
+    refund_of,
+    pricing_rule_uuid,
+    kind,
+    bundle_of,
+    vat_rate,
+    net,
+    tax,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+    (SELECT original.unit_cost FROM article_transaction original WHERE original.uuid = $5)
+) RETURNING uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of
+`
+
+type CreateRefundArticleTransactionParams struct {
+	ArticleUuid     uuid.UUID     `json:"article_uuid"`
+	TransactionUuid uuid.UUID     `json:"transaction_uuid"`
+	Amount          int32         `json:"amount"`
+	Price           util.Money    `json:"price"`
+	RefundOf        uuid.NullUUID `json:"refund_of"`
+	PricingRuleUuid uuid.NullUUID `json:"pricing_rule_uuid"`
+	Kind            string        `json:"kind"`
+	BundleOf        uuid.NullUUID `json:"bundle_of"`
+	VatRate         int32         `json:"vat_rate"`
+	Net             util.Money    `json:"net"`
+	Tax             util.Money    `json:"tax"`
+}
+
+func (q *Queries) CreateRefundArticleTransaction(ctx context.Context, arg CreateRefundArticleTransactionParams) (ArticleTransaction, error) {
+	row := q.queryRow(ctx, q.createRefundArticleTransactionStmt, createRefundArticleTransaction,
+		arg.ArticleUuid,
+		arg.TransactionUuid,
+		arg.Amount,
+		arg.Price,
+		arg.RefundOf,
+		arg.PricingRuleUuid,
+		arg.Kind,
+		arg.BundleOf,
+		arg.VatRate,
+		arg.Net,
+		arg.Tax,
+	)
+	var i ArticleTransaction
+	err := row.Scan(
+		&i.Uuid,
+		&i.ArticleUuid,
+		&i.TransactionUuid,
+		&i.Amount,
+		&i.Price,
+		&i.RefundOf,
+		&i.PricingRuleUuid,
+		&i.Kind,
+		&i.BundleOf,
+		&i.VatRate,
+		&i.Net,
+		&i.Tax,
+		&i.UnitCost,
+		&i.DepositOf,
 	)
 	return i, err
 }
@@ -58,8 +159,28 @@ func (q *Queries) DeleteArticleTransaction(ctx context.Context, argUuid uuid.UUI
 	return err
 }
 
+const deleteArticleTransactionsByTransaction = `-- name: DeleteArticleTransactionsByTransaction :exec
+DELETE FROM article_transaction
+WHERE transaction_uuid = $1
+`
+
+func (q *Queries) DeleteArticleTransactionsByTransaction(ctx context.Context, transactionUuid uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteArticleTransactionsByTransactionStmt, deleteArticleTransactionsByTransaction, transactionUuid)
+	return err
+}
+
+const deleteDepositLines = `-- name: DeleteDepositLines :exec
+DELETE FROM article_transaction
+WHERE deposit_of = $1
+`
+
+func (q *Queries) DeleteDepositLines(ctx context.Context, depositOf uuid.NullUUID) error {
+	_, err := q.exec(ctx, q.deleteDepositLinesStmt, deleteDepositLines, depositOf)
+	return err
+}
+
 const getArticleTransactionById = `-- name: GetArticleTransactionById :one
-SELECT uuid, article_uuid, transaction_uuid, amount, price FROM article_transaction
+SELECT uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of FROM article_transaction
 WHERE uuid = $1 LIMIT 1
 `
 
@@ -72,12 +193,21 @@ func (q *Queries) GetArticleTransactionById(ctx context.Context, argUuid uuid.UU
 		&i.TransactionUuid,
 		&i.Amount,
 		&i.Price,
+		&i.RefundOf,
+		&i.PricingRuleUuid,
+		&i.Kind,
+		&i.BundleOf,
+		&i.VatRate,
+		&i.Net,
+		&i.Tax,
+		&i.UnitCost,
+		&i.DepositOf,
 	)
 	return i, err
 }
 
 const getArticleTransactions = `-- name: GetArticleTransactions :many
-SELECT uuid, article_uuid, transaction_uuid, amount, price FROM article_transaction
+SELECT uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of FROM article_transaction
 `
 
 func (q *Queries) GetArticleTransactions(ctx context.Context) ([]ArticleTransaction, error) {
@@ -95,6 +225,58 @@ func (q *Queries) GetArticleTransactions(ctx context.Context) ([]ArticleTransact
 			&i.TransactionUuid,
 			&i.Amount,
 			&i.Price,
+			&i.RefundOf,
+			&i.PricingRuleUuid,
+			&i.Kind,
+			&i.BundleOf,
+			&i.VatRate,
+			&i.Net,
+			&i.Tax,
+			&i.UnitCost,
+			&i.DepositOf,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getArticleTransactionsByTransaction = `-- name: GetArticleTransactionsByTransaction :many
+SELECT uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of FROM article_transaction
+WHERE transaction_uuid = $1
+`
+
+func (q *Queries) GetArticleTransactionsByTransaction(ctx context.Context, transactionUuid uuid.UUID) ([]ArticleTransaction, error) {
+	rows, err := q.query(ctx, q.getArticleTransactionsByTransactionStmt, getArticleTransactionsByTransaction, transactionUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ArticleTransaction{}
+	for rows.Next() {
+		var i ArticleTransaction
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ArticleUuid,
+			&i.TransactionUuid,
+			&i.Amount,
+			&i.Price,
+			&i.RefundOf,
+			&i.PricingRuleUuid,
+			&i.Kind,
+			&i.BundleOf,
+			&i.VatRate,
+			&i.Net,
+			&i.Tax,
+			&i.UnitCost,
+			&i.DepositOf,
 		); err != nil {
 			return nil, err
 		}
@@ -110,13 +292,36 @@ func (q *Queries) GetArticleTransactions(ctx context.Context) ([]ArticleTransact
 }
 
 const getArticleTransactionsGroupedByArticle = `-- name: GetArticleTransactionsGroupedByArticle :many
-select article_uuid, sum(amount) as amount from article_transaction
+select article_uuid, sum(amount)::bigint as amount, sum(revenue)::numeric(12,2) as revenue,
+    sum(cost)::numeric(12,2) as cost, (sum(revenue) - sum(cost))::numeric(12,2) as profit from (
+    select article_transaction.article_uuid, article_transaction.amount,
+        article_transaction.amount * article_transaction.price as revenue,
+        article_transaction.amount * article_transaction.unit_cost as cost
+    from article_transaction
+    join transaction on transaction.uuid = article_transaction.transaction_uuid
+    where article_transaction.kind = 'sale'
+    and transaction.status = 'charged'
+    and not exists (select 1 from article_transaction component where component.bundle_of = article_transaction.uuid)
+    union all
+    select component.article_uuid, component.amount, bundle.amount * bundle.price * coalesce(
+        component.amount * component.price / nullif(sum(component.amount * component.price) over (partition by component.bundle_of), 0),
+        1.0 / count(*) over (partition by component.bundle_of)
+    ) as revenue, component.amount * component.unit_cost as cost
+    from article_transaction component
+    join article_transaction bundle on bundle.uuid = component.bundle_of
+    join transaction on transaction.uuid = component.transaction_uuid
+    where component.kind = 'component'
+    and transaction.status = 'charged'
+) lines
 group by article_uuid
 `
 
 type GetArticleTransactionsGroupedByArticleRow struct {
-	ArticleUuid uuid.UUID `json:"article_uuid"`
-	Amount      int64     `json:"amount"`
+	ArticleUuid uuid.UUID  `json:"article_uuid"`
+	Amount      int64      `json:"amount"`
+	Revenue     util.Money `json:"revenue"`
+	Cost        util.Money `json:"cost"`
+	Profit      util.Money `json:"profit"`
 }
 
 func (q *Queries) GetArticleTransactionsGroupedByArticle(ctx context.Context) ([]GetArticleTransactionsGroupedByArticleRow, error) {
@@ -128,7 +333,186 @@ func (q *Queries) GetArticleTransactionsGroupedByArticle(ctx context.Context) ([
 	items := []GetArticleTransactionsGroupedByArticleRow{}
 	for rows.Next() {
 		var i GetArticleTransactionsGroupedByArticleRow
-		if err := rows.Scan(&i.ArticleUuid, &i.Amount); err != nil {
+		if err := rows.Scan(
+			&i.ArticleUuid,
+			&i.Amount,
+			&i.Revenue,
+			&i.Cost,
+			&i.Profit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getArticleTransactionsGroupedByPricingRule = `-- name: GetArticleTransactionsGroupedByPricingRule :many
+select article_transaction.pricing_rule_uuid, sum(article_transaction.amount) as amount,
+    sum(article_transaction.amount * article_transaction.price)::numeric(12,2) as revenue
+from article_transaction
+join transaction on transaction.uuid = article_transaction.transaction_uuid
+where article_transaction.kind = 'sale'
+and transaction.status = 'charged'
+group by article_transaction.pricing_rule_uuid
+`
+
+type GetArticleTransactionsGroupedByPricingRuleRow struct {
+	PricingRuleUuid uuid.NullUUID `json:"pricing_rule_uuid"`
+	Amount          int64         `json:"amount"`
+	Revenue         util.Money    `json:"revenue"`
+}
+
+func (q *Queries) GetArticleTransactionsGroupedByPricingRule(ctx context.Context) ([]GetArticleTransactionsGroupedByPricingRuleRow, error) {
+	rows, err := q.query(ctx, q.getArticleTransactionsGroupedByPricingRuleStmt, getArticleTransactionsGroupedByPricingRule)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetArticleTransactionsGroupedByPricingRuleRow{}
+	for rows.Next() {
+		var i GetArticleTransactionsGroupedByPricingRuleRow
+		if err := rows.Scan(&i.PricingRuleUuid, &i.Amount, &i.Revenue); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getArticleTransactionsOfTransactions = `-- name: GetArticleTransactionsOfTransactions :many
+SELECT uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of FROM article_transaction
+WHERE transaction_uuid IN (
+    SELECT uuid FROM transaction
+    WHERE ($1::varchar IS NULL OR resident = $1)
+    AND ($2::uuid IS NULL OR event_uuid = $2)
+)
+`
+
+type GetArticleTransactionsOfTransactionsParams struct {
+	Resident  null.String   `json:"resident"`
+	EventUuid uuid.NullUUID `json:"event_uuid"`
+}
+
+func (q *Queries) GetArticleTransactionsOfTransactions(ctx context.Context, arg GetArticleTransactionsOfTransactionsParams) ([]ArticleTransaction, error) {
+	rows, err := q.query(ctx, q.getArticleTransactionsOfTransactionsStmt, getArticleTransactionsOfTransactions, arg.Resident, arg.EventUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ArticleTransaction{}
+	for rows.Next() {
+		var i ArticleTransaction
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ArticleUuid,
+			&i.TransactionUuid,
+			&i.Amount,
+			&i.Price,
+			&i.RefundOf,
+			&i.PricingRuleUuid,
+			&i.Kind,
+			&i.BundleOf,
+			&i.VatRate,
+			&i.Net,
+			&i.Tax,
+			&i.UnitCost,
+			&i.DepositOf,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getComponentLines = `-- name: GetComponentLines :many
+SELECT uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of FROM article_transaction
+WHERE bundle_of = $1
+`
+
+func (q *Queries) GetComponentLines(ctx context.Context, bundleOf uuid.NullUUID) ([]ArticleTransaction, error) {
+	rows, err := q.query(ctx, q.getComponentLinesStmt, getComponentLines, bundleOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ArticleTransaction{}
+	for rows.Next() {
+		var i ArticleTransaction
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ArticleUuid,
+			&i.TransactionUuid,
+			&i.Amount,
+			&i.Price,
+			&i.RefundOf,
+			&i.PricingRuleUuid,
+			&i.Kind,
+			&i.BundleOf,
+			&i.VatRate,
+			&i.Net,
+			&i.Tax,
+			&i.UnitCost,
+			&i.DepositOf,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDepositLiabilities = `-- name: GetDepositLiabilities :many
+select article_transaction.article_uuid, sum(article_transaction.amount) as bottles,
+    sum(article_transaction.amount * article_transaction.price)::numeric(12,2) as liability
+from article_transaction
+join transaction on transaction.uuid = article_transaction.transaction_uuid
+where article_transaction.kind in ('deposit', 'deposit_return')
+and transaction.status = 'charged'
+group by article_transaction.article_uuid
+`
+
+type GetDepositLiabilitiesRow struct {
+	ArticleUuid uuid.UUID  `json:"article_uuid"`
+	Bottles     int64      `json:"bottles"`
+	Liability   util.Money `json:"liability"`
+}
+
+func (q *Queries) GetDepositLiabilities(ctx context.Context) ([]GetDepositLiabilitiesRow, error) {
+	rows, err := q.query(ctx, q.getDepositLiabilitiesStmt, getDepositLiabilities)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDepositLiabilitiesRow{}
+	for rows.Next() {
+		var i GetDepositLiabilitiesRow
+		if err := rows.Scan(&i.ArticleUuid, &i.Bottles, &i.Liability); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -142,32 +526,116 @@ func (q *Queries) GetArticleTransactionsGroupedByArticle(ctx context.Context) ([
 	return items, nil
 }
 
+const getReceiptLines = `-- name: GetReceiptLines :many
+SELECT article.name AS article_name, article_transaction.amount, article_transaction.price, article_transaction.kind, article_transaction.vat_rate
+FROM article_transaction
+JOIN article ON article.uuid = article_transaction.article_uuid
+WHERE article_transaction.transaction_uuid = $1
+AND article_transaction.kind <> 'component'
+ORDER BY article.name, article_transaction.kind DESC
+`
+
+type GetReceiptLinesRow struct {
+	ArticleName string     `json:"article_name"`
+	Amount      int32      `json:"amount"`
+	Price       util.Money `json:"price"`
+	Kind        string     `json:"kind"`
+	VatRate     int32      `json:"vat_rate"`
+}
+
+func (q *Queries) GetReceiptLines(ctx context.Context, transactionUuid uuid.UUID) ([]GetReceiptLinesRow, error) {
+	rows, err := q.query(ctx, q.getReceiptLinesStmt, getReceiptLines, transactionUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReceiptLinesRow{}
+	for rows.Next() {
+		var i GetReceiptLinesRow
+		if err := rows.Scan(
+			&i.ArticleName,
+			&i.Amount,
+			&i.Price,
+			&i.Kind,
+			&i.VatRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRefundedAmount = `-- name: GetRefundedAmount :one
+SELECT COALESCE(-SUM(amount), 0)::int AS refunded FROM article_transaction
+WHERE refund_of = $1
+`
+
+func (q *Queries) GetRefundedAmount(ctx context.Context, refundOf uuid.NullUUID) (int32, error) {
+	row := q.queryRow(ctx, q.getRefundedAmountStmt, getRefundedAmount, refundOf)
+	var refunded int32
+	err := row.Scan(&refunded)
+	return refunded, err
+}
+
+const getTransactionLinesTotal = `-- name: GetTransactionLinesTotal :one
+SELECT COALESCE(SUM(amount * price), 0)::numeric(12,2) AS total FROM article_transaction
+WHERE transaction_uuid = $1
+AND kind <> 'component'
+`
+
+func (q *Queries) GetTransactionLinesTotal(ctx context.Context, transactionUuid uuid.UUID) (util.Money, error) {
+	row := q.queryRow(ctx, q.getTransactionLinesTotalStmt, getTransactionLinesTotal, transactionUuid)
+	var total util.Money
+	err := row.Scan(&total)
+	return total, err
+}
+
 const updateArticleTransaction = `-- name: UpdateArticleTransaction :one
 UPDATE article_transaction
 SET
-    article_uuid = COALESCE($1, article_uuid),
-    transaction_uuid = COALESCE($2, transaction_uuid),
-    amount = COALESCE($3, amount),
-    price = COALESCE($4, price)
-WHERE uuid = $5
-RETURNING uuid, article_uuid, transaction_uuid, amount, price
+    article_uuid = $2,
+    transaction_uuid = $3,
+    amount = $4,
+    price = $5,
+    pricing_rule_uuid = $6,
+    vat_rate = $7,
+    net = $8,
+    tax = $9,
+    unit_cost = (SELECT purchase_price FROM article WHERE article.uuid = $2)
+WHERE uuid = $1
+RETURNING uuid, article_uuid, transaction_uuid, amount, price, refund_of, pricing_rule_uuid, kind, bundle_of, vat_rate, net, tax, unit_cost, deposit_of
 `
 
 type UpdateArticleTransactionParams struct {
-	ArticleUuid     uuid.NullUUID `json:"article_uuid"`
-	TransactionUuid uuid.NullUUID `json:"transaction_uuid"`
-	Amount          null.Int32    `json:"amount"`
-	Price           null.Float    `json:"price"`
 	Uuid            uuid.UUID     `json:"uuid"`
+	ArticleUuid     uuid.UUID     `json:"article_uuid"`
+	TransactionUuid uuid.UUID     `json:"transaction_uuid"`
+	Amount          int32         `json:"amount"`
+	Price           util.Money    `json:"price"`
+	PricingRuleUuid uuid.NullUUID `json:"pricing_rule_uuid"`
+	VatRate         int32         `json:"vat_rate"`
+	Net             util.Money    `json:"net"`
+	Tax             util.Money    `json:"tax"`
 }
 
 func (q *Queries) UpdateArticleTransaction(ctx context.Context, arg UpdateArticleTransactionParams) (ArticleTransaction, error) {
 	row := q.queryRow(ctx, q.updateArticleTransactionStmt, updateArticleTransaction,
+		arg.Uuid,
 		arg.ArticleUuid,
 		arg.TransactionUuid,
 		arg.Amount,
 		arg.Price,
-		arg.Uuid,
+		arg.PricingRuleUuid,
+		arg.VatRate,
+		arg.Net,
+		arg.Tax,
 	)
 	var i ArticleTransaction
 	err := row.Scan(
@@ -176,6 +644,15 @@ func (q *Queries) UpdateArticleTransaction(ctx context.Context, arg UpdateArticl
 		&i.TransactionUuid,
 		&i.Amount,
 		&i.Price,
+		&i.RefundOf,
+		&i.PricingRuleUuid,
+		&i.Kind,
+		&i.BundleOf,
+		&i.VatRate,
+		&i.Net,
+		&i.Tax,
+		&i.UnitCost,
+		&i.DepositOf,
 	)
 	return i, err
 }
@@ -7,20 +7,45 @@ package db
 
 import (
 	"context"
+	"time"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/google/uuid"
 	null "github.com/guregu/null/v5"
 )
 
+const archiveArticleType = `-- name: ArchiveArticleType :one
+UPDATE article_type
+SET archived_at = COALESCE(archived_at, now())
+WHERE uuid = $1
+RETURNING uuid, name, "desc", icon_codepoint, color, vat_rate, archived_at
+`
+
+func (q *Queries) ArchiveArticleType(ctx context.Context, argUuid uuid.UUID) (ArticleType, error) {
+	row := q.queryRow(ctx, q.archiveArticleTypeStmt, archiveArticleType, argUuid)
+	var i ArticleType
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.Desc,
+		&i.IconCodepoint,
+		&i.Color,
+		&i.VatRate,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
 const createArticleType = `-- name: CreateArticleType :one
 INSERT INTO article_type (
     "name",
     "desc",
     "icon_codepoint",
-    color
+    color,
+    vat_rate
 ) VALUES (
-    $1, $2, $3, $4
-) RETURNING uuid, name, "desc", icon_codepoint, color
+    $1, $2, $3, $4, $5
+) RETURNING uuid, name, "desc", icon_codepoint, color, vat_rate, archived_at
 `
 
 type CreateArticleTypeParams struct {
@@ -28,6 +53,7 @@ type CreateArticleTypeParams struct {
 	Desc          null.String `json:"desc"`
 	IconCodepoint int32       `json:"icon_codepoint"`
 	Color         string      `json:"color"`
+	VatRate       int32       `json:"vat_rate"`
 }
 
 func (q *Queries) CreateArticleType(ctx context.Context, arg CreateArticleTypeParams) (ArticleType, error) {
@@ -36,6 +62,7 @@ func (q *Queries) CreateArticleType(ctx context.Context, arg CreateArticleTypePa
 		arg.Desc,
 		arg.IconCodepoint,
 		arg.Color,
+		arg.VatRate,
 	)
 	var i ArticleType
 	err := row.Scan(
@@ -44,22 +71,14 @@ func (q *Queries) CreateArticleType(ctx context.Context, arg CreateArticleTypePa
 		&i.Desc,
 		&i.IconCodepoint,
 		&i.Color,
+		&i.VatRate,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
-const deleteArticleType = `-- name: DeleteArticleType :exec
-DELETE FROM article_type
-WHERE uuid = $1
-`
-
-func (q *Queries) DeleteArticleType(ctx context.Context, argUuid uuid.UUID) error {
-	_, err := q.exec(ctx, q.deleteArticleTypeStmt, deleteArticleType, argUuid)
-	return err
-}
-
 const getArticleTypeById = `-- name: GetArticleTypeById :one
-SELECT uuid, name, "desc", icon_codepoint, color FROM article_type
+SELECT uuid, name, "desc", icon_codepoint, color, vat_rate, archived_at FROM article_type
 WHERE uuid = $1 LIMIT 1
 `
 
@@ -72,16 +91,19 @@ func (q *Queries) GetArticleTypeById(ctx context.Context, argUuid uuid.UUID) (Ar
 		&i.Desc,
 		&i.IconCodepoint,
 		&i.Color,
+		&i.VatRate,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
 
 const getArticleTypes = `-- name: GetArticleTypes :many
-SELECT uuid, name, "desc", icon_codepoint, color FROM article_type
+SELECT uuid, name, "desc", icon_codepoint, color, vat_rate, archived_at FROM article_type
+WHERE $1::bool OR archived_at IS NULL
 `
 
-func (q *Queries) GetArticleTypes(ctx context.Context) ([]ArticleType, error) {
-	rows, err := q.query(ctx, q.getArticleTypesStmt, getArticleTypes)
+func (q *Queries) GetArticleTypes(ctx context.Context, archived bool) ([]ArticleType, error) {
+	rows, err := q.query(ctx, q.getArticleTypesStmt, getArticleTypes, archived)
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +117,8 @@ func (q *Queries) GetArticleTypes(ctx context.Context) ([]ArticleType, error) {
 			&i.Desc,
 			&i.IconCodepoint,
 			&i.Color,
+			&i.VatRate,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -110,21 +134,32 @@ func (q *Queries) GetArticleTypes(ctx context.Context) ([]ArticleType, error) {
 }
 
 const getArticleTypesWithArticles = `-- name: GetArticleTypesWithArticles :many
-select article_type.uuid, article_type.name, article_type."desc", article_type.icon_codepoint, article_type.color, article.uuid, article.name, article."desc", article.purchase_price, article.resell_price, article.article_type_uuid from article_type left join article on article.article_type_uuid = article_type.uuid
+select article_type.uuid, article_type.name, article_type."desc", article_type.icon_codepoint, article_type.color, article_type.vat_rate, article_type.archived_at, article.uuid, article.name, article."desc", article_price.purchase_price, article_price.resell_price, article.article_type_uuid, article.deposit, article.vat_rate, article.stock, article.stock_policy, article.reorder_threshold, article.archived_at
+from article_type
+left join (article join article_price on article_price.article_uuid = article.uuid and article_price.valid_from <= $1::timestamp and (article_price.valid_to is null or article_price.valid_to > $1::timestamp))
+    on article.article_type_uuid = article_type.uuid and (article.archived_at is null or article.archived_at > $1::timestamp)
+where article_type.archived_at is null or article_type.archived_at > $1::timestamp
+order by article_type.uuid
 `
 
 type GetArticleTypesWithArticlesRow struct {
-	ArticleType     ArticleType   `json:"article_type"`
-	Uuid            uuid.NullUUID `json:"uuid"`
-	Name            null.String   `json:"name"`
-	Desc            null.String   `json:"desc"`
-	PurchasePrice   null.Float    `json:"purchase_price"`
-	ResellPrice     null.Float    `json:"resell_price"`
-	ArticleTypeUuid uuid.NullUUID `json:"article_type_uuid"`
+	ArticleType      ArticleType    `json:"article_type"`
+	Uuid             uuid.NullUUID  `json:"uuid"`
+	Name             null.String    `json:"name"`
+	Desc             null.String    `json:"desc"`
+	PurchasePrice    util.NullMoney `json:"purchase_price"`
+	ResellPrice      util.NullMoney `json:"resell_price"`
+	ArticleTypeUuid  uuid.NullUUID  `json:"article_type_uuid"`
+	Deposit          util.NullMoney `json:"deposit"`
+	VatRate          null.Int32     `json:"vat_rate"`
+	Stock            null.Int32     `json:"stock"`
+	StockPolicy      null.String    `json:"stock_policy"`
+	ReorderThreshold null.Int32     `json:"reorder_threshold"`
+	ArchivedAt       null.Time      `json:"archived_at"`
 }
 
-func (q *Queries) GetArticleTypesWithArticles(ctx context.Context) ([]GetArticleTypesWithArticlesRow, error) {
-	rows, err := q.query(ctx, q.getArticleTypesWithArticlesStmt, getArticleTypesWithArticles)
+func (q *Queries) GetArticleTypesWithArticles(ctx context.Context, at time.Time) ([]GetArticleTypesWithArticlesRow, error) {
+	rows, err := q.query(ctx, q.getArticleTypesWithArticlesStmt, getArticleTypesWithArticles, at)
 	if err != nil {
 		return nil, err
 	}
@@ -138,12 +173,20 @@ func (q *Queries) GetArticleTypesWithArticles(ctx context.Context) ([]GetArticle
 			&i.ArticleType.Desc,
 			&i.ArticleType.IconCodepoint,
 			&i.ArticleType.Color,
+			&i.ArticleType.VatRate,
+			&i.ArticleType.ArchivedAt,
 			&i.Uuid,
 			&i.Name,
 			&i.Desc,
 			&i.PurchasePrice,
 			&i.ResellPrice,
 			&i.ArticleTypeUuid,
+			&i.Deposit,
+			&i.VatRate,
+			&i.Stock,
+			&i.StockPolicy,
+			&i.ReorderThreshold,
+			&i.ArchivedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -158,15 +201,38 @@ func (q *Queries) GetArticleTypesWithArticles(ctx context.Context) ([]GetArticle
 	return items, nil
 }
 
+const restoreArticleType = `-- name: RestoreArticleType :one
+UPDATE article_type
+SET archived_at = NULL
+WHERE uuid = $1
+RETURNING uuid, name, "desc", icon_codepoint, color, vat_rate, archived_at
+`
+
+func (q *Queries) RestoreArticleType(ctx context.Context, argUuid uuid.UUID) (ArticleType, error) {
+	row := q.queryRow(ctx, q.restoreArticleTypeStmt, restoreArticleType, argUuid)
+	var i ArticleType
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.Desc,
+		&i.IconCodepoint,
+		&i.Color,
+		&i.VatRate,
+		&i.ArchivedAt,
+	)
+	return i, err
+}
+
 const updateArticleType = `-- name: UpdateArticleType :one
 UPDATE article_type
 SET
     "name" = COALESCE($1, "name"),
     "desc" = COALESCE($2, "desc"),
     "icon_codepoint" = COALESCE($3, "icon_codepoint"),
-    "color" = COALESCE($4, "color")
-WHERE uuid = $5
-RETURNING uuid, name, "desc", icon_codepoint, color
+    "color" = COALESCE($4, "color"),
+    vat_rate = COALESCE($5, vat_rate)
+WHERE uuid = $6
+RETURNING uuid, name, "desc", icon_codepoint, color, vat_rate, archived_at
 `
 
 type UpdateArticleTypeParams struct {
@@ -174,6 +240,7 @@ type UpdateArticleTypeParams struct {
 	Desc          null.String `json:"desc"`
 	IconCodepoint null.Int32  `json:"icon_codepoint"`
 	Color         null.String `json:"color"`
+	VatRate       null.Int32  `json:"vat_rate"`
 	Uuid          uuid.UUID   `json:"uuid"`
 }
 
@@ -183,6 +250,7 @@ func (q *Queries) UpdateArticleType(ctx context.Context, arg UpdateArticleTypePa
 		arg.Desc,
 		arg.IconCodepoint,
 		arg.Color,
+		arg.VatRate,
 		arg.Uuid,
 	)
 	var i ArticleType
@@ -192,6 +260,8 @@ func (q *Queries) UpdateArticleType(ctx context.Context, arg UpdateArticleTypePa
 		&i.Desc,
 		&i.IconCodepoint,
 		&i.Color,
+		&i.VatRate,
+		&i.ArchivedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBundle is returned for components that would nest bundles or
+// contain the bundle itself
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// ArticleComponentParams is a component of a bundle with its amount per bundle
+type ArticleComponentParams struct {
+	ComponentUuid uuid.UUID
+	Amount        int32
+}
+
+// SetArticleComponentsTx replaces the components of a bundle, without
+// components the article is a plain article again. Bundles can not be nested.
+func (store *Store) SetArticleComponentsTx(ctx context.Context, bundle uuid.UUID, components []ArticleComponentParams) ([]ArticleComponent, error) {
+	result := []ArticleComponent{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if len(components) > 0 {
+			isComponent, err := q.IsArticleComponent(ctx, bundle)
+			if err != nil {
+				return err
+			}
+			if isComponent {
+				return fmt.Errorf("%w: the article is a component of another bundle", ErrInvalidBundle)
+			}
+		}
+
+		if err := q.DeleteArticleComponents(ctx, bundle); err != nil {
+			return err
+		}
+
+		for _, component := range components {
+			if component.ComponentUuid == bundle {
+				return fmt.Errorf("%w: a bundle can not contain itself", ErrInvalidBundle)
+			}
+
+			nested, err := q.GetArticleComponents(ctx, component.ComponentUuid)
+			if err != nil {
+				return err
+			}
+			if len(nested) > 0 {
+				return fmt.Errorf("%w: component %s is a bundle itself", ErrInvalidBundle, component.ComponentUuid)
+			}
+
+			created, err := q.CreateArticleComponent(ctx, CreateArticleComponentParams{
+				BundleUuid:    bundle,
+				ComponentUuid: component.ComponentUuid,
+				Amount:        component.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			result = append(result, created)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// createComponentLines writes a component line for every component of a sold
+// bundle. The component lines count the sold units of the components, their
+// price is the price of the component in the current price list and weights
+// the split of the bundle revenue in the reports. Plain articles have no
+// components.
+func (q *Queries) createComponentLines(ctx context.Context, bundle ArticleTransaction) ([]ArticleTransaction, error) {
+	components, err := q.GetArticleComponents(ctx, bundle.ArticleUuid)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) == 0 {
+		return nil, nil
+	}
+
+	prices, err := q.GetCurrentPriceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]ArticleTransaction, 0, len(components))
+	for _, component := range components {
+		article, err := q.GetArticleById(ctx, component.ComponentUuid)
+		if err != nil {
+			return nil, err
+		}
+
+		price, _ := prices.Price(article)
+		line, err := q.CreateArticleTransaction(ctx, CreateArticleTransactionParams{
+			ArticleUuid:     component.ComponentUuid,
+			TransactionUuid: bundle.TransactionUuid,
+			Amount:          component.Amount * bundle.Amount,
+			Price:           price,
+			Kind:            ArticleTransactionComponent,
+			BundleOf:        uuid.NullUUID{UUID: bundle.Uuid, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/guregu/null/v5"
+)
+
+// CreditPolicy limits the spending of a resident. Without a minimum balance
+// the resident may go arbitrarily negative, without a daily cap there is no
+// limit per day.
+type CreditPolicy struct {
+	MinBalance util.NullMoney `json:"min_balance"`
+	DailyCap   util.NullMoney `json:"daily_cap"`
+}
+
+// InsufficientFundsError is returned when the credit policy blocks a sale,
+// the shortfall is the amount missing to allow it
+type InsufficientFundsError struct {
+	Shortfall util.Money
+	Reason    string
+}
+
+func (err *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("%s: %s, %s short", ErrInsufficientFunds, err.Reason, err.Shortfall)
+}
+
+func (err *InsufficientFundsError) Unwrap() error {
+	return ErrInsufficientFunds
+}
+
+// GetCreditPolicy returns the policy of the resident, limits set on the
+// resident take precedence over the ones of their group
+func (q *Queries) GetCreditPolicy(ctx context.Context, resident Resident) (CreditPolicy, error) {
+	policy := CreditPolicy{MinBalance: resident.MinBalance, DailyCap: resident.DailyCap}
+	if !resident.GroupName.Valid {
+		return policy, nil
+	}
+
+	group, err := q.GetResidentGroupById(ctx, resident.GroupName.String)
+	if err != nil {
+		return policy, err
+	}
+
+	if !policy.MinBalance.Valid {
+		policy.MinBalance = group.MinBalance
+	}
+	if !policy.DailyCap.Valid {
+		policy.DailyCap = group.DailyCap
+	}
+
+	return policy, nil
+}
+
+// CheckCreditParams contains the input parameters of the credit check
+type CheckCreditParams struct {
+	Resident       Resident
+	PaymentBackend string
+	// Balance is the live balance of the backend, backends without a balance
+	// are only checked against the daily cap
+	Balance util.NullMoney
+	// Prepaid backends can never go below zero, whatever the policy allows
+	Prepaid bool
+	Amount  util.Money
+	Date    time.Time
+}
+
+// CheckCredit reports whether the resident may spend the amount. Debits that
+// are still queued and the items on unclosed tabs are spent already. A
+// blocked sale returns an InsufficientFundsError. The row of the resident
+// stays locked, it has to run in the transaction writing the sale so that
+// parallel sales of the resident are checked one after the other.
+func (q *Queries) CheckCredit(ctx context.Context, arg CheckCreditParams) error {
+	resident, err := q.GetUserByIdForUpdate(ctx, arg.Resident.Name)
+	if err != nil {
+		return err
+	}
+
+	policy, err := q.GetCreditPolicy(ctx, resident)
+	if err != nil {
+		return err
+	}
+
+	tabs, err := q.GetUnclosedTabTotal(ctx, resident.Name)
+	if err != nil {
+		return err
+	}
+
+	minBalance := policy.MinBalance
+	if arg.Prepaid && (!minBalance.Valid || minBalance.Money < 0) {
+		minBalance = util.NullMoneyFrom(0)
+	}
+
+	if arg.Balance.Valid && minBalance.Valid {
+		pending, err := q.GetPendingPaymentAmount(ctx, GetPendingPaymentAmountParams{
+			Username: null.StringFrom(resident.Name),
+			Backend:  arg.PaymentBackend,
+		})
+		if err != nil {
+			return err
+		}
+
+		// pending debits are negative
+		left := arg.Balance.Money + pending - tabs - arg.Amount
+		if left < minBalance.Money {
+			return &InsufficientFundsError{
+				Shortfall: minBalance.Money - left,
+				Reason:    fmt.Sprintf("the balance may not go below %s", minBalance.Money),
+			}
+		}
+	}
+
+	if policy.DailyCap.Valid {
+		year, month, day := arg.Date.Date()
+		spent, err := q.GetResidentSpending(ctx, GetResidentSpendingParams{
+			Resident: null.StringFrom(resident.Name),
+			Since:    time.Date(year, month, day, 0, 0, 0, 0, arg.Date.Location()),
+		})
+		if err != nil {
+			return err
+		}
+
+		if total := spent + tabs + arg.Amount; total > policy.DailyCap.Money {
+			return &InsufficientFundsError{
+				Shortfall: total - policy.DailyCap.Money,
+				Reason:    fmt.Sprintf("the daily cap is %s", policy.DailyCap.Money),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// createTestResident writes a resident of a new group with the limits of the
+// group and the ones set on the resident
+func createTestResident(t *testing.T, group CreditPolicy, own CreditPolicy) Resident {
+	t.Helper()
+	ctx := context.Background()
+
+	residentGroup, err := testStore.CreateResidentGroup(ctx, CreateResidentGroupParams{Name: "group " + uuid.NewString(), MinBalance: group.MinBalance, DailyCap: group.DailyCap})
+	if err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+
+	resident, err := testStore.CreateUser(ctx, CreateUserParams{Name: "resident " + uuid.NewString(), Code: uuid.NewString()})
+	if err != nil {
+		t.Fatalf("create resident: %v", err)
+	}
+
+	resident, err = testStore.UpdateUser(ctx, UpdateUserParams{
+		Name:       null.StringFrom(resident.Name),
+		GroupName:  null.StringFrom(residentGroup.Name),
+		MinBalance: own.MinBalance,
+		DailyCap:   own.DailyCap,
+	})
+	if err != nil {
+		t.Fatalf("update resident: %v", err)
+	}
+	return resident
+}
+
+func TestCheckCredit(t *testing.T) {
+	requireStore(t)
+
+	group := CreditPolicy{MinBalance: util.NullMoneyFrom(-1000), DailyCap: util.NullMoneyFrom(2000)}
+
+	tests := []struct {
+		name      string
+		own       CreditPolicy
+		balance   util.NullMoney
+		prepaid   bool
+		amount    util.Money
+		shortfall util.Money // zero if the sale is allowed
+	}{
+		{name: "within the minimum balance", balance: util.NullMoneyFrom(500), amount: 1400},
+		{name: "below the minimum balance", balance: util.NullMoneyFrom(500), amount: 1600, shortfall: 100},
+		{name: "prepaid backends stop at zero", balance: util.NullMoneyFrom(500), prepaid: true, amount: 600, shortfall: 100},
+		{name: "backend without a balance", amount: 1900},
+		{name: "above the daily cap", amount: 2100, shortfall: 100},
+		{name: "limit of the resident wins over the group", own: CreditPolicy{MinBalance: util.NullMoneyFrom(-2000)}, balance: util.NullMoneyFrom(0), amount: 1900},
+		{name: "daily cap of the resident wins over the group", own: CreditPolicy{DailyCap: util.NullMoneyFrom(500)}, amount: 600, shortfall: 100},
+	}
+
+	for _, tt := range tests {
+		resident := createTestResident(t, group, tt.own)
+
+		err := testStore.CheckCredit(context.Background(), CheckCreditParams{
+			Resident:       resident,
+			PaymentBackend: "savapage",
+			Balance:        tt.balance,
+			Prepaid:        tt.prepaid,
+			Amount:         tt.amount,
+			Date:           time.Now(),
+		})
+
+		var insufficient *InsufficientFundsError
+		switch {
+		case tt.shortfall == 0 && err != nil:
+			t.Errorf("%s: got %v, want the sale to be allowed", tt.name, err)
+		case tt.shortfall != 0 && !errors.As(err, &insufficient):
+			t.Errorf("%s: got %v, want an InsufficientFundsError", tt.name, err)
+		case tt.shortfall != 0 && insufficient.Shortfall != tt.shortfall:
+			t.Errorf("%s: shortfall is %v, want %v", tt.name, insufficient.Shortfall, tt.shortfall)
+		}
+	}
+}
+
+func TestCheckCreditCountsQueuedSales(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	resident := createTestResident(t, CreditPolicy{MinBalance: util.NullMoneyFrom(0), DailyCap: util.NullMoneyFrom(2000)}, CreditPolicy{})
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 500})
+
+	_, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		Resident:       null.StringFrom(resident.Name),
+		PaymentBackend: "savapage",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{{ArticleUuid: article.Uuid, Amount: 3, Price: 500, VatRate: 19}},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		balance   util.NullMoney
+		amount    util.Money
+		shortfall util.Money
+	}{
+		// the queued debit of 15.00 is not charged yet
+		{name: "queued debit counts against the balance", balance: util.NullMoneyFrom(2000), amount: 600, shortfall: 100},
+		{name: "sale of the day counts against the cap", amount: 600, shortfall: 100},
+		{name: "within both limits", balance: util.NullMoneyFrom(2000), amount: 500},
+	}
+
+	for _, tt := range tests {
+		err := store.CheckCredit(ctx, CheckCreditParams{
+			Resident:       resident,
+			PaymentBackend: "savapage",
+			Balance:        tt.balance,
+			Amount:         tt.amount,
+			Date:           time.Now(),
+		})
+
+		var insufficient *InsufficientFundsError
+		switch {
+		case tt.shortfall == 0 && err != nil:
+			t.Errorf("%s: got %v, want the sale to be allowed", tt.name, err)
+		case tt.shortfall != 0 && !errors.As(err, &insufficient):
+			t.Errorf("%s: got %v, want an InsufficientFundsError", tt.name, err)
+		case tt.shortfall != 0 && insufficient.Shortfall != tt.shortfall:
+			t.Errorf("%s: shortfall is %v, want %v", tt.name, insufficient.Shortfall, tt.shortfall)
+		}
+	}
+}
+
+func TestCreateArticleTransactionTxChecksCredit(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	resident := createTestResident(t, CreditPolicy{DailyCap: util.NullMoneyFrom(1000)}, CreditPolicy{})
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 400})
+	line := CheckoutLine{ArticleUuid: article.Uuid, Amount: 1, Price: 400, VatRate: 19}
+
+	checkout, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		Resident:       null.StringFrom(resident.Name),
+		PaymentBackend: "savapage",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{line},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	credit := &CheckCreditParams{Resident: resident, PaymentBackend: "savapage"}
+
+	// 4.00 + 4.00 stays below the cap of 10.00, a third unit does not
+	if _, err := store.CreateArticleTransactionTx(ctx, checkout.Transaction.Uuid, line, credit); err != nil {
+		t.Fatalf("second line: %v", err)
+	}
+	_, err = store.CreateArticleTransactionTx(ctx, checkout.Transaction.Uuid, line, credit)
+
+	var insufficient *InsufficientFundsError
+	if !errors.As(err, &insufficient) || insufficient.Shortfall != 200 {
+		t.Errorf("third line: got %v, want a shortfall of 2.00", err)
+	}
+}
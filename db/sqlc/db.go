@@ -24,54 +24,234 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.applyArticlePricesStmt, err = db.PrepareContext(ctx, applyArticlePrices); err != nil {
+		return nil, fmt.Errorf("error preparing query ApplyArticlePrices: %w", err)
+	}
+	if q.archiveArticleStmt, err = db.PrepareContext(ctx, archiveArticle); err != nil {
+		return nil, fmt.Errorf("error preparing query ArchiveArticle: %w", err)
+	}
+	if q.archiveArticleTypeStmt, err = db.PrepareContext(ctx, archiveArticleType); err != nil {
+		return nil, fmt.Errorf("error preparing query ArchiveArticleType: %w", err)
+	}
+	if q.clearDefaultLocationStmt, err = db.PrepareContext(ctx, clearDefaultLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearDefaultLocation: %w", err)
+	}
+	if q.closeShiftStmt, err = db.PrepareContext(ctx, closeShift); err != nil {
+		return nil, fmt.Errorf("error preparing query CloseShift: %w", err)
+	}
+	if q.closeStocktakeStmt, err = db.PrepareContext(ctx, closeStocktake); err != nil {
+		return nil, fmt.Errorf("error preparing query CloseStocktake: %w", err)
+	}
+	if q.closeTabStmt, err = db.PrepareContext(ctx, closeTab); err != nil {
+		return nil, fmt.Errorf("error preparing query CloseTab: %w", err)
+	}
+	if q.countStocktakeLineStmt, err = db.PrepareContext(ctx, countStocktakeLine); err != nil {
+		return nil, fmt.Errorf("error preparing query CountStocktakeLine: %w", err)
+	}
 	if q.createArticleStmt, err = db.PrepareContext(ctx, createArticle); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateArticle: %w", err)
 	}
+	if q.createArticleComponentStmt, err = db.PrepareContext(ctx, createArticleComponent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateArticleComponent: %w", err)
+	}
+	if q.createArticlePriceStmt, err = db.PrepareContext(ctx, createArticlePrice); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateArticlePrice: %w", err)
+	}
 	if q.createArticleTransactionStmt, err = db.PrepareContext(ctx, createArticleTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateArticleTransaction: %w", err)
 	}
 	if q.createArticleTypeStmt, err = db.PrepareContext(ctx, createArticleType); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateArticleType: %w", err)
 	}
+	if q.createClosingStmt, err = db.PrepareContext(ctx, createClosing); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateClosing: %w", err)
+	}
+	if q.createClosingArticleTypeStmt, err = db.PrepareContext(ctx, createClosingArticleType); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateClosingArticleType: %w", err)
+	}
+	if q.createClosingPaymentBackendStmt, err = db.PrepareContext(ctx, createClosingPaymentBackend); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateClosingPaymentBackend: %w", err)
+	}
 	if q.createEventStmt, err = db.PrepareContext(ctx, createEvent); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateEvent: %w", err)
 	}
+	if q.createGoodsReceiptStmt, err = db.PrepareContext(ctx, createGoodsReceipt); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateGoodsReceipt: %w", err)
+	}
+	if q.createGoodsReceiptLineStmt, err = db.PrepareContext(ctx, createGoodsReceiptLine); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateGoodsReceiptLine: %w", err)
+	}
+	if q.createLedgerEntryStmt, err = db.PrepareContext(ctx, createLedgerEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLedgerEntry: %w", err)
+	}
+	if q.createLedgerPostingStmt, err = db.PrepareContext(ctx, createLedgerPosting); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLedgerPosting: %w", err)
+	}
+	if q.createLocationStmt, err = db.PrepareContext(ctx, createLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLocation: %w", err)
+	}
+	if q.createPaymentOutboxStmt, err = db.PrepareContext(ctx, createPaymentOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePaymentOutbox: %w", err)
+	}
+	if q.createPricingRuleStmt, err = db.PrepareContext(ctx, createPricingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePricingRule: %w", err)
+	}
+	if q.createPurchaseOrderStmt, err = db.PrepareContext(ctx, createPurchaseOrder); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePurchaseOrder: %w", err)
+	}
+	if q.createPurchaseOrderLineStmt, err = db.PrepareContext(ctx, createPurchaseOrderLine); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePurchaseOrderLine: %w", err)
+	}
+	if q.createRefundArticleTransactionStmt, err = db.PrepareContext(ctx, createRefundArticleTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRefundArticleTransaction: %w", err)
+	}
+	if q.createRefundTransactionStmt, err = db.PrepareContext(ctx, createRefundTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRefundTransaction: %w", err)
+	}
+	if q.createResidentGroupStmt, err = db.PrepareContext(ctx, createResidentGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateResidentGroup: %w", err)
+	}
+	if q.createShiftStmt, err = db.PrepareContext(ctx, createShift); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateShift: %w", err)
+	}
+	if q.createShiftCountStmt, err = db.PrepareContext(ctx, createShiftCount); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateShiftCount: %w", err)
+	}
+	if q.createStockAlertStmt, err = db.PrepareContext(ctx, createStockAlert); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStockAlert: %w", err)
+	}
+	if q.createStockAlertDeliveryStmt, err = db.PrepareContext(ctx, createStockAlertDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStockAlertDelivery: %w", err)
+	}
+	if q.createStockMovementStmt, err = db.PrepareContext(ctx, createStockMovement); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStockMovement: %w", err)
+	}
+	if q.createStockTransferStmt, err = db.PrepareContext(ctx, createStockTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStockTransfer: %w", err)
+	}
+	if q.createStocktakeStmt, err = db.PrepareContext(ctx, createStocktake); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStocktake: %w", err)
+	}
+	if q.createStocktakeLinesStmt, err = db.PrepareContext(ctx, createStocktakeLines); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStocktakeLines: %w", err)
+	}
+	if q.createSupplierStmt, err = db.PrepareContext(ctx, createSupplier); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSupplier: %w", err)
+	}
+	if q.createTabStmt, err = db.PrepareContext(ctx, createTab); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTab: %w", err)
+	}
+	if q.createTabItemStmt, err = db.PrepareContext(ctx, createTabItem); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTabItem: %w", err)
+	}
+	if q.createTerminalStmt, err = db.PrepareContext(ctx, createTerminal); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTerminal: %w", err)
+	}
 	if q.createTransactionStmt, err = db.PrepareContext(ctx, createTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTransaction: %w", err)
 	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
-	if q.deleteArticleStmt, err = db.PrepareContext(ctx, deleteArticle); err != nil {
-		return nil, fmt.Errorf("error preparing query DeleteArticle: %w", err)
+	if q.createWebhookStmt, err = db.PrepareContext(ctx, createWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhook: %w", err)
+	}
+	if q.createWebhookDeliveryStmt, err = db.PrepareContext(ctx, createWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDelivery: %w", err)
+	}
+	if q.deleteArticleComponentsStmt, err = db.PrepareContext(ctx, deleteArticleComponents); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteArticleComponents: %w", err)
+	}
+	if q.deleteArticlePriceStmt, err = db.PrepareContext(ctx, deleteArticlePrice); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteArticlePrice: %w", err)
 	}
 	if q.deleteArticleTransactionStmt, err = db.PrepareContext(ctx, deleteArticleTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteArticleTransaction: %w", err)
 	}
-	if q.deleteArticleTypeStmt, err = db.PrepareContext(ctx, deleteArticleType); err != nil {
-		return nil, fmt.Errorf("error preparing query DeleteArticleType: %w", err)
+	if q.deleteArticleTransactionsByTransactionStmt, err = db.PrepareContext(ctx, deleteArticleTransactionsByTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteArticleTransactionsByTransaction: %w", err)
+	}
+	if q.deleteDepositLinesStmt, err = db.PrepareContext(ctx, deleteDepositLines); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteDepositLines: %w", err)
 	}
 	if q.deleteEventStmt, err = db.PrepareContext(ctx, deleteEvent); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteEvent: %w", err)
 	}
+	if q.deleteLocationStmt, err = db.PrepareContext(ctx, deleteLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteLocation: %w", err)
+	}
+	if q.deletePricingRuleStmt, err = db.PrepareContext(ctx, deletePricingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePricingRule: %w", err)
+	}
+	if q.deleteResidentGroupStmt, err = db.PrepareContext(ctx, deleteResidentGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteResidentGroup: %w", err)
+	}
+	if q.deleteSupplierStmt, err = db.PrepareContext(ctx, deleteSupplier); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSupplier: %w", err)
+	}
+	if q.deleteTerminalStmt, err = db.PrepareContext(ctx, deleteTerminal); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTerminal: %w", err)
+	}
 	if q.deleteTransactionStmt, err = db.PrepareContext(ctx, deleteTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteTransaction: %w", err)
 	}
 	if q.deleteUserStmt, err = db.PrepareContext(ctx, deleteUser); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteUser: %w", err)
 	}
+	if q.deleteWebhookStmt, err = db.PrepareContext(ctx, deleteWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWebhook: %w", err)
+	}
+	if q.endArticlePriceStmt, err = db.PrepareContext(ctx, endArticlePrice); err != nil {
+		return nil, fmt.Errorf("error preparing query EndArticlePrice: %w", err)
+	}
+	if q.extendArticlePriceStmt, err = db.PrepareContext(ctx, extendArticlePrice); err != nil {
+		return nil, fmt.Errorf("error preparing query ExtendArticlePrice: %w", err)
+	}
+	if q.flagTabStmt, err = db.PrepareContext(ctx, flagTab); err != nil {
+		return nil, fmt.Errorf("error preparing query FlagTab: %w", err)
+	}
 	if q.getArticleByIdStmt, err = db.PrepareContext(ctx, getArticleById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetArticleById: %w", err)
 	}
+	if q.getArticleByIdForUpdateStmt, err = db.PrepareContext(ctx, getArticleByIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticleByIdForUpdate: %w", err)
+	}
+	if q.getArticleComponentsStmt, err = db.PrepareContext(ctx, getArticleComponents); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticleComponents: %w", err)
+	}
+	if q.getArticlePriceAtStmt, err = db.PrepareContext(ctx, getArticlePriceAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticlePriceAt: %w", err)
+	}
+	if q.getArticlePriceByIdStmt, err = db.PrepareContext(ctx, getArticlePriceById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticlePriceById: %w", err)
+	}
+	if q.getArticlePricesStmt, err = db.PrepareContext(ctx, getArticlePrices); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticlePrices: %w", err)
+	}
+	if q.getArticlePricesAtStmt, err = db.PrepareContext(ctx, getArticlePricesAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticlePricesAt: %w", err)
+	}
+	if q.getArticleStockForUpdateStmt, err = db.PrepareContext(ctx, getArticleStockForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticleStockForUpdate: %w", err)
+	}
 	if q.getArticleTransactionByIdStmt, err = db.PrepareContext(ctx, getArticleTransactionById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetArticleTransactionById: %w", err)
 	}
 	if q.getArticleTransactionsStmt, err = db.PrepareContext(ctx, getArticleTransactions); err != nil {
 		return nil, fmt.Errorf("error preparing query GetArticleTransactions: %w", err)
 	}
+	if q.getArticleTransactionsByTransactionStmt, err = db.PrepareContext(ctx, getArticleTransactionsByTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticleTransactionsByTransaction: %w", err)
+	}
 	if q.getArticleTransactionsGroupedByArticleStmt, err = db.PrepareContext(ctx, getArticleTransactionsGroupedByArticle); err != nil {
 		return nil, fmt.Errorf("error preparing query GetArticleTransactionsGroupedByArticle: %w", err)
 	}
+	if q.getArticleTransactionsGroupedByPricingRuleStmt, err = db.PrepareContext(ctx, getArticleTransactionsGroupedByPricingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticleTransactionsGroupedByPricingRule: %w", err)
+	}
+	if q.getArticleTransactionsOfTransactionsStmt, err = db.PrepareContext(ctx, getArticleTransactionsOfTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArticleTransactionsOfTransactions: %w", err)
+	}
 	if q.getArticleTypeByIdStmt, err = db.PrepareContext(ctx, getArticleTypeById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetArticleTypeById: %w", err)
 	}
@@ -84,30 +264,327 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getArticlesStmt, err = db.PrepareContext(ctx, getArticles); err != nil {
 		return nil, fmt.Errorf("error preparing query GetArticles: %w", err)
 	}
+	if q.getClosingArticleTotalsStmt, err = db.PrepareContext(ctx, getClosingArticleTotals); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosingArticleTotals: %w", err)
+	}
+	if q.getClosingArticleTypeTotalsStmt, err = db.PrepareContext(ctx, getClosingArticleTypeTotals); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosingArticleTypeTotals: %w", err)
+	}
+	if q.getClosingArticleTypesStmt, err = db.PrepareContext(ctx, getClosingArticleTypes); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosingArticleTypes: %w", err)
+	}
+	if q.getClosingByIdStmt, err = db.PrepareContext(ctx, getClosingById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosingById: %w", err)
+	}
+	if q.getClosingPaymentBackendTotalsStmt, err = db.PrepareContext(ctx, getClosingPaymentBackendTotals); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosingPaymentBackendTotals: %w", err)
+	}
+	if q.getClosingPaymentBackendsStmt, err = db.PrepareContext(ctx, getClosingPaymentBackends); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosingPaymentBackends: %w", err)
+	}
+	if q.getClosingTransactionTotalsStmt, err = db.PrepareContext(ctx, getClosingTransactionTotals); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosingTransactionTotals: %w", err)
+	}
+	if q.getClosingsStmt, err = db.PrepareContext(ctx, getClosings); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClosings: %w", err)
+	}
+	if q.getComponentLinesStmt, err = db.PrepareContext(ctx, getComponentLines); err != nil {
+		return nil, fmt.Errorf("error preparing query GetComponentLines: %w", err)
+	}
+	if q.getDefaultLocationStmt, err = db.PrepareContext(ctx, getDefaultLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDefaultLocation: %w", err)
+	}
+	if q.getDepositLiabilitiesStmt, err = db.PrepareContext(ctx, getDepositLiabilities); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDepositLiabilities: %w", err)
+	}
+	if q.getDuePaymentOutboxStmt, err = db.PrepareContext(ctx, getDuePaymentOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDuePaymentOutbox: %w", err)
+	}
+	if q.getDueStockAlertsStmt, err = db.PrepareContext(ctx, getDueStockAlerts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDueStockAlerts: %w", err)
+	}
+	if q.getEventByDateStmt, err = db.PrepareContext(ctx, getEventByDate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEventByDate: %w", err)
+	}
 	if q.getEventByIdStmt, err = db.PrepareContext(ctx, getEventById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEventById: %w", err)
 	}
 	if q.getEventsStmt, err = db.PrepareContext(ctx, getEvents); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEvents: %w", err)
 	}
+	if q.getGoodsReceiptLinesOfOrderStmt, err = db.PrepareContext(ctx, getGoodsReceiptLinesOfOrder); err != nil {
+		return nil, fmt.Errorf("error preparing query GetGoodsReceiptLinesOfOrder: %w", err)
+	}
+	if q.getGoodsReceiptsStmt, err = db.PrepareContext(ctx, getGoodsReceipts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetGoodsReceipts: %w", err)
+	}
+	if q.getLastClosingStmt, err = db.PrepareContext(ctx, getLastClosing); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLastClosing: %w", err)
+	}
+	if q.getLedgerAccountBalanceStmt, err = db.PrepareContext(ctx, getLedgerAccountBalance); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLedgerAccountBalance: %w", err)
+	}
+	if q.getLedgerEntriesByAccountStmt, err = db.PrepareContext(ctx, getLedgerEntriesByAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLedgerEntriesByAccount: %w", err)
+	}
+	if q.getLedgerPostingByDetailsStmt, err = db.PrepareContext(ctx, getLedgerPostingByDetails); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLedgerPostingByDetails: %w", err)
+	}
+	if q.getLocationByIdStmt, err = db.PrepareContext(ctx, getLocationById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLocationById: %w", err)
+	}
+	if q.getLocationsStmt, err = db.PrepareContext(ctx, getLocations); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLocations: %w", err)
+	}
+	if q.getOpenShiftByTerminalStmt, err = db.PrepareContext(ctx, getOpenShiftByTerminal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenShiftByTerminal: %w", err)
+	}
+	if q.getOpenStocktakeStmt, err = db.PrepareContext(ctx, getOpenStocktake); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenStocktake: %w", err)
+	}
+	if q.getOpenTabsOfEndedEventsStmt, err = db.PrepareContext(ctx, getOpenTabsOfEndedEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenTabsOfEndedEvents: %w", err)
+	}
+	if q.getOrCreateWalletAccountStmt, err = db.PrepareContext(ctx, getOrCreateWalletAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrCreateWalletAccount: %w", err)
+	}
+	if q.getPaymentOutboxByIdStmt, err = db.PrepareContext(ctx, getPaymentOutboxById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPaymentOutboxById: %w", err)
+	}
+	if q.getPaymentOutboxByTransactionStmt, err = db.PrepareContext(ctx, getPaymentOutboxByTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPaymentOutboxByTransaction: %w", err)
+	}
+	if q.getPaymentOutboxNeedingReconciliationStmt, err = db.PrepareContext(ctx, getPaymentOutboxNeedingReconciliation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPaymentOutboxNeedingReconciliation: %w", err)
+	}
+	if q.getPendingPaymentAmountStmt, err = db.PrepareContext(ctx, getPendingPaymentAmount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPendingPaymentAmount: %w", err)
+	}
+	if q.getPricingRuleByIdStmt, err = db.PrepareContext(ctx, getPricingRuleById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPricingRuleById: %w", err)
+	}
+	if q.getPricingRulesStmt, err = db.PrepareContext(ctx, getPricingRules); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPricingRules: %w", err)
+	}
+	if q.getPricingRulesForEventStmt, err = db.PrepareContext(ctx, getPricingRulesForEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPricingRulesForEvent: %w", err)
+	}
+	if q.getPurchaseOrderByIdStmt, err = db.PrepareContext(ctx, getPurchaseOrderById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPurchaseOrderById: %w", err)
+	}
+	if q.getPurchaseOrderByIdForUpdateStmt, err = db.PrepareContext(ctx, getPurchaseOrderByIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPurchaseOrderByIdForUpdate: %w", err)
+	}
+	if q.getPurchaseOrderLinesStmt, err = db.PrepareContext(ctx, getPurchaseOrderLines); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPurchaseOrderLines: %w", err)
+	}
+	if q.getPurchaseOrdersStmt, err = db.PrepareContext(ctx, getPurchaseOrders); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPurchaseOrders: %w", err)
+	}
+	if q.getReceiptLinesStmt, err = db.PrepareContext(ctx, getReceiptLines); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReceiptLines: %w", err)
+	}
+	if q.getRefundedAmountStmt, err = db.PrepareContext(ctx, getRefundedAmount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRefundedAmount: %w", err)
+	}
+	if q.getRefundsByTransactionStmt, err = db.PrepareContext(ctx, getRefundsByTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRefundsByTransaction: %w", err)
+	}
+	if q.getResidentGroupByIdStmt, err = db.PrepareContext(ctx, getResidentGroupById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetResidentGroupById: %w", err)
+	}
+	if q.getResidentGroupsStmt, err = db.PrepareContext(ctx, getResidentGroups); err != nil {
+		return nil, fmt.Errorf("error preparing query GetResidentGroups: %w", err)
+	}
+	if q.getResidentSpendingStmt, err = db.PrepareContext(ctx, getResidentSpending); err != nil {
+		return nil, fmt.Errorf("error preparing query GetResidentSpending: %w", err)
+	}
+	if q.getShiftByIdStmt, err = db.PrepareContext(ctx, getShiftById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetShiftById: %w", err)
+	}
+	if q.getShiftByIdForUpdateStmt, err = db.PrepareContext(ctx, getShiftByIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetShiftByIdForUpdate: %w", err)
+	}
+	if q.getShiftCashTotalStmt, err = db.PrepareContext(ctx, getShiftCashTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetShiftCashTotal: %w", err)
+	}
+	if q.getShiftCountsStmt, err = db.PrepareContext(ctx, getShiftCounts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetShiftCounts: %w", err)
+	}
+	if q.getShiftsStmt, err = db.PrepareContext(ctx, getShifts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetShifts: %w", err)
+	}
+	if q.getSplitSharesStmt, err = db.PrepareContext(ctx, getSplitShares); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSplitShares: %w", err)
+	}
+	if q.getStockAlertDeliveriesStmt, err = db.PrepareContext(ctx, getStockAlertDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStockAlertDeliveries: %w", err)
+	}
+	if q.getStockAlertsStmt, err = db.PrepareContext(ctx, getStockAlerts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStockAlerts: %w", err)
+	}
+	if q.getStockByLocationStmt, err = db.PrepareContext(ctx, getStockByLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStockByLocation: %w", err)
+	}
+	if q.getStockMovementsByArticleStmt, err = db.PrepareContext(ctx, getStockMovementsByArticle); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStockMovementsByArticle: %w", err)
+	}
+	if q.getStockTransfersStmt, err = db.PrepareContext(ctx, getStockTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStockTransfers: %w", err)
+	}
+	if q.getStocktakeByIdStmt, err = db.PrepareContext(ctx, getStocktakeById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStocktakeById: %w", err)
+	}
+	if q.getStocktakeByIdForUpdateStmt, err = db.PrepareContext(ctx, getStocktakeByIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStocktakeByIdForUpdate: %w", err)
+	}
+	if q.getStocktakeLinesStmt, err = db.PrepareContext(ctx, getStocktakeLines); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStocktakeLines: %w", err)
+	}
+	if q.getStocktakesStmt, err = db.PrepareContext(ctx, getStocktakes); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStocktakes: %w", err)
+	}
+	if q.getSupplierByIdStmt, err = db.PrepareContext(ctx, getSupplierById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSupplierById: %w", err)
+	}
+	if q.getSuppliersStmt, err = db.PrepareContext(ctx, getSuppliers); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSuppliers: %w", err)
+	}
+	if q.getSystemLedgerAccountStmt, err = db.PrepareContext(ctx, getSystemLedgerAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSystemLedgerAccount: %w", err)
+	}
+	if q.getTabByIdStmt, err = db.PrepareContext(ctx, getTabById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTabById: %w", err)
+	}
+	if q.getTabByIdForUpdateStmt, err = db.PrepareContext(ctx, getTabByIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTabByIdForUpdate: %w", err)
+	}
+	if q.getTabByTransactionStmt, err = db.PrepareContext(ctx, getTabByTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTabByTransaction: %w", err)
+	}
+	if q.getTabItemsByTabStmt, err = db.PrepareContext(ctx, getTabItemsByTab); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTabItemsByTab: %w", err)
+	}
+	if q.getTabsStmt, err = db.PrepareContext(ctx, getTabs); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTabs: %w", err)
+	}
+	if q.getTerminalByIdStmt, err = db.PrepareContext(ctx, getTerminalById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTerminalById: %w", err)
+	}
+	if q.getTerminalsStmt, err = db.PrepareContext(ctx, getTerminals); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTerminals: %w", err)
+	}
 	if q.getTransactionByIdStmt, err = db.PrepareContext(ctx, getTransactionById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTransactionById: %w", err)
 	}
+	if q.getTransactionByIdForUpdateStmt, err = db.PrepareContext(ctx, getTransactionByIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransactionByIdForUpdate: %w", err)
+	}
+	if q.getTransactionLinesTotalStmt, err = db.PrepareContext(ctx, getTransactionLinesTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransactionLinesTotal: %w", err)
+	}
+	if q.getTransactionStockLocationStmt, err = db.PrepareContext(ctx, getTransactionStockLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransactionStockLocation: %w", err)
+	}
 	if q.getTransactionsStmt, err = db.PrepareContext(ctx, getTransactions); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTransactions: %w", err)
 	}
+	if q.getUnclosedTabByResidentStmt, err = db.PrepareContext(ctx, getUnclosedTabByResident); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUnclosedTabByResident: %w", err)
+	}
+	if q.getUnclosedTabTotalStmt, err = db.PrepareContext(ctx, getUnclosedTabTotal); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUnclosedTabTotal: %w", err)
+	}
+	if q.getUndeliveredWebhooksStmt, err = db.PrepareContext(ctx, getUndeliveredWebhooks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUndeliveredWebhooks: %w", err)
+	}
 	if q.getUserByCodeStmt, err = db.PrepareContext(ctx, getUserByCode); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserByCode: %w", err)
 	}
 	if q.getUserByIdStmt, err = db.PrepareContext(ctx, getUserById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserById: %w", err)
 	}
+	if q.getUserByIdForUpdateStmt, err = db.PrepareContext(ctx, getUserByIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserByIdForUpdate: %w", err)
+	}
 	if q.getUsersStmt, err = db.PrepareContext(ctx, getUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUsers: %w", err)
 	}
+	if q.getWalletAccountStmt, err = db.PrepareContext(ctx, getWalletAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWalletAccount: %w", err)
+	}
+	if q.getWebhookByIdStmt, err = db.PrepareContext(ctx, getWebhookById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookById: %w", err)
+	}
+	if q.getWebhooksStmt, err = db.PrepareContext(ctx, getWebhooks); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhooks: %w", err)
+	}
+	if q.hasLocationStockStmt, err = db.PrepareContext(ctx, hasLocationStock); err != nil {
+		return nil, fmt.Errorf("error preparing query HasLocationStock: %w", err)
+	}
+	if q.hasSupplierPurchaseOrdersStmt, err = db.PrepareContext(ctx, hasSupplierPurchaseOrders); err != nil {
+		return nil, fmt.Errorf("error preparing query HasSupplierPurchaseOrders: %w", err)
+	}
+	if q.isArticleComponentStmt, err = db.PrepareContext(ctx, isArticleComponent); err != nil {
+		return nil, fmt.Errorf("error preparing query IsArticleComponent: %w", err)
+	}
+	if q.isPeriodClosedStmt, err = db.PrepareContext(ctx, isPeriodClosed); err != nil {
+		return nil, fmt.Errorf("error preparing query IsPeriodClosed: %w", err)
+	}
+	if q.lockClosingsStmt, err = db.PrepareContext(ctx, lockClosings); err != nil {
+		return nil, fmt.Errorf("error preparing query LockClosings: %w", err)
+	}
+	if q.lockClosingsForSaleStmt, err = db.PrepareContext(ctx, lockClosingsForSale); err != nil {
+		return nil, fmt.Errorf("error preparing query LockClosingsForSale: %w", err)
+	}
+	if q.lockLedgerAccountStmt, err = db.PrepareContext(ctx, lockLedgerAccount); err != nil {
+		return nil, fmt.Errorf("error preparing query LockLedgerAccount: %w", err)
+	}
+	if q.markPaymentOutboxProcessedStmt, err = db.PrepareContext(ctx, markPaymentOutboxProcessed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkPaymentOutboxProcessed: %w", err)
+	}
+	if q.markStockAlertProcessedStmt, err = db.PrepareContext(ctx, markStockAlertProcessed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkStockAlertProcessed: %w", err)
+	}
+	if q.moveArticleStockStmt, err = db.PrepareContext(ctx, moveArticleStock); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveArticleStock: %w", err)
+	}
+	if q.moveLocationStockStmt, err = db.PrepareContext(ctx, moveLocationStock); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveLocationStock: %w", err)
+	}
+	if q.receiveArticleStockStmt, err = db.PrepareContext(ctx, receiveArticleStock); err != nil {
+		return nil, fmt.Errorf("error preparing query ReceiveArticleStock: %w", err)
+	}
+	if q.receivePurchaseOrderLineStmt, err = db.PrepareContext(ctx, receivePurchaseOrderLine); err != nil {
+		return nil, fmt.Errorf("error preparing query ReceivePurchaseOrderLine: %w", err)
+	}
+	if q.recordPaymentOutboxAttemptStmt, err = db.PrepareContext(ctx, recordPaymentOutboxAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordPaymentOutboxAttempt: %w", err)
+	}
+	if q.recordStockAlertAttemptStmt, err = db.PrepareContext(ctx, recordStockAlertAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordStockAlertAttempt: %w", err)
+	}
+	if q.requeuePaymentOutboxStmt, err = db.PrepareContext(ctx, requeuePaymentOutbox); err != nil {
+		return nil, fmt.Errorf("error preparing query RequeuePaymentOutbox: %w", err)
+	}
+	if q.restoreArticleStmt, err = db.PrepareContext(ctx, restoreArticle); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreArticle: %w", err)
+	}
+	if q.restoreArticleTypeStmt, err = db.PrepareContext(ctx, restoreArticleType); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreArticleType: %w", err)
+	}
+	if q.setDefaultLocationStmt, err = db.PrepareContext(ctx, setDefaultLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query SetDefaultLocation: %w", err)
+	}
+	if q.setLaterPurchasePriceStmt, err = db.PrepareContext(ctx, setLaterPurchasePrice); err != nil {
+		return nil, fmt.Errorf("error preparing query SetLaterPurchasePrice: %w", err)
+	}
 	if q.updateArticleStmt, err = db.PrepareContext(ctx, updateArticle); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateArticle: %w", err)
 	}
+	if q.updateArticlePriceStmt, err = db.PrepareContext(ctx, updateArticlePrice); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateArticlePrice: %w", err)
+	}
 	if q.updateArticleTransactionStmt, err = db.PrepareContext(ctx, updateArticleTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateArticleTransaction: %w", err)
 	}
@@ -117,9 +594,33 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateEventStmt, err = db.PrepareContext(ctx, updateEvent); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateEvent: %w", err)
 	}
+	if q.updateLocationStmt, err = db.PrepareContext(ctx, updateLocation); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateLocation: %w", err)
+	}
+	if q.updatePaymentOutboxAmountStmt, err = db.PrepareContext(ctx, updatePaymentOutboxAmount); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePaymentOutboxAmount: %w", err)
+	}
+	if q.updatePricingRuleStmt, err = db.PrepareContext(ctx, updatePricingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePricingRule: %w", err)
+	}
+	if q.updatePurchaseOrderStatusStmt, err = db.PrepareContext(ctx, updatePurchaseOrderStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePurchaseOrderStatus: %w", err)
+	}
+	if q.updateResidentGroupStmt, err = db.PrepareContext(ctx, updateResidentGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateResidentGroup: %w", err)
+	}
+	if q.updateSupplierStmt, err = db.PrepareContext(ctx, updateSupplier); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateSupplier: %w", err)
+	}
+	if q.updateTerminalStmt, err = db.PrepareContext(ctx, updateTerminal); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTerminal: %w", err)
+	}
 	if q.updateTransactionStmt, err = db.PrepareContext(ctx, updateTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateTransaction: %w", err)
 	}
+	if q.updateTransactionStatusStmt, err = db.PrepareContext(ctx, updateTransactionStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTransactionStatus: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
@@ -128,11 +629,61 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
+	if q.applyArticlePricesStmt != nil {
+		if cerr := q.applyArticlePricesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing applyArticlePricesStmt: %w", cerr)
+		}
+	}
+	if q.archiveArticleStmt != nil {
+		if cerr := q.archiveArticleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing archiveArticleStmt: %w", cerr)
+		}
+	}
+	if q.archiveArticleTypeStmt != nil {
+		if cerr := q.archiveArticleTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing archiveArticleTypeStmt: %w", cerr)
+		}
+	}
+	if q.clearDefaultLocationStmt != nil {
+		if cerr := q.clearDefaultLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearDefaultLocationStmt: %w", cerr)
+		}
+	}
+	if q.closeShiftStmt != nil {
+		if cerr := q.closeShiftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closeShiftStmt: %w", cerr)
+		}
+	}
+	if q.closeStocktakeStmt != nil {
+		if cerr := q.closeStocktakeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closeStocktakeStmt: %w", cerr)
+		}
+	}
+	if q.closeTabStmt != nil {
+		if cerr := q.closeTabStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closeTabStmt: %w", cerr)
+		}
+	}
+	if q.countStocktakeLineStmt != nil {
+		if cerr := q.countStocktakeLineStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countStocktakeLineStmt: %w", cerr)
+		}
+	}
 	if q.createArticleStmt != nil {
 		if cerr := q.createArticleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createArticleStmt: %w", cerr)
 		}
 	}
+	if q.createArticleComponentStmt != nil {
+		if cerr := q.createArticleComponentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createArticleComponentStmt: %w", cerr)
+		}
+	}
+	if q.createArticlePriceStmt != nil {
+		if cerr := q.createArticlePriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createArticlePriceStmt: %w", cerr)
+		}
+	}
 	if q.createArticleTransactionStmt != nil {
 		if cerr := q.createArticleTransactionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createArticleTransactionStmt: %w", cerr)
@@ -143,11 +694,146 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createArticleTypeStmt: %w", cerr)
 		}
 	}
+	if q.createClosingStmt != nil {
+		if cerr := q.createClosingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createClosingStmt: %w", cerr)
+		}
+	}
+	if q.createClosingArticleTypeStmt != nil {
+		if cerr := q.createClosingArticleTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createClosingArticleTypeStmt: %w", cerr)
+		}
+	}
+	if q.createClosingPaymentBackendStmt != nil {
+		if cerr := q.createClosingPaymentBackendStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createClosingPaymentBackendStmt: %w", cerr)
+		}
+	}
 	if q.createEventStmt != nil {
 		if cerr := q.createEventStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createEventStmt: %w", cerr)
 		}
 	}
+	if q.createGoodsReceiptStmt != nil {
+		if cerr := q.createGoodsReceiptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createGoodsReceiptStmt: %w", cerr)
+		}
+	}
+	if q.createGoodsReceiptLineStmt != nil {
+		if cerr := q.createGoodsReceiptLineStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createGoodsReceiptLineStmt: %w", cerr)
+		}
+	}
+	if q.createLedgerEntryStmt != nil {
+		if cerr := q.createLedgerEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLedgerEntryStmt: %w", cerr)
+		}
+	}
+	if q.createLedgerPostingStmt != nil {
+		if cerr := q.createLedgerPostingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLedgerPostingStmt: %w", cerr)
+		}
+	}
+	if q.createLocationStmt != nil {
+		if cerr := q.createLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLocationStmt: %w", cerr)
+		}
+	}
+	if q.createPaymentOutboxStmt != nil {
+		if cerr := q.createPaymentOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPaymentOutboxStmt: %w", cerr)
+		}
+	}
+	if q.createPricingRuleStmt != nil {
+		if cerr := q.createPricingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPricingRuleStmt: %w", cerr)
+		}
+	}
+	if q.createPurchaseOrderStmt != nil {
+		if cerr := q.createPurchaseOrderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPurchaseOrderStmt: %w", cerr)
+		}
+	}
+	if q.createPurchaseOrderLineStmt != nil {
+		if cerr := q.createPurchaseOrderLineStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPurchaseOrderLineStmt: %w", cerr)
+		}
+	}
+	if q.createRefundArticleTransactionStmt != nil {
+		if cerr := q.createRefundArticleTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRefundArticleTransactionStmt: %w", cerr)
+		}
+	}
+	if q.createRefundTransactionStmt != nil {
+		if cerr := q.createRefundTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRefundTransactionStmt: %w", cerr)
+		}
+	}
+	if q.createResidentGroupStmt != nil {
+		if cerr := q.createResidentGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createResidentGroupStmt: %w", cerr)
+		}
+	}
+	if q.createShiftStmt != nil {
+		if cerr := q.createShiftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createShiftStmt: %w", cerr)
+		}
+	}
+	if q.createShiftCountStmt != nil {
+		if cerr := q.createShiftCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createShiftCountStmt: %w", cerr)
+		}
+	}
+	if q.createStockAlertStmt != nil {
+		if cerr := q.createStockAlertStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStockAlertStmt: %w", cerr)
+		}
+	}
+	if q.createStockAlertDeliveryStmt != nil {
+		if cerr := q.createStockAlertDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStockAlertDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.createStockMovementStmt != nil {
+		if cerr := q.createStockMovementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStockMovementStmt: %w", cerr)
+		}
+	}
+	if q.createStockTransferStmt != nil {
+		if cerr := q.createStockTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStockTransferStmt: %w", cerr)
+		}
+	}
+	if q.createStocktakeStmt != nil {
+		if cerr := q.createStocktakeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStocktakeStmt: %w", cerr)
+		}
+	}
+	if q.createStocktakeLinesStmt != nil {
+		if cerr := q.createStocktakeLinesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStocktakeLinesStmt: %w", cerr)
+		}
+	}
+	if q.createSupplierStmt != nil {
+		if cerr := q.createSupplierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSupplierStmt: %w", cerr)
+		}
+	}
+	if q.createTabStmt != nil {
+		if cerr := q.createTabStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTabStmt: %w", cerr)
+		}
+	}
+	if q.createTabItemStmt != nil {
+		if cerr := q.createTabItemStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTabItemStmt: %w", cerr)
+		}
+	}
+	if q.createTerminalStmt != nil {
+		if cerr := q.createTerminalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTerminalStmt: %w", cerr)
+		}
+	}
 	if q.createTransactionStmt != nil {
 		if cerr := q.createTransactionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTransactionStmt: %w", cerr)
@@ -158,9 +844,24 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
-	if q.deleteArticleStmt != nil {
-		if cerr := q.deleteArticleStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing deleteArticleStmt: %w", cerr)
+	if q.createWebhookStmt != nil {
+		if cerr := q.createWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookDeliveryStmt != nil {
+		if cerr := q.createWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.deleteArticleComponentsStmt != nil {
+		if cerr := q.deleteArticleComponentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteArticleComponentsStmt: %w", cerr)
+		}
+	}
+	if q.deleteArticlePriceStmt != nil {
+		if cerr := q.deleteArticlePriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteArticlePriceStmt: %w", cerr)
 		}
 	}
 	if q.deleteArticleTransactionStmt != nil {
@@ -168,9 +869,14 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteArticleTransactionStmt: %w", cerr)
 		}
 	}
-	if q.deleteArticleTypeStmt != nil {
-		if cerr := q.deleteArticleTypeStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing deleteArticleTypeStmt: %w", cerr)
+	if q.deleteArticleTransactionsByTransactionStmt != nil {
+		if cerr := q.deleteArticleTransactionsByTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteArticleTransactionsByTransactionStmt: %w", cerr)
+		}
+	}
+	if q.deleteDepositLinesStmt != nil {
+		if cerr := q.deleteDepositLinesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteDepositLinesStmt: %w", cerr)
 		}
 	}
 	if q.deleteEventStmt != nil {
@@ -178,6 +884,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteEventStmt: %w", cerr)
 		}
 	}
+	if q.deleteLocationStmt != nil {
+		if cerr := q.deleteLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteLocationStmt: %w", cerr)
+		}
+	}
+	if q.deletePricingRuleStmt != nil {
+		if cerr := q.deletePricingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePricingRuleStmt: %w", cerr)
+		}
+	}
+	if q.deleteResidentGroupStmt != nil {
+		if cerr := q.deleteResidentGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteResidentGroupStmt: %w", cerr)
+		}
+	}
+	if q.deleteSupplierStmt != nil {
+		if cerr := q.deleteSupplierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSupplierStmt: %w", cerr)
+		}
+	}
+	if q.deleteTerminalStmt != nil {
+		if cerr := q.deleteTerminalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTerminalStmt: %w", cerr)
+		}
+	}
 	if q.deleteTransactionStmt != nil {
 		if cerr := q.deleteTransactionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteTransactionStmt: %w", cerr)
@@ -188,11 +919,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteUserStmt: %w", cerr)
 		}
 	}
+	if q.deleteWebhookStmt != nil {
+		if cerr := q.deleteWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWebhookStmt: %w", cerr)
+		}
+	}
+	if q.endArticlePriceStmt != nil {
+		if cerr := q.endArticlePriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing endArticlePriceStmt: %w", cerr)
+		}
+	}
+	if q.extendArticlePriceStmt != nil {
+		if cerr := q.extendArticlePriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing extendArticlePriceStmt: %w", cerr)
+		}
+	}
+	if q.flagTabStmt != nil {
+		if cerr := q.flagTabStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing flagTabStmt: %w", cerr)
+		}
+	}
 	if q.getArticleByIdStmt != nil {
 		if cerr := q.getArticleByIdStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getArticleByIdStmt: %w", cerr)
 		}
 	}
+	if q.getArticleByIdForUpdateStmt != nil {
+		if cerr := q.getArticleByIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticleByIdForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getArticleComponentsStmt != nil {
+		if cerr := q.getArticleComponentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticleComponentsStmt: %w", cerr)
+		}
+	}
+	if q.getArticlePriceAtStmt != nil {
+		if cerr := q.getArticlePriceAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticlePriceAtStmt: %w", cerr)
+		}
+	}
+	if q.getArticlePriceByIdStmt != nil {
+		if cerr := q.getArticlePriceByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticlePriceByIdStmt: %w", cerr)
+		}
+	}
+	if q.getArticlePricesStmt != nil {
+		if cerr := q.getArticlePricesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticlePricesStmt: %w", cerr)
+		}
+	}
+	if q.getArticlePricesAtStmt != nil {
+		if cerr := q.getArticlePricesAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticlePricesAtStmt: %w", cerr)
+		}
+	}
+	if q.getArticleStockForUpdateStmt != nil {
+		if cerr := q.getArticleStockForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticleStockForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.getArticleTransactionByIdStmt != nil {
 		if cerr := q.getArticleTransactionByIdStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getArticleTransactionByIdStmt: %w", cerr)
@@ -203,11 +989,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getArticleTransactionsStmt: %w", cerr)
 		}
 	}
+	if q.getArticleTransactionsByTransactionStmt != nil {
+		if cerr := q.getArticleTransactionsByTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticleTransactionsByTransactionStmt: %w", cerr)
+		}
+	}
 	if q.getArticleTransactionsGroupedByArticleStmt != nil {
 		if cerr := q.getArticleTransactionsGroupedByArticleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getArticleTransactionsGroupedByArticleStmt: %w", cerr)
 		}
 	}
+	if q.getArticleTransactionsGroupedByPricingRuleStmt != nil {
+		if cerr := q.getArticleTransactionsGroupedByPricingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticleTransactionsGroupedByPricingRuleStmt: %w", cerr)
+		}
+	}
+	if q.getArticleTransactionsOfTransactionsStmt != nil {
+		if cerr := q.getArticleTransactionsOfTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArticleTransactionsOfTransactionsStmt: %w", cerr)
+		}
+	}
 	if q.getArticleTypeByIdStmt != nil {
 		if cerr := q.getArticleTypeByIdStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getArticleTypeByIdStmt: %w", cerr)
@@ -228,6 +1029,76 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getArticlesStmt: %w", cerr)
 		}
 	}
+	if q.getClosingArticleTotalsStmt != nil {
+		if cerr := q.getClosingArticleTotalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingArticleTotalsStmt: %w", cerr)
+		}
+	}
+	if q.getClosingArticleTypeTotalsStmt != nil {
+		if cerr := q.getClosingArticleTypeTotalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingArticleTypeTotalsStmt: %w", cerr)
+		}
+	}
+	if q.getClosingArticleTypesStmt != nil {
+		if cerr := q.getClosingArticleTypesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingArticleTypesStmt: %w", cerr)
+		}
+	}
+	if q.getClosingByIdStmt != nil {
+		if cerr := q.getClosingByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingByIdStmt: %w", cerr)
+		}
+	}
+	if q.getClosingPaymentBackendTotalsStmt != nil {
+		if cerr := q.getClosingPaymentBackendTotalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingPaymentBackendTotalsStmt: %w", cerr)
+		}
+	}
+	if q.getClosingPaymentBackendsStmt != nil {
+		if cerr := q.getClosingPaymentBackendsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingPaymentBackendsStmt: %w", cerr)
+		}
+	}
+	if q.getClosingTransactionTotalsStmt != nil {
+		if cerr := q.getClosingTransactionTotalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingTransactionTotalsStmt: %w", cerr)
+		}
+	}
+	if q.getClosingsStmt != nil {
+		if cerr := q.getClosingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClosingsStmt: %w", cerr)
+		}
+	}
+	if q.getComponentLinesStmt != nil {
+		if cerr := q.getComponentLinesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getComponentLinesStmt: %w", cerr)
+		}
+	}
+	if q.getDefaultLocationStmt != nil {
+		if cerr := q.getDefaultLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDefaultLocationStmt: %w", cerr)
+		}
+	}
+	if q.getDepositLiabilitiesStmt != nil {
+		if cerr := q.getDepositLiabilitiesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDepositLiabilitiesStmt: %w", cerr)
+		}
+	}
+	if q.getDuePaymentOutboxStmt != nil {
+		if cerr := q.getDuePaymentOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDuePaymentOutboxStmt: %w", cerr)
+		}
+	}
+	if q.getDueStockAlertsStmt != nil {
+		if cerr := q.getDueStockAlertsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDueStockAlertsStmt: %w", cerr)
+		}
+	}
+	if q.getEventByDateStmt != nil {
+		if cerr := q.getEventByDateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEventByDateStmt: %w", cerr)
+		}
+	}
 	if q.getEventByIdStmt != nil {
 		if cerr := q.getEventByIdStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEventByIdStmt: %w", cerr)
@@ -238,16 +1109,316 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getEventsStmt: %w", cerr)
 		}
 	}
+	if q.getGoodsReceiptLinesOfOrderStmt != nil {
+		if cerr := q.getGoodsReceiptLinesOfOrderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getGoodsReceiptLinesOfOrderStmt: %w", cerr)
+		}
+	}
+	if q.getGoodsReceiptsStmt != nil {
+		if cerr := q.getGoodsReceiptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getGoodsReceiptsStmt: %w", cerr)
+		}
+	}
+	if q.getLastClosingStmt != nil {
+		if cerr := q.getLastClosingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLastClosingStmt: %w", cerr)
+		}
+	}
+	if q.getLedgerAccountBalanceStmt != nil {
+		if cerr := q.getLedgerAccountBalanceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLedgerAccountBalanceStmt: %w", cerr)
+		}
+	}
+	if q.getLedgerEntriesByAccountStmt != nil {
+		if cerr := q.getLedgerEntriesByAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLedgerEntriesByAccountStmt: %w", cerr)
+		}
+	}
+	if q.getLedgerPostingByDetailsStmt != nil {
+		if cerr := q.getLedgerPostingByDetailsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLedgerPostingByDetailsStmt: %w", cerr)
+		}
+	}
+	if q.getLocationByIdStmt != nil {
+		if cerr := q.getLocationByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLocationByIdStmt: %w", cerr)
+		}
+	}
+	if q.getLocationsStmt != nil {
+		if cerr := q.getLocationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLocationsStmt: %w", cerr)
+		}
+	}
+	if q.getOpenShiftByTerminalStmt != nil {
+		if cerr := q.getOpenShiftByTerminalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenShiftByTerminalStmt: %w", cerr)
+		}
+	}
+	if q.getOpenStocktakeStmt != nil {
+		if cerr := q.getOpenStocktakeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenStocktakeStmt: %w", cerr)
+		}
+	}
+	if q.getOpenTabsOfEndedEventsStmt != nil {
+		if cerr := q.getOpenTabsOfEndedEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenTabsOfEndedEventsStmt: %w", cerr)
+		}
+	}
+	if q.getOrCreateWalletAccountStmt != nil {
+		if cerr := q.getOrCreateWalletAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrCreateWalletAccountStmt: %w", cerr)
+		}
+	}
+	if q.getPaymentOutboxByIdStmt != nil {
+		if cerr := q.getPaymentOutboxByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPaymentOutboxByIdStmt: %w", cerr)
+		}
+	}
+	if q.getPaymentOutboxByTransactionStmt != nil {
+		if cerr := q.getPaymentOutboxByTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPaymentOutboxByTransactionStmt: %w", cerr)
+		}
+	}
+	if q.getPaymentOutboxNeedingReconciliationStmt != nil {
+		if cerr := q.getPaymentOutboxNeedingReconciliationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPaymentOutboxNeedingReconciliationStmt: %w", cerr)
+		}
+	}
+	if q.getPendingPaymentAmountStmt != nil {
+		if cerr := q.getPendingPaymentAmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPendingPaymentAmountStmt: %w", cerr)
+		}
+	}
+	if q.getPricingRuleByIdStmt != nil {
+		if cerr := q.getPricingRuleByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPricingRuleByIdStmt: %w", cerr)
+		}
+	}
+	if q.getPricingRulesStmt != nil {
+		if cerr := q.getPricingRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPricingRulesStmt: %w", cerr)
+		}
+	}
+	if q.getPricingRulesForEventStmt != nil {
+		if cerr := q.getPricingRulesForEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPricingRulesForEventStmt: %w", cerr)
+		}
+	}
+	if q.getPurchaseOrderByIdStmt != nil {
+		if cerr := q.getPurchaseOrderByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPurchaseOrderByIdStmt: %w", cerr)
+		}
+	}
+	if q.getPurchaseOrderByIdForUpdateStmt != nil {
+		if cerr := q.getPurchaseOrderByIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPurchaseOrderByIdForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getPurchaseOrderLinesStmt != nil {
+		if cerr := q.getPurchaseOrderLinesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPurchaseOrderLinesStmt: %w", cerr)
+		}
+	}
+	if q.getPurchaseOrdersStmt != nil {
+		if cerr := q.getPurchaseOrdersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPurchaseOrdersStmt: %w", cerr)
+		}
+	}
+	if q.getReceiptLinesStmt != nil {
+		if cerr := q.getReceiptLinesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReceiptLinesStmt: %w", cerr)
+		}
+	}
+	if q.getRefundedAmountStmt != nil {
+		if cerr := q.getRefundedAmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRefundedAmountStmt: %w", cerr)
+		}
+	}
+	if q.getRefundsByTransactionStmt != nil {
+		if cerr := q.getRefundsByTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRefundsByTransactionStmt: %w", cerr)
+		}
+	}
+	if q.getResidentGroupByIdStmt != nil {
+		if cerr := q.getResidentGroupByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getResidentGroupByIdStmt: %w", cerr)
+		}
+	}
+	if q.getResidentGroupsStmt != nil {
+		if cerr := q.getResidentGroupsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getResidentGroupsStmt: %w", cerr)
+		}
+	}
+	if q.getResidentSpendingStmt != nil {
+		if cerr := q.getResidentSpendingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getResidentSpendingStmt: %w", cerr)
+		}
+	}
+	if q.getShiftByIdStmt != nil {
+		if cerr := q.getShiftByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getShiftByIdStmt: %w", cerr)
+		}
+	}
+	if q.getShiftByIdForUpdateStmt != nil {
+		if cerr := q.getShiftByIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getShiftByIdForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getShiftCashTotalStmt != nil {
+		if cerr := q.getShiftCashTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getShiftCashTotalStmt: %w", cerr)
+		}
+	}
+	if q.getShiftCountsStmt != nil {
+		if cerr := q.getShiftCountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getShiftCountsStmt: %w", cerr)
+		}
+	}
+	if q.getShiftsStmt != nil {
+		if cerr := q.getShiftsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getShiftsStmt: %w", cerr)
+		}
+	}
+	if q.getSplitSharesStmt != nil {
+		if cerr := q.getSplitSharesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSplitSharesStmt: %w", cerr)
+		}
+	}
+	if q.getStockAlertDeliveriesStmt != nil {
+		if cerr := q.getStockAlertDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStockAlertDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.getStockAlertsStmt != nil {
+		if cerr := q.getStockAlertsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStockAlertsStmt: %w", cerr)
+		}
+	}
+	if q.getStockByLocationStmt != nil {
+		if cerr := q.getStockByLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStockByLocationStmt: %w", cerr)
+		}
+	}
+	if q.getStockMovementsByArticleStmt != nil {
+		if cerr := q.getStockMovementsByArticleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStockMovementsByArticleStmt: %w", cerr)
+		}
+	}
+	if q.getStockTransfersStmt != nil {
+		if cerr := q.getStockTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStockTransfersStmt: %w", cerr)
+		}
+	}
+	if q.getStocktakeByIdStmt != nil {
+		if cerr := q.getStocktakeByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStocktakeByIdStmt: %w", cerr)
+		}
+	}
+	if q.getStocktakeByIdForUpdateStmt != nil {
+		if cerr := q.getStocktakeByIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStocktakeByIdForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getStocktakeLinesStmt != nil {
+		if cerr := q.getStocktakeLinesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStocktakeLinesStmt: %w", cerr)
+		}
+	}
+	if q.getStocktakesStmt != nil {
+		if cerr := q.getStocktakesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStocktakesStmt: %w", cerr)
+		}
+	}
+	if q.getSupplierByIdStmt != nil {
+		if cerr := q.getSupplierByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSupplierByIdStmt: %w", cerr)
+		}
+	}
+	if q.getSuppliersStmt != nil {
+		if cerr := q.getSuppliersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSuppliersStmt: %w", cerr)
+		}
+	}
+	if q.getSystemLedgerAccountStmt != nil {
+		if cerr := q.getSystemLedgerAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSystemLedgerAccountStmt: %w", cerr)
+		}
+	}
+	if q.getTabByIdStmt != nil {
+		if cerr := q.getTabByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTabByIdStmt: %w", cerr)
+		}
+	}
+	if q.getTabByIdForUpdateStmt != nil {
+		if cerr := q.getTabByIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTabByIdForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTabByTransactionStmt != nil {
+		if cerr := q.getTabByTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTabByTransactionStmt: %w", cerr)
+		}
+	}
+	if q.getTabItemsByTabStmt != nil {
+		if cerr := q.getTabItemsByTabStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTabItemsByTabStmt: %w", cerr)
+		}
+	}
+	if q.getTabsStmt != nil {
+		if cerr := q.getTabsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTabsStmt: %w", cerr)
+		}
+	}
+	if q.getTerminalByIdStmt != nil {
+		if cerr := q.getTerminalByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTerminalByIdStmt: %w", cerr)
+		}
+	}
+	if q.getTerminalsStmt != nil {
+		if cerr := q.getTerminalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTerminalsStmt: %w", cerr)
+		}
+	}
 	if q.getTransactionByIdStmt != nil {
 		if cerr := q.getTransactionByIdStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTransactionByIdStmt: %w", cerr)
 		}
 	}
+	if q.getTransactionByIdForUpdateStmt != nil {
+		if cerr := q.getTransactionByIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransactionByIdForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTransactionLinesTotalStmt != nil {
+		if cerr := q.getTransactionLinesTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransactionLinesTotalStmt: %w", cerr)
+		}
+	}
+	if q.getTransactionStockLocationStmt != nil {
+		if cerr := q.getTransactionStockLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransactionStockLocationStmt: %w", cerr)
+		}
+	}
 	if q.getTransactionsStmt != nil {
 		if cerr := q.getTransactionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTransactionsStmt: %w", cerr)
 		}
 	}
+	if q.getUnclosedTabByResidentStmt != nil {
+		if cerr := q.getUnclosedTabByResidentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUnclosedTabByResidentStmt: %w", cerr)
+		}
+	}
+	if q.getUnclosedTabTotalStmt != nil {
+		if cerr := q.getUnclosedTabTotalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUnclosedTabTotalStmt: %w", cerr)
+		}
+	}
+	if q.getUndeliveredWebhooksStmt != nil {
+		if cerr := q.getUndeliveredWebhooksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUndeliveredWebhooksStmt: %w", cerr)
+		}
+	}
 	if q.getUserByCodeStmt != nil {
 		if cerr := q.getUserByCodeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserByCodeStmt: %w", cerr)
@@ -258,16 +1429,141 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserByIdStmt: %w", cerr)
 		}
 	}
+	if q.getUserByIdForUpdateStmt != nil {
+		if cerr := q.getUserByIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserByIdForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.getUsersStmt != nil {
 		if cerr := q.getUsersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUsersStmt: %w", cerr)
 		}
 	}
+	if q.getWalletAccountStmt != nil {
+		if cerr := q.getWalletAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWalletAccountStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookByIdStmt != nil {
+		if cerr := q.getWebhookByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookByIdStmt: %w", cerr)
+		}
+	}
+	if q.getWebhooksStmt != nil {
+		if cerr := q.getWebhooksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhooksStmt: %w", cerr)
+		}
+	}
+	if q.hasLocationStockStmt != nil {
+		if cerr := q.hasLocationStockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing hasLocationStockStmt: %w", cerr)
+		}
+	}
+	if q.hasSupplierPurchaseOrdersStmt != nil {
+		if cerr := q.hasSupplierPurchaseOrdersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing hasSupplierPurchaseOrdersStmt: %w", cerr)
+		}
+	}
+	if q.isArticleComponentStmt != nil {
+		if cerr := q.isArticleComponentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isArticleComponentStmt: %w", cerr)
+		}
+	}
+	if q.isPeriodClosedStmt != nil {
+		if cerr := q.isPeriodClosedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isPeriodClosedStmt: %w", cerr)
+		}
+	}
+	if q.lockClosingsStmt != nil {
+		if cerr := q.lockClosingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockClosingsStmt: %w", cerr)
+		}
+	}
+	if q.lockClosingsForSaleStmt != nil {
+		if cerr := q.lockClosingsForSaleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockClosingsForSaleStmt: %w", cerr)
+		}
+	}
+	if q.lockLedgerAccountStmt != nil {
+		if cerr := q.lockLedgerAccountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockLedgerAccountStmt: %w", cerr)
+		}
+	}
+	if q.markPaymentOutboxProcessedStmt != nil {
+		if cerr := q.markPaymentOutboxProcessedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markPaymentOutboxProcessedStmt: %w", cerr)
+		}
+	}
+	if q.markStockAlertProcessedStmt != nil {
+		if cerr := q.markStockAlertProcessedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markStockAlertProcessedStmt: %w", cerr)
+		}
+	}
+	if q.moveArticleStockStmt != nil {
+		if cerr := q.moveArticleStockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveArticleStockStmt: %w", cerr)
+		}
+	}
+	if q.moveLocationStockStmt != nil {
+		if cerr := q.moveLocationStockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveLocationStockStmt: %w", cerr)
+		}
+	}
+	if q.receiveArticleStockStmt != nil {
+		if cerr := q.receiveArticleStockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing receiveArticleStockStmt: %w", cerr)
+		}
+	}
+	if q.receivePurchaseOrderLineStmt != nil {
+		if cerr := q.receivePurchaseOrderLineStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing receivePurchaseOrderLineStmt: %w", cerr)
+		}
+	}
+	if q.recordPaymentOutboxAttemptStmt != nil {
+		if cerr := q.recordPaymentOutboxAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordPaymentOutboxAttemptStmt: %w", cerr)
+		}
+	}
+	if q.recordStockAlertAttemptStmt != nil {
+		if cerr := q.recordStockAlertAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordStockAlertAttemptStmt: %w", cerr)
+		}
+	}
+	if q.requeuePaymentOutboxStmt != nil {
+		if cerr := q.requeuePaymentOutboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing requeuePaymentOutboxStmt: %w", cerr)
+		}
+	}
+	if q.restoreArticleStmt != nil {
+		if cerr := q.restoreArticleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreArticleStmt: %w", cerr)
+		}
+	}
+	if q.restoreArticleTypeStmt != nil {
+		if cerr := q.restoreArticleTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreArticleTypeStmt: %w", cerr)
+		}
+	}
+	if q.setDefaultLocationStmt != nil {
+		if cerr := q.setDefaultLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setDefaultLocationStmt: %w", cerr)
+		}
+	}
+	if q.setLaterPurchasePriceStmt != nil {
+		if cerr := q.setLaterPurchasePriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setLaterPurchasePriceStmt: %w", cerr)
+		}
+	}
 	if q.updateArticleStmt != nil {
 		if cerr := q.updateArticleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateArticleStmt: %w", cerr)
 		}
 	}
+	if q.updateArticlePriceStmt != nil {
+		if cerr := q.updateArticlePriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateArticlePriceStmt: %w", cerr)
+		}
+	}
 	if q.updateArticleTransactionStmt != nil {
 		if cerr := q.updateArticleTransactionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateArticleTransactionStmt: %w", cerr)
@@ -283,11 +1579,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateEventStmt: %w", cerr)
 		}
 	}
+	if q.updateLocationStmt != nil {
+		if cerr := q.updateLocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateLocationStmt: %w", cerr)
+		}
+	}
+	if q.updatePaymentOutboxAmountStmt != nil {
+		if cerr := q.updatePaymentOutboxAmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePaymentOutboxAmountStmt: %w", cerr)
+		}
+	}
+	if q.updatePricingRuleStmt != nil {
+		if cerr := q.updatePricingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePricingRuleStmt: %w", cerr)
+		}
+	}
+	if q.updatePurchaseOrderStatusStmt != nil {
+		if cerr := q.updatePurchaseOrderStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePurchaseOrderStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateResidentGroupStmt != nil {
+		if cerr := q.updateResidentGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateResidentGroupStmt: %w", cerr)
+		}
+	}
+	if q.updateSupplierStmt != nil {
+		if cerr := q.updateSupplierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateSupplierStmt: %w", cerr)
+		}
+	}
+	if q.updateTerminalStmt != nil {
+		if cerr := q.updateTerminalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTerminalStmt: %w", cerr)
+		}
+	}
 	if q.updateTransactionStmt != nil {
 		if cerr := q.updateTransactionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateTransactionStmt: %w", cerr)
 		}
 	}
+	if q.updateTransactionStatusStmt != nil {
+		if cerr := q.updateTransactionStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTransactionStatusStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
@@ -330,79 +1666,413 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                         DBTX
-	tx                                         *sql.Tx
-	createArticleStmt                          *sql.Stmt
-	createArticleTransactionStmt               *sql.Stmt
-	createArticleTypeStmt                      *sql.Stmt
-	createEventStmt                            *sql.Stmt
-	createTransactionStmt                      *sql.Stmt
-	createUserStmt                             *sql.Stmt
-	deleteArticleStmt                          *sql.Stmt
-	deleteArticleTransactionStmt               *sql.Stmt
-	deleteArticleTypeStmt                      *sql.Stmt
-	deleteEventStmt                            *sql.Stmt
-	deleteTransactionStmt                      *sql.Stmt
-	deleteUserStmt                             *sql.Stmt
-	getArticleByIdStmt                         *sql.Stmt
-	getArticleTransactionByIdStmt              *sql.Stmt
-	getArticleTransactionsStmt                 *sql.Stmt
-	getArticleTransactionsGroupedByArticleStmt *sql.Stmt
-	getArticleTypeByIdStmt                     *sql.Stmt
-	getArticleTypesStmt                        *sql.Stmt
-	getArticleTypesWithArticlesStmt            *sql.Stmt
-	getArticlesStmt                            *sql.Stmt
-	getEventByIdStmt                           *sql.Stmt
-	getEventsStmt                              *sql.Stmt
-	getTransactionByIdStmt                     *sql.Stmt
-	getTransactionsStmt                        *sql.Stmt
-	getUserByCodeStmt                          *sql.Stmt
-	getUserByIdStmt                            *sql.Stmt
-	getUsersStmt                               *sql.Stmt
-	updateArticleStmt                          *sql.Stmt
-	updateArticleTransactionStmt               *sql.Stmt
-	updateArticleTypeStmt                      *sql.Stmt
-	updateEventStmt                            *sql.Stmt
-	updateTransactionStmt                      *sql.Stmt
-	updateUserStmt                             *sql.Stmt
+	db                                             DBTX
+	tx                                             *sql.Tx
+	applyArticlePricesStmt                         *sql.Stmt
+	archiveArticleStmt                             *sql.Stmt
+	archiveArticleTypeStmt                         *sql.Stmt
+	clearDefaultLocationStmt                       *sql.Stmt
+	closeShiftStmt                                 *sql.Stmt
+	closeStocktakeStmt                             *sql.Stmt
+	closeTabStmt                                   *sql.Stmt
+	countStocktakeLineStmt                         *sql.Stmt
+	createArticleStmt                              *sql.Stmt
+	createArticleComponentStmt                     *sql.Stmt
+	createArticlePriceStmt                         *sql.Stmt
+	createArticleTransactionStmt                   *sql.Stmt
+	createArticleTypeStmt                          *sql.Stmt
+	createClosingStmt                              *sql.Stmt
+	createClosingArticleTypeStmt                   *sql.Stmt
+	createClosingPaymentBackendStmt                *sql.Stmt
+	createEventStmt                                *sql.Stmt
+	createGoodsReceiptStmt                         *sql.Stmt
+	createGoodsReceiptLineStmt                     *sql.Stmt
+	createLedgerEntryStmt                          *sql.Stmt
+	createLedgerPostingStmt                        *sql.Stmt
+	createLocationStmt                             *sql.Stmt
+	createPaymentOutboxStmt                        *sql.Stmt
+	createPricingRuleStmt                          *sql.Stmt
+	createPurchaseOrderStmt                        *sql.Stmt
+	createPurchaseOrderLineStmt                    *sql.Stmt
+	createRefundArticleTransactionStmt             *sql.Stmt
+	createRefundTransactionStmt                    *sql.Stmt
+	createResidentGroupStmt                        *sql.Stmt
+	createShiftStmt                                *sql.Stmt
+	createShiftCountStmt                           *sql.Stmt
+	createStockAlertStmt                           *sql.Stmt
+	createStockAlertDeliveryStmt                   *sql.Stmt
+	createStockMovementStmt                        *sql.Stmt
+	createStockTransferStmt                        *sql.Stmt
+	createStocktakeStmt                            *sql.Stmt
+	createStocktakeLinesStmt                       *sql.Stmt
+	createSupplierStmt                             *sql.Stmt
+	createTabStmt                                  *sql.Stmt
+	createTabItemStmt                              *sql.Stmt
+	createTerminalStmt                             *sql.Stmt
+	createTransactionStmt                          *sql.Stmt
+	createUserStmt                                 *sql.Stmt
+	createWebhookStmt                              *sql.Stmt
+	createWebhookDeliveryStmt                      *sql.Stmt
+	deleteArticleComponentsStmt                    *sql.Stmt
+	deleteArticlePriceStmt                         *sql.Stmt
+	deleteArticleTransactionStmt                   *sql.Stmt
+	deleteArticleTransactionsByTransactionStmt     *sql.Stmt
+	deleteDepositLinesStmt                         *sql.Stmt
+	deleteEventStmt                                *sql.Stmt
+	deleteLocationStmt                             *sql.Stmt
+	deletePricingRuleStmt                          *sql.Stmt
+	deleteResidentGroupStmt                        *sql.Stmt
+	deleteSupplierStmt                             *sql.Stmt
+	deleteTerminalStmt                             *sql.Stmt
+	deleteTransactionStmt                          *sql.Stmt
+	deleteUserStmt                                 *sql.Stmt
+	deleteWebhookStmt                              *sql.Stmt
+	endArticlePriceStmt                            *sql.Stmt
+	extendArticlePriceStmt                         *sql.Stmt
+	flagTabStmt                                    *sql.Stmt
+	getArticleByIdStmt                             *sql.Stmt
+	getArticleByIdForUpdateStmt                    *sql.Stmt
+	getArticleComponentsStmt                       *sql.Stmt
+	getArticlePriceAtStmt                          *sql.Stmt
+	getArticlePriceByIdStmt                        *sql.Stmt
+	getArticlePricesStmt                           *sql.Stmt
+	getArticlePricesAtStmt                         *sql.Stmt
+	getArticleStockForUpdateStmt                   *sql.Stmt
+	getArticleTransactionByIdStmt                  *sql.Stmt
+	getArticleTransactionsStmt                     *sql.Stmt
+	getArticleTransactionsByTransactionStmt        *sql.Stmt
+	getArticleTransactionsGroupedByArticleStmt     *sql.Stmt
+	getArticleTransactionsGroupedByPricingRuleStmt *sql.Stmt
+	getArticleTransactionsOfTransactionsStmt       *sql.Stmt
+	getArticleTypeByIdStmt                         *sql.Stmt
+	getArticleTypesStmt                            *sql.Stmt
+	getArticleTypesWithArticlesStmt                *sql.Stmt
+	getArticlesStmt                                *sql.Stmt
+	getClosingArticleTotalsStmt                    *sql.Stmt
+	getClosingArticleTypeTotalsStmt                *sql.Stmt
+	getClosingArticleTypesStmt                     *sql.Stmt
+	getClosingByIdStmt                             *sql.Stmt
+	getClosingPaymentBackendTotalsStmt             *sql.Stmt
+	getClosingPaymentBackendsStmt                  *sql.Stmt
+	getClosingTransactionTotalsStmt                *sql.Stmt
+	getClosingsStmt                                *sql.Stmt
+	getComponentLinesStmt                          *sql.Stmt
+	getDefaultLocationStmt                         *sql.Stmt
+	getDepositLiabilitiesStmt                      *sql.Stmt
+	getDuePaymentOutboxStmt                        *sql.Stmt
+	getDueStockAlertsStmt                          *sql.Stmt
+	getEventByDateStmt                             *sql.Stmt
+	getEventByIdStmt                               *sql.Stmt
+	getEventsStmt                                  *sql.Stmt
+	getGoodsReceiptLinesOfOrderStmt                *sql.Stmt
+	getGoodsReceiptsStmt                           *sql.Stmt
+	getLastClosingStmt                             *sql.Stmt
+	getLedgerAccountBalanceStmt                    *sql.Stmt
+	getLedgerEntriesByAccountStmt                  *sql.Stmt
+	getLedgerPostingByDetailsStmt                  *sql.Stmt
+	getLocationByIdStmt                            *sql.Stmt
+	getLocationsStmt                               *sql.Stmt
+	getOpenShiftByTerminalStmt                     *sql.Stmt
+	getOpenStocktakeStmt                           *sql.Stmt
+	getOpenTabsOfEndedEventsStmt                   *sql.Stmt
+	getOrCreateWalletAccountStmt                   *sql.Stmt
+	getPaymentOutboxByIdStmt                       *sql.Stmt
+	getPaymentOutboxByTransactionStmt              *sql.Stmt
+	getPaymentOutboxNeedingReconciliationStmt      *sql.Stmt
+	getPendingPaymentAmountStmt                    *sql.Stmt
+	getPricingRuleByIdStmt                         *sql.Stmt
+	getPricingRulesStmt                            *sql.Stmt
+	getPricingRulesForEventStmt                    *sql.Stmt
+	getPurchaseOrderByIdStmt                       *sql.Stmt
+	getPurchaseOrderByIdForUpdateStmt              *sql.Stmt
+	getPurchaseOrderLinesStmt                      *sql.Stmt
+	getPurchaseOrdersStmt                          *sql.Stmt
+	getReceiptLinesStmt                            *sql.Stmt
+	getRefundedAmountStmt                          *sql.Stmt
+	getRefundsByTransactionStmt                    *sql.Stmt
+	getResidentGroupByIdStmt                       *sql.Stmt
+	getResidentGroupsStmt                          *sql.Stmt
+	getResidentSpendingStmt                        *sql.Stmt
+	getShiftByIdStmt                               *sql.Stmt
+	getShiftByIdForUpdateStmt                      *sql.Stmt
+	getShiftCashTotalStmt                          *sql.Stmt
+	getShiftCountsStmt                             *sql.Stmt
+	getShiftsStmt                                  *sql.Stmt
+	getSplitSharesStmt                             *sql.Stmt
+	getStockAlertDeliveriesStmt                    *sql.Stmt
+	getStockAlertsStmt                             *sql.Stmt
+	getStockByLocationStmt                         *sql.Stmt
+	getStockMovementsByArticleStmt                 *sql.Stmt
+	getStockTransfersStmt                          *sql.Stmt
+	getStocktakeByIdStmt                           *sql.Stmt
+	getStocktakeByIdForUpdateStmt                  *sql.Stmt
+	getStocktakeLinesStmt                          *sql.Stmt
+	getStocktakesStmt                              *sql.Stmt
+	getSupplierByIdStmt                            *sql.Stmt
+	getSuppliersStmt                               *sql.Stmt
+	getSystemLedgerAccountStmt                     *sql.Stmt
+	getTabByIdStmt                                 *sql.Stmt
+	getTabByIdForUpdateStmt                        *sql.Stmt
+	getTabByTransactionStmt                        *sql.Stmt
+	getTabItemsByTabStmt                           *sql.Stmt
+	getTabsStmt                                    *sql.Stmt
+	getTerminalByIdStmt                            *sql.Stmt
+	getTerminalsStmt                               *sql.Stmt
+	getTransactionByIdStmt                         *sql.Stmt
+	getTransactionByIdForUpdateStmt                *sql.Stmt
+	getTransactionLinesTotalStmt                   *sql.Stmt
+	getTransactionStockLocationStmt                *sql.Stmt
+	getTransactionsStmt                            *sql.Stmt
+	getUnclosedTabByResidentStmt                   *sql.Stmt
+	getUnclosedTabTotalStmt                        *sql.Stmt
+	getUndeliveredWebhooksStmt                     *sql.Stmt
+	getUserByCodeStmt                              *sql.Stmt
+	getUserByIdStmt                                *sql.Stmt
+	getUserByIdForUpdateStmt                       *sql.Stmt
+	getUsersStmt                                   *sql.Stmt
+	getWalletAccountStmt                           *sql.Stmt
+	getWebhookByIdStmt                             *sql.Stmt
+	getWebhooksStmt                                *sql.Stmt
+	hasLocationStockStmt                           *sql.Stmt
+	hasSupplierPurchaseOrdersStmt                  *sql.Stmt
+	isArticleComponentStmt                         *sql.Stmt
+	isPeriodClosedStmt                             *sql.Stmt
+	lockClosingsStmt                               *sql.Stmt
+	lockClosingsForSaleStmt                        *sql.Stmt
+	lockLedgerAccountStmt                          *sql.Stmt
+	markPaymentOutboxProcessedStmt                 *sql.Stmt
+	markStockAlertProcessedStmt                    *sql.Stmt
+	moveArticleStockStmt                           *sql.Stmt
+	moveLocationStockStmt                          *sql.Stmt
+	receiveArticleStockStmt                        *sql.Stmt
+	receivePurchaseOrderLineStmt                   *sql.Stmt
+	recordPaymentOutboxAttemptStmt                 *sql.Stmt
+	recordStockAlertAttemptStmt                    *sql.Stmt
+	requeuePaymentOutboxStmt                       *sql.Stmt
+	restoreArticleStmt                             *sql.Stmt
+	restoreArticleTypeStmt                         *sql.Stmt
+	setDefaultLocationStmt                         *sql.Stmt
+	setLaterPurchasePriceStmt                      *sql.Stmt
+	updateArticleStmt                              *sql.Stmt
+	updateArticlePriceStmt                         *sql.Stmt
+	updateArticleTransactionStmt                   *sql.Stmt
+	updateArticleTypeStmt                          *sql.Stmt
+	updateEventStmt                                *sql.Stmt
+	updateLocationStmt                             *sql.Stmt
+	updatePaymentOutboxAmountStmt                  *sql.Stmt
+	updatePricingRuleStmt                          *sql.Stmt
+	updatePurchaseOrderStatusStmt                  *sql.Stmt
+	updateResidentGroupStmt                        *sql.Stmt
+	updateSupplierStmt                             *sql.Stmt
+	updateTerminalStmt                             *sql.Stmt
+	updateTransactionStmt                          *sql.Stmt
+	updateTransactionStatusStmt                    *sql.Stmt
+	updateUserStmt                                 *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                            tx,
-		tx:                            tx,
-		createArticleStmt:             q.createArticleStmt,
-		createArticleTransactionStmt:  q.createArticleTransactionStmt,
-		createArticleTypeStmt:         q.createArticleTypeStmt,
-		createEventStmt:               q.createEventStmt,
-		createTransactionStmt:         q.createTransactionStmt,
-		createUserStmt:                q.createUserStmt,
-		deleteArticleStmt:             q.deleteArticleStmt,
-		deleteArticleTransactionStmt:  q.deleteArticleTransactionStmt,
-		deleteArticleTypeStmt:         q.deleteArticleTypeStmt,
-		deleteEventStmt:               q.deleteEventStmt,
-		deleteTransactionStmt:         q.deleteTransactionStmt,
-		deleteUserStmt:                q.deleteUserStmt,
-		getArticleByIdStmt:            q.getArticleByIdStmt,
-		getArticleTransactionByIdStmt: q.getArticleTransactionByIdStmt,
-		getArticleTransactionsStmt:    q.getArticleTransactionsStmt,
-		getArticleTransactionsGroupedByArticleStmt: q.getArticleTransactionsGroupedByArticleStmt,
-		getArticleTypeByIdStmt:                     q.getArticleTypeByIdStmt,
-		getArticleTypesStmt:                        q.getArticleTypesStmt,
-		getArticleTypesWithArticlesStmt:            q.getArticleTypesWithArticlesStmt,
-		getArticlesStmt:                            q.getArticlesStmt,
-		getEventByIdStmt:                           q.getEventByIdStmt,
-		getEventsStmt:                              q.getEventsStmt,
-		getTransactionByIdStmt:                     q.getTransactionByIdStmt,
-		getTransactionsStmt:                        q.getTransactionsStmt,
-		getUserByCodeStmt:                          q.getUserByCodeStmt,
-		getUserByIdStmt:                            q.getUserByIdStmt,
-		getUsersStmt:                               q.getUsersStmt,
-		updateArticleStmt:                          q.updateArticleStmt,
-		updateArticleTransactionStmt:               q.updateArticleTransactionStmt,
-		updateArticleTypeStmt:                      q.updateArticleTypeStmt,
-		updateEventStmt:                            q.updateEventStmt,
-		updateTransactionStmt:                      q.updateTransactionStmt,
-		updateUserStmt:                             q.updateUserStmt,
+		db:                                             tx,
+		tx:                                             tx,
+		applyArticlePricesStmt:                         q.applyArticlePricesStmt,
+		archiveArticleStmt:                             q.archiveArticleStmt,
+		archiveArticleTypeStmt:                         q.archiveArticleTypeStmt,
+		clearDefaultLocationStmt:                       q.clearDefaultLocationStmt,
+		closeShiftStmt:                                 q.closeShiftStmt,
+		closeStocktakeStmt:                             q.closeStocktakeStmt,
+		closeTabStmt:                                   q.closeTabStmt,
+		countStocktakeLineStmt:                         q.countStocktakeLineStmt,
+		createArticleStmt:                              q.createArticleStmt,
+		createArticleComponentStmt:                     q.createArticleComponentStmt,
+		createArticlePriceStmt:                         q.createArticlePriceStmt,
+		createArticleTransactionStmt:                   q.createArticleTransactionStmt,
+		createArticleTypeStmt:                          q.createArticleTypeStmt,
+		createClosingStmt:                              q.createClosingStmt,
+		createClosingArticleTypeStmt:                   q.createClosingArticleTypeStmt,
+		createClosingPaymentBackendStmt:                q.createClosingPaymentBackendStmt,
+		createEventStmt:                                q.createEventStmt,
+		createGoodsReceiptStmt:                         q.createGoodsReceiptStmt,
+		createGoodsReceiptLineStmt:                     q.createGoodsReceiptLineStmt,
+		createLedgerEntryStmt:                          q.createLedgerEntryStmt,
+		createLedgerPostingStmt:                        q.createLedgerPostingStmt,
+		createLocationStmt:                             q.createLocationStmt,
+		createPaymentOutboxStmt:                        q.createPaymentOutboxStmt,
+		createPricingRuleStmt:                          q.createPricingRuleStmt,
+		createPurchaseOrderStmt:                        q.createPurchaseOrderStmt,
+		createPurchaseOrderLineStmt:                    q.createPurchaseOrderLineStmt,
+		createRefundArticleTransactionStmt:             q.createRefundArticleTransactionStmt,
+		createRefundTransactionStmt:                    q.createRefundTransactionStmt,
+		createResidentGroupStmt:                        q.createResidentGroupStmt,
+		createShiftStmt:                                q.createShiftStmt,
+		createShiftCountStmt:                           q.createShiftCountStmt,
+		createStockAlertStmt:                           q.createStockAlertStmt,
+		createStockAlertDeliveryStmt:                   q.createStockAlertDeliveryStmt,
+		createStockMovementStmt:                        q.createStockMovementStmt,
+		createStockTransferStmt:                        q.createStockTransferStmt,
+		createStocktakeStmt:                            q.createStocktakeStmt,
+		createStocktakeLinesStmt:                       q.createStocktakeLinesStmt,
+		createSupplierStmt:                             q.createSupplierStmt,
+		createTabStmt:                                  q.createTabStmt,
+		createTabItemStmt:                              q.createTabItemStmt,
+		createTerminalStmt:                             q.createTerminalStmt,
+		createTransactionStmt:                          q.createTransactionStmt,
+		createUserStmt:                                 q.createUserStmt,
+		createWebhookStmt:                              q.createWebhookStmt,
+		createWebhookDeliveryStmt:                      q.createWebhookDeliveryStmt,
+		deleteArticleComponentsStmt:                    q.deleteArticleComponentsStmt,
+		deleteArticlePriceStmt:                         q.deleteArticlePriceStmt,
+		deleteArticleTransactionStmt:                   q.deleteArticleTransactionStmt,
+		deleteArticleTransactionsByTransactionStmt:     q.deleteArticleTransactionsByTransactionStmt,
+		deleteDepositLinesStmt:                         q.deleteDepositLinesStmt,
+		deleteEventStmt:                                q.deleteEventStmt,
+		deleteLocationStmt:                             q.deleteLocationStmt,
+		deletePricingRuleStmt:                          q.deletePricingRuleStmt,
+		deleteResidentGroupStmt:                        q.deleteResidentGroupStmt,
+		deleteSupplierStmt:                             q.deleteSupplierStmt,
+		deleteTerminalStmt:                             q.deleteTerminalStmt,
+		deleteTransactionStmt:                          q.deleteTransactionStmt,
+		deleteUserStmt:                                 q.deleteUserStmt,
+		deleteWebhookStmt:                              q.deleteWebhookStmt,
+		endArticlePriceStmt:                            q.endArticlePriceStmt,
+		extendArticlePriceStmt:                         q.extendArticlePriceStmt,
+		flagTabStmt:                                    q.flagTabStmt,
+		getArticleByIdStmt:                             q.getArticleByIdStmt,
+		getArticleByIdForUpdateStmt:                    q.getArticleByIdForUpdateStmt,
+		getArticleComponentsStmt:                       q.getArticleComponentsStmt,
+		getArticlePriceAtStmt:                          q.getArticlePriceAtStmt,
+		getArticlePriceByIdStmt:                        q.getArticlePriceByIdStmt,
+		getArticlePricesStmt:                           q.getArticlePricesStmt,
+		getArticlePricesAtStmt:                         q.getArticlePricesAtStmt,
+		getArticleStockForUpdateStmt:                   q.getArticleStockForUpdateStmt,
+		getArticleTransactionByIdStmt:                  q.getArticleTransactionByIdStmt,
+		getArticleTransactionsStmt:                     q.getArticleTransactionsStmt,
+		getArticleTransactionsByTransactionStmt:        q.getArticleTransactionsByTransactionStmt,
+		getArticleTransactionsGroupedByArticleStmt:     q.getArticleTransactionsGroupedByArticleStmt,
+		getArticleTransactionsGroupedByPricingRuleStmt: q.getArticleTransactionsGroupedByPricingRuleStmt,
+		getArticleTransactionsOfTransactionsStmt:       q.getArticleTransactionsOfTransactionsStmt,
+		getArticleTypeByIdStmt:                         q.getArticleTypeByIdStmt,
+		getArticleTypesStmt:                            q.getArticleTypesStmt,
+		getArticleTypesWithArticlesStmt:                q.getArticleTypesWithArticlesStmt,
+		getArticlesStmt:                                q.getArticlesStmt,
+		getClosingArticleTotalsStmt:                    q.getClosingArticleTotalsStmt,
+		getClosingArticleTypeTotalsStmt:                q.getClosingArticleTypeTotalsStmt,
+		getClosingArticleTypesStmt:                     q.getClosingArticleTypesStmt,
+		getClosingByIdStmt:                             q.getClosingByIdStmt,
+		getClosingPaymentBackendTotalsStmt:             q.getClosingPaymentBackendTotalsStmt,
+		getClosingPaymentBackendsStmt:                  q.getClosingPaymentBackendsStmt,
+		getClosingTransactionTotalsStmt:                q.getClosingTransactionTotalsStmt,
+		getClosingsStmt:                                q.getClosingsStmt,
+		getComponentLinesStmt:                          q.getComponentLinesStmt,
+		getDefaultLocationStmt:                         q.getDefaultLocationStmt,
+		getDepositLiabilitiesStmt:                      q.getDepositLiabilitiesStmt,
+		getDuePaymentOutboxStmt:                        q.getDuePaymentOutboxStmt,
+		getDueStockAlertsStmt:                          q.getDueStockAlertsStmt,
+		getEventByDateStmt:                             q.getEventByDateStmt,
+		getEventByIdStmt:                               q.getEventByIdStmt,
+		getEventsStmt:                                  q.getEventsStmt,
+		getGoodsReceiptLinesOfOrderStmt:                q.getGoodsReceiptLinesOfOrderStmt,
+		getGoodsReceiptsStmt:                           q.getGoodsReceiptsStmt,
+		getLastClosingStmt:                             q.getLastClosingStmt,
+		getLedgerAccountBalanceStmt:                    q.getLedgerAccountBalanceStmt,
+		getLedgerEntriesByAccountStmt:                  q.getLedgerEntriesByAccountStmt,
+		getLedgerPostingByDetailsStmt:                  q.getLedgerPostingByDetailsStmt,
+		getLocationByIdStmt:                            q.getLocationByIdStmt,
+		getLocationsStmt:                               q.getLocationsStmt,
+		getOpenShiftByTerminalStmt:                     q.getOpenShiftByTerminalStmt,
+		getOpenStocktakeStmt:                           q.getOpenStocktakeStmt,
+		getOpenTabsOfEndedEventsStmt:                   q.getOpenTabsOfEndedEventsStmt,
+		getOrCreateWalletAccountStmt:                   q.getOrCreateWalletAccountStmt,
+		getPaymentOutboxByIdStmt:                       q.getPaymentOutboxByIdStmt,
+		getPaymentOutboxByTransactionStmt:              q.getPaymentOutboxByTransactionStmt,
+		getPaymentOutboxNeedingReconciliationStmt:      q.getPaymentOutboxNeedingReconciliationStmt,
+		getPendingPaymentAmountStmt:                    q.getPendingPaymentAmountStmt,
+		getPricingRuleByIdStmt:                         q.getPricingRuleByIdStmt,
+		getPricingRulesStmt:                            q.getPricingRulesStmt,
+		getPricingRulesForEventStmt:                    q.getPricingRulesForEventStmt,
+		getPurchaseOrderByIdStmt:                       q.getPurchaseOrderByIdStmt,
+		getPurchaseOrderByIdForUpdateStmt:              q.getPurchaseOrderByIdForUpdateStmt,
+		getPurchaseOrderLinesStmt:                      q.getPurchaseOrderLinesStmt,
+		getPurchaseOrdersStmt:                          q.getPurchaseOrdersStmt,
+		getReceiptLinesStmt:                            q.getReceiptLinesStmt,
+		getRefundedAmountStmt:                          q.getRefundedAmountStmt,
+		getRefundsByTransactionStmt:                    q.getRefundsByTransactionStmt,
+		getResidentGroupByIdStmt:                       q.getResidentGroupByIdStmt,
+		getResidentGroupsStmt:                          q.getResidentGroupsStmt,
+		getResidentSpendingStmt:                        q.getResidentSpendingStmt,
+		getShiftByIdStmt:                               q.getShiftByIdStmt,
+		getShiftByIdForUpdateStmt:                      q.getShiftByIdForUpdateStmt,
+		getShiftCashTotalStmt:                          q.getShiftCashTotalStmt,
+		getShiftCountsStmt:                             q.getShiftCountsStmt,
+		getShiftsStmt:                                  q.getShiftsStmt,
+		getSplitSharesStmt:                             q.getSplitSharesStmt,
+		getStockAlertDeliveriesStmt:                    q.getStockAlertDeliveriesStmt,
+		getStockAlertsStmt:                             q.getStockAlertsStmt,
+		getStockByLocationStmt:                         q.getStockByLocationStmt,
+		getStockMovementsByArticleStmt:                 q.getStockMovementsByArticleStmt,
+		getStockTransfersStmt:                          q.getStockTransfersStmt,
+		getStocktakeByIdStmt:                           q.getStocktakeByIdStmt,
+		getStocktakeByIdForUpdateStmt:                  q.getStocktakeByIdForUpdateStmt,
+		getStocktakeLinesStmt:                          q.getStocktakeLinesStmt,
+		getStocktakesStmt:                              q.getStocktakesStmt,
+		getSupplierByIdStmt:                            q.getSupplierByIdStmt,
+		getSuppliersStmt:                               q.getSuppliersStmt,
+		getSystemLedgerAccountStmt:                     q.getSystemLedgerAccountStmt,
+		getTabByIdStmt:                                 q.getTabByIdStmt,
+		getTabByIdForUpdateStmt:                        q.getTabByIdForUpdateStmt,
+		getTabByTransactionStmt:                        q.getTabByTransactionStmt,
+		getTabItemsByTabStmt:                           q.getTabItemsByTabStmt,
+		getTabsStmt:                                    q.getTabsStmt,
+		getTerminalByIdStmt:                            q.getTerminalByIdStmt,
+		getTerminalsStmt:                               q.getTerminalsStmt,
+		getTransactionByIdStmt:                         q.getTransactionByIdStmt,
+		getTransactionByIdForUpdateStmt:                q.getTransactionByIdForUpdateStmt,
+		getTransactionLinesTotalStmt:                   q.getTransactionLinesTotalStmt,
+		getTransactionStockLocationStmt:                q.getTransactionStockLocationStmt,
+		getTransactionsStmt:                            q.getTransactionsStmt,
+		getUnclosedTabByResidentStmt:                   q.getUnclosedTabByResidentStmt,
+		getUnclosedTabTotalStmt:                        q.getUnclosedTabTotalStmt,
+		getUndeliveredWebhooksStmt:                     q.getUndeliveredWebhooksStmt,
+		getUserByCodeStmt:                              q.getUserByCodeStmt,
+		getUserByIdStmt:                                q.getUserByIdStmt,
+		getUserByIdForUpdateStmt:                       q.getUserByIdForUpdateStmt,
+		getUsersStmt:                                   q.getUsersStmt,
+		getWalletAccountStmt:                           q.getWalletAccountStmt,
+		getWebhookByIdStmt:                             q.getWebhookByIdStmt,
+		getWebhooksStmt:                                q.getWebhooksStmt,
+		hasLocationStockStmt:                           q.hasLocationStockStmt,
+		hasSupplierPurchaseOrdersStmt:                  q.hasSupplierPurchaseOrdersStmt,
+		isArticleComponentStmt:                         q.isArticleComponentStmt,
+		isPeriodClosedStmt:                             q.isPeriodClosedStmt,
+		lockClosingsStmt:                               q.lockClosingsStmt,
+		lockClosingsForSaleStmt:                        q.lockClosingsForSaleStmt,
+		lockLedgerAccountStmt:                          q.lockLedgerAccountStmt,
+		markPaymentOutboxProcessedStmt:                 q.markPaymentOutboxProcessedStmt,
+		markStockAlertProcessedStmt:                    q.markStockAlertProcessedStmt,
+		moveArticleStockStmt:                           q.moveArticleStockStmt,
+		moveLocationStockStmt:                          q.moveLocationStockStmt,
+		receiveArticleStockStmt:                        q.receiveArticleStockStmt,
+		receivePurchaseOrderLineStmt:                   q.receivePurchaseOrderLineStmt,
+		recordPaymentOutboxAttemptStmt:                 q.recordPaymentOutboxAttemptStmt,
+		recordStockAlertAttemptStmt:                    q.recordStockAlertAttemptStmt,
+		requeuePaymentOutboxStmt:                       q.requeuePaymentOutboxStmt,
+		restoreArticleStmt:                             q.restoreArticleStmt,
+		restoreArticleTypeStmt:                         q.restoreArticleTypeStmt,
+		setDefaultLocationStmt:                         q.setDefaultLocationStmt,
+		setLaterPurchasePriceStmt:                      q.setLaterPurchasePriceStmt,
+		updateArticleStmt:                              q.updateArticleStmt,
+		updateArticlePriceStmt:                         q.updateArticlePriceStmt,
+		updateArticleTransactionStmt:                   q.updateArticleTransactionStmt,
+		updateArticleTypeStmt:                          q.updateArticleTypeStmt,
+		updateEventStmt:                                q.updateEventStmt,
+		updateLocationStmt:                             q.updateLocationStmt,
+		updatePaymentOutboxAmountStmt:                  q.updatePaymentOutboxAmountStmt,
+		updatePricingRuleStmt:                          q.updatePricingRuleStmt,
+		updatePurchaseOrderStatusStmt:                  q.updatePurchaseOrderStatusStmt,
+		updateResidentGroupStmt:                        q.updateResidentGroupStmt,
+		updateSupplierStmt:                             q.updateSupplierStmt,
+		updateTerminalStmt:                             q.updateTerminalStmt,
+		updateTransactionStmt:                          q.updateTransactionStmt,
+		updateTransactionStatusStmt:                    q.updateTransactionStatusStmt,
+		updateUserStmt:                                 q.updateUserStmt,
 	}
 }
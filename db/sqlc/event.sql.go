@@ -59,6 +59,27 @@ func (q *Queries) DeleteEvent(ctx context.Context, argUuid uuid.UUID) error {
 	return err
 }
 
+const getEventByDate = `-- name: GetEventByDate :one
+SELECT uuid, name, "desc", from_date, to_date FROM event
+WHERE from_date <= $1::timestamp
+AND to_date >= $1::timestamp
+ORDER BY from_date DESC
+LIMIT 1
+`
+
+func (q *Queries) GetEventByDate(ctx context.Context, date time.Time) (Event, error) {
+	row := q.queryRow(ctx, q.getEventByDateStmt, getEventByDate, date)
+	var i Event
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.Desc,
+		&i.FromDate,
+		&i.ToDate,
+	)
+	return i, err
+}
+
 const getEventById = `-- name: GetEventById :one
 SELECT uuid, name, "desc", from_date, to_date FROM event
 WHERE uuid = $1 LIMIT 1
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate"
+	_ "github.com/golang-migrate/migrate/database/postgres"
+	_ "github.com/golang-migrate/migrate/source/file"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// testStore is connected to the database in TEST_DB_SOURCE, the database
+// needs the uuid-ossp extension of init-db.sql
+var testStore *Store
+
+func TestMain(m *testing.M) {
+	source := os.Getenv("TEST_DB_SOURCE")
+	if source != "" {
+		migration, err := migrate.New("file://../migration", source)
+		if err != nil {
+			log.Fatalf("could not create migrate instance: %v", err)
+		}
+		if err := migration.Up(); err != nil && err != migrate.ErrNoChange {
+			log.Fatalf("could not apply migrations: %v", err)
+		}
+
+		conn, err := sql.Open("postgres", source)
+		if err != nil {
+			log.Fatalf("could not connect to the test database: %v", err)
+		}
+		testStore = NewStore(conn)
+	}
+
+	os.Exit(m.Run())
+}
+
+// requireStore skips tests that need a database when none is configured
+func requireStore(t *testing.T) *Store {
+	t.Helper()
+	if testStore == nil {
+		t.Skip("TEST_DB_SOURCE is not set")
+	}
+	return testStore
+}
+
+// createTestArticle writes an article of a new article type, its name is
+// unique so tests do not see each other's rows
+func createTestArticle(t *testing.T, arg CreateArticleParams) Article {
+	t.Helper()
+	ctx := context.Background()
+
+	articleType, err := testStore.CreateArticleType(ctx, CreateArticleTypeParams{Name: "type " + uuid.NewString(), Color: "#000000", VatRate: 19})
+	if err != nil {
+		t.Fatalf("create article type: %v", err)
+	}
+
+	arg.Name = "article " + uuid.NewString()
+	arg.ArticleTypeUuid = articleType.Uuid
+	if arg.StockPolicy == "" {
+		arg.StockPolicy = StockPolicyAllow
+	}
+
+	article, err := testStore.CreateArticle(ctx, arg)
+	if err != nil {
+		t.Fatalf("create article: %v", err)
+	}
+	return article
+}
+
+// getTestArticle reads the article again, e.g. to compare its stock
+func getTestArticle(t *testing.T, articleUuid uuid.UUID) Article {
+	t.Helper()
+	article, err := testStore.GetArticleById(context.Background(), articleUuid)
+	if err != nil {
+		t.Fatalf("get article: %v", err)
+	}
+	return article
+}
@@ -7,25 +7,63 @@ package db
 import (
 	"time"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/google/uuid"
 	null "github.com/guregu/null/v5"
 )
 
 type Article struct {
-	Uuid            uuid.UUID   `json:"uuid"`
-	Name            string      `json:"name"`
-	Desc            null.String `json:"desc"`
-	PurchasePrice   float64     `json:"purchase_price"`
-	ResellPrice     float64     `json:"resell_price"`
-	ArticleTypeUuid uuid.UUID   `json:"article_type_uuid"`
+	Uuid             uuid.UUID   `json:"uuid"`
+	Name             string      `json:"name"`
+	Desc             null.String `json:"desc"`
+	PurchasePrice    util.Money  `json:"purchase_price"`
+	ResellPrice      util.Money  `json:"resell_price"`
+	ArticleTypeUuid  uuid.UUID   `json:"article_type_uuid"`
+	Deposit          util.Money  `json:"deposit"`
+	VatRate          null.Int32  `json:"vat_rate"`
+	Stock            int32       `json:"stock"`
+	StockPolicy      string      `json:"stock_policy"`
+	ReorderThreshold null.Int32  `json:"reorder_threshold"`
+	ArchivedAt       null.Time   `json:"archived_at"`
+}
+
+type ArticleComponent struct {
+	BundleUuid    uuid.UUID `json:"bundle_uuid"`
+	ComponentUuid uuid.UUID `json:"component_uuid"`
+	Amount        int32     `json:"amount"`
+}
+
+type ArticlePrice struct {
+	Uuid          uuid.UUID  `json:"uuid"`
+	ArticleUuid   uuid.UUID  `json:"article_uuid"`
+	PurchasePrice util.Money `json:"purchase_price"`
+	ResellPrice   util.Money `json:"resell_price"`
+	ValidFrom     time.Time  `json:"valid_from"`
+	ValidTo       null.Time  `json:"valid_to"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type ArticleStock struct {
+	ArticleUuid  uuid.UUID `json:"article_uuid"`
+	LocationUuid uuid.UUID `json:"location_uuid"`
+	Stock        int32     `json:"stock"`
 }
 
 type ArticleTransaction struct {
-	Uuid            uuid.UUID `json:"uuid"`
-	ArticleUuid     uuid.UUID `json:"article_uuid"`
-	TransactionUuid uuid.UUID `json:"transaction_uuid"`
-	Amount          int32     `json:"amount"`
-	Price           float64   `json:"price"`
+	Uuid            uuid.UUID     `json:"uuid"`
+	ArticleUuid     uuid.UUID     `json:"article_uuid"`
+	TransactionUuid uuid.UUID     `json:"transaction_uuid"`
+	Amount          int32         `json:"amount"`
+	Price           util.Money    `json:"price"`
+	RefundOf        uuid.NullUUID `json:"refund_of"`
+	PricingRuleUuid uuid.NullUUID `json:"pricing_rule_uuid"`
+	Kind            string        `json:"kind"`
+	BundleOf        uuid.NullUUID `json:"bundle_of"`
+	VatRate         int32         `json:"vat_rate"`
+	Net             util.Money    `json:"net"`
+	Tax             util.Money    `json:"tax"`
+	UnitCost        util.Money    `json:"unit_cost"`
+	DepositOf       uuid.NullUUID `json:"deposit_of"`
 }
 
 type ArticleType struct {
@@ -34,6 +72,37 @@ type ArticleType struct {
 	Desc          null.String `json:"desc"`
 	IconCodepoint int32       `json:"icon_codepoint"`
 	Color         string      `json:"color"`
+	VatRate       int32       `json:"vat_rate"`
+	ArchivedAt    null.Time   `json:"archived_at"`
+}
+
+type Closing struct {
+	Uuid             uuid.UUID  `json:"uuid"`
+	PeriodStart      null.Time  `json:"period_start"`
+	PeriodEnd        time.Time  `json:"period_end"`
+	CreatedAt        time.Time  `json:"created_at"`
+	Transactions     int32      `json:"transactions"`
+	Revenue          util.Money `json:"revenue"`
+	Refunds          int32      `json:"refunds"`
+	RefundTotal      util.Money `json:"refund_total"`
+	DepositsCharged  util.Money `json:"deposits_charged"`
+	DepositsReturned util.Money `json:"deposits_returned"`
+	Pending          int32      `json:"pending"`
+}
+
+type ClosingArticleType struct {
+	ClosingUuid     uuid.UUID  `json:"closing_uuid"`
+	ArticleTypeUuid uuid.UUID  `json:"article_type_uuid"`
+	Name            string     `json:"name"`
+	Amount          int32      `json:"amount"`
+	Revenue         util.Money `json:"revenue"`
+}
+
+type ClosingPaymentBackend struct {
+	ClosingUuid    uuid.UUID  `json:"closing_uuid"`
+	PaymentBackend string     `json:"payment_backend"`
+	Transactions   int32      `json:"transactions"`
+	Total          util.Money `json:"total"`
 }
 
 type Event struct {
@@ -44,13 +113,253 @@ type Event struct {
 	ToDate   time.Time   `json:"to_date"`
 }
 
+type GoodsReceipt struct {
+	Uuid              uuid.UUID   `json:"uuid"`
+	PurchaseOrderUuid uuid.UUID   `json:"purchase_order_uuid"`
+	Note              null.String `json:"note"`
+	ReceivedAt        time.Time   `json:"received_at"`
+}
+
+type GoodsReceiptLine struct {
+	Uuid                  uuid.UUID  `json:"uuid"`
+	GoodsReceiptUuid      uuid.UUID  `json:"goods_receipt_uuid"`
+	PurchaseOrderLineUuid uuid.UUID  `json:"purchase_order_line_uuid"`
+	ArticleUuid           uuid.UUID  `json:"article_uuid"`
+	Quantity              int32      `json:"quantity"`
+	UnitCost              util.Money `json:"unit_cost"`
+}
+
+type LedgerAccount struct {
+	Uuid      uuid.UUID   `json:"uuid"`
+	Name      string      `json:"name"`
+	Kind      string      `json:"kind"`
+	Resident  null.String `json:"resident"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type LedgerEntry struct {
+	Uuid        uuid.UUID  `json:"uuid"`
+	PostingUuid uuid.UUID  `json:"posting_uuid"`
+	AccountUuid uuid.UUID  `json:"account_uuid"`
+	Amount      util.Money `json:"amount"`
+}
+
+type LedgerPosting struct {
+	Uuid      uuid.UUID `json:"uuid"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Location struct {
+	Uuid      uuid.UUID `json:"uuid"`
+	Name      string    `json:"name"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PaymentOutbox struct {
+	Uuid                uuid.UUID   `json:"uuid"`
+	TransactionUuid     uuid.UUID   `json:"transaction_uuid"`
+	Username            null.String `json:"username"`
+	Amount              util.Money  `json:"amount"`
+	Details             string      `json:"details"`
+	Attempts            int32       `json:"attempts"`
+	LastError           null.String `json:"last_error"`
+	NeedsReconciliation bool        `json:"needs_reconciliation"`
+	NextAttemptAt       time.Time   `json:"next_attempt_at"`
+	CreatedAt           time.Time   `json:"created_at"`
+	ProcessedAt         null.Time   `json:"processed_at"`
+	Backend             string      `json:"backend"`
+	Reversal            bool        `json:"reversal"`
+}
+
+type PricingRule struct {
+	Uuid            uuid.UUID      `json:"uuid"`
+	Name            string         `json:"name"`
+	ArticleUuid     uuid.NullUUID  `json:"article_uuid"`
+	ArticleTypeUuid uuid.NullUUID  `json:"article_type_uuid"`
+	EventUuid       uuid.NullUUID  `json:"event_uuid"`
+	Weekdays        null.Int32     `json:"weekdays"`
+	StartsAt        null.Int32     `json:"starts_at"`
+	EndsAt          null.Int32     `json:"ends_at"`
+	FixedPrice      util.NullMoney `json:"fixed_price"`
+	PercentOff      null.Int32     `json:"percent_off"`
+	Priority        int32          `json:"priority"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type PurchaseOrder struct {
+	Uuid         uuid.UUID   `json:"uuid"`
+	SupplierUuid uuid.UUID   `json:"supplier_uuid"`
+	Status       string      `json:"status"`
+	Note         null.String `json:"note"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+type PurchaseOrderLine struct {
+	Uuid              uuid.UUID  `json:"uuid"`
+	PurchaseOrderUuid uuid.UUID  `json:"purchase_order_uuid"`
+	ArticleUuid       uuid.UUID  `json:"article_uuid"`
+	Quantity          int32      `json:"quantity"`
+	UnitCost          util.Money `json:"unit_cost"`
+	Received          int32      `json:"received"`
+}
+
 type Resident struct {
-	Name string `json:"name"`
-	Code string `json:"code"`
+	Name           string         `json:"name"`
+	Code           string         `json:"code"`
+	PaymentBackend null.String    `json:"payment_backend"`
+	GroupName      null.String    `json:"group_name"`
+	MinBalance     util.NullMoney `json:"min_balance"`
+	DailyCap       util.NullMoney `json:"daily_cap"`
+}
+
+type ResidentGroup struct {
+	Name       string         `json:"name"`
+	MinBalance util.NullMoney `json:"min_balance"`
+	DailyCap   util.NullMoney `json:"daily_cap"`
+}
+
+type Shift struct {
+	Uuid         uuid.UUID      `json:"uuid"`
+	Bartender    string         `json:"bartender"`
+	TerminalUuid uuid.NullUUID  `json:"terminal_uuid"`
+	EventUuid    uuid.NullUUID  `json:"event_uuid"`
+	OpeningFloat util.Money     `json:"opening_float"`
+	OpenedAt     time.Time      `json:"opened_at"`
+	ClosedAt     null.Time      `json:"closed_at"`
+	ExpectedCash util.NullMoney `json:"expected_cash"`
+	CountedCash  util.NullMoney `json:"counted_cash"`
+	Difference   util.NullMoney `json:"difference"`
+}
+
+type ShiftCount struct {
+	ShiftUuid    uuid.UUID  `json:"shift_uuid"`
+	Denomination util.Money `json:"denomination"`
+	Count        int32      `json:"count"`
+}
+
+type StockAlert struct {
+	Uuid             uuid.UUID   `json:"uuid"`
+	ArticleUuid      uuid.UUID   `json:"article_uuid"`
+	Stock            int32       `json:"stock"`
+	ReorderThreshold int32       `json:"reorder_threshold"`
+	Attempts         int32       `json:"attempts"`
+	LastError        null.String `json:"last_error"`
+	NextAttemptAt    time.Time   `json:"next_attempt_at"`
+	CreatedAt        time.Time   `json:"created_at"`
+	ProcessedAt      null.Time   `json:"processed_at"`
+}
+
+type StockAlertDelivery struct {
+	AlertUuid   uuid.UUID `json:"alert_uuid"`
+	Channel     string    `json:"channel"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+type StockAlertWebhookDelivery struct {
+	AlertUuid   uuid.UUID `json:"alert_uuid"`
+	WebhookUuid uuid.UUID `json:"webhook_uuid"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+type StockMovement struct {
+	Uuid                   uuid.UUID     `json:"uuid"`
+	ArticleUuid            uuid.UUID     `json:"article_uuid"`
+	Quantity               int32         `json:"quantity"`
+	StockAfter             int32         `json:"stock_after"`
+	Reason                 string        `json:"reason"`
+	ArticleTransactionUuid uuid.NullUUID `json:"article_transaction_uuid"`
+	Note                   null.String   `json:"note"`
+	CreatedAt              time.Time     `json:"created_at"`
+	GoodsReceiptUuid       uuid.NullUUID `json:"goods_receipt_uuid"`
+	StocktakeUuid          uuid.NullUUID `json:"stocktake_uuid"`
+	LocationUuid           uuid.NullUUID `json:"location_uuid"`
+	StockTransferUuid      uuid.NullUUID `json:"stock_transfer_uuid"`
+}
+
+type StockTransfer struct {
+	Uuid             uuid.UUID     `json:"uuid"`
+	ArticleUuid      uuid.UUID     `json:"article_uuid"`
+	FromLocationUuid uuid.NullUUID `json:"from_location_uuid"`
+	ToLocationUuid   uuid.NullUUID `json:"to_location_uuid"`
+	Quantity         int32         `json:"quantity"`
+	Note             null.String   `json:"note"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type Stocktake struct {
+	Uuid         uuid.UUID     `json:"uuid"`
+	Note         null.String   `json:"note"`
+	OpenedAt     time.Time     `json:"opened_at"`
+	ClosedAt     null.Time     `json:"closed_at"`
+	LocationUuid uuid.NullUUID `json:"location_uuid"`
+}
+
+type StocktakeLine struct {
+	StocktakeUuid uuid.UUID  `json:"stocktake_uuid"`
+	ArticleUuid   uuid.UUID  `json:"article_uuid"`
+	Expected      int32      `json:"expected"`
+	Counted       null.Int32 `json:"counted"`
+	UnitCost      util.Money `json:"unit_cost"`
+}
+
+type Supplier struct {
+	Uuid      uuid.UUID   `json:"uuid"`
+	Name      string      `json:"name"`
+	Contact   null.String `json:"contact"`
+	Email     null.String `json:"email"`
+	Phone     null.String `json:"phone"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type Tab struct {
+	Uuid            uuid.UUID     `json:"uuid"`
+	Resident        string        `json:"resident"`
+	TerminalUuid    uuid.NullUUID `json:"terminal_uuid"`
+	EventUuid       uuid.NullUUID `json:"event_uuid"`
+	PaymentBackend  string        `json:"payment_backend"`
+	Status          string        `json:"status"`
+	TransactionUuid uuid.NullUUID `json:"transaction_uuid"`
+	OpenedAt        time.Time     `json:"opened_at"`
+	ClosedAt        null.Time     `json:"closed_at"`
+}
+
+type TabItem struct {
+	Uuid            uuid.UUID     `json:"uuid"`
+	TabUuid         uuid.UUID     `json:"tab_uuid"`
+	ArticleUuid     uuid.UUID     `json:"article_uuid"`
+	Amount          int32         `json:"amount"`
+	Price           util.Money    `json:"price"`
+	CreatedAt       time.Time     `json:"created_at"`
+	PricingRuleUuid uuid.NullUUID `json:"pricing_rule_uuid"`
+	Deposit         util.Money    `json:"deposit"`
+}
+
+type Terminal struct {
+	Uuid           uuid.UUID     `json:"uuid"`
+	Name           string        `json:"name"`
+	PaymentBackend null.String   `json:"payment_backend"`
+	LocationUuid   uuid.NullUUID `json:"location_uuid"`
 }
 
 type Transaction struct {
-	Uuid  uuid.UUID `json:"uuid"`
-	Date  time.Time `json:"date"`
-	Price float64   `json:"price"`
+	Uuid           uuid.UUID      `json:"uuid"`
+	Date           time.Time      `json:"date"`
+	Price          util.Money     `json:"price"`
+	Status         string         `json:"status"`
+	RefundOf       uuid.NullUUID  `json:"refund_of"`
+	Resident       null.String    `json:"resident"`
+	EventUuid      uuid.NullUUID  `json:"event_uuid"`
+	PaymentBackend string         `json:"payment_backend"`
+	TerminalUuid   uuid.NullUUID  `json:"terminal_uuid"`
+	SplitOf        uuid.NullUUID  `json:"split_of"`
+	BalanceAfter   util.NullMoney `json:"balance_after"`
+}
+
+type Webhook struct {
+	Uuid      uuid.UUID   `json:"uuid"`
+	Url       string      `json:"url"`
+	Secret    null.String `json:"secret"`
+	CreatedAt time.Time   `json:"created_at"`
 }
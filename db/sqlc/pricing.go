@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+// ErrArticleArchived is returned when an archived article is sold
+var ErrArticleArchived = errors.New("article is archived")
+
+// barLocation is the time zone of the bar, the weekdays and time windows of
+// the pricing rules are set in it
+var barLocation = time.UTC
+
+// SetBarLocation sets the time zone the pricing rules are evaluated in, it is
+// called once at startup with the configured zone
+func SetBarLocation(loc *time.Location) {
+	barLocation = loc
+}
+
+// PriceList holds the resell prices and pricing rules in effect at a moment.
+// It is the single place where the price of an article is resolved, for sales
+// as well as for the menu and reports.
+type PriceList struct {
+	At        time.Time
+	EventUuid uuid.NullUUID
+	prices    map[uuid.UUID]util.Money
+	rules     []PricingRule
+}
+
+// GetCurrentPriceList loads the price list in effect now. Sales are always
+// priced with it, price lists of other times only serve the menu and reports.
+func (q *Queries) GetCurrentPriceList(ctx context.Context) (PriceList, error) {
+	return q.GetPriceList(ctx, time.Now())
+}
+
+// GetPriceList loads the resell prices of the price history and the rules
+// that apply at the given time, including the ones of the event running then
+func (q *Queries) GetPriceList(ctx context.Context, at time.Time) (PriceList, error) {
+	event, err := q.eventAt(ctx, at)
+	if err != nil {
+		return PriceList{}, err
+	}
+
+	rules, err := q.GetPricingRulesForEvent(ctx, event)
+	if err != nil {
+		return PriceList{}, err
+	}
+
+	prices, err := q.GetArticlePricesAt(ctx, at)
+	if err != nil {
+		return PriceList{}, err
+	}
+
+	list := PriceList{At: at, EventUuid: event, prices: make(map[uuid.UUID]util.Money, len(prices))}
+	for _, price := range prices {
+		list.prices[price.ArticleUuid] = price.ResellPrice
+	}
+	for _, rule := range rules {
+		if rule.ActiveAt(at) {
+			list.rules = append(list.rules, rule)
+		}
+	}
+
+	return list, nil
+}
+
+// Price returns the unit price of the article and the rule it was resolved
+// with. Of all matching rules the one with the highest priority wins, then
+// the most specific one, then the cheapest. Without a matching rule the
+// resell price in effect at the time of the list applies.
+func (list PriceList) Price(article Article) (util.Money, uuid.NullUUID) {
+	resellPrice, ok := list.prices[article.Uuid]
+	if !ok {
+		resellPrice = article.ResellPrice
+	}
+
+	var best *PricingRule
+	var bestPrice util.Money
+
+	for i := range list.rules {
+		rule := &list.rules[i]
+		if !rule.Matches(article) {
+			continue
+		}
+
+		price := rule.Apply(resellPrice)
+		if best == nil || rule.Priority > best.Priority ||
+			rule.Priority == best.Priority && (rule.scopes() > best.scopes() ||
+				rule.scopes() == best.scopes() && price < bestPrice) {
+			best, bestPrice = rule, price
+		}
+	}
+
+	if best == nil {
+		return resellPrice, uuid.NullUUID{}
+	}
+
+	return bestPrice, uuid.NullUUID{UUID: best.Uuid, Valid: true}
+}
+
+// Matches reports whether the article is within the article and type scope
+// of the rule, the event scope is handled by the price list
+func (rule PricingRule) Matches(article Article) bool {
+	if rule.ArticleUuid.Valid && rule.ArticleUuid.UUID != article.Uuid {
+		return false
+	}
+	if rule.ArticleTypeUuid.Valid && rule.ArticleTypeUuid.UUID != article.ArticleTypeUuid {
+		return false
+	}
+	return true
+}
+
+// ActiveAt reports whether the time is within the weekdays and time window of
+// the rule, both are read on the clock of the bar. A window wrapping midnight
+// belongs to the weekday it starts on.
+func (rule PricingRule) ActiveAt(at time.Time) bool {
+	at = at.In(barLocation)
+	minute := int32(at.Hour()*60 + at.Minute())
+	weekday := at.Weekday()
+
+	start, end := int32(0), int32(24*60)
+	if rule.StartsAt.Valid {
+		start = rule.StartsAt.Int32
+	}
+	if rule.EndsAt.Valid {
+		end = rule.EndsAt.Int32
+	}
+
+	if start <= end {
+		if minute < start || minute >= end {
+			return false
+		}
+	} else {
+		switch {
+		case minute >= start:
+		case minute < end:
+			weekday = (weekday + 6) % 7
+		default:
+			return false
+		}
+	}
+
+	return !rule.Weekdays.Valid || rule.Weekdays.Int32&(1<<weekday) != 0
+}
+
+// Apply returns the price of a unit under the rule, discounts are rounded to
+// the nearest cent
+func (rule PricingRule) Apply(price util.Money) util.Money {
+	if rule.FixedPrice.Valid {
+		return rule.FixedPrice.Money
+	}
+	if rule.PercentOff.Valid {
+		return (price*util.Money(100-rule.PercentOff.Int32) + 50) / 100
+	}
+	return price
+}
+
+// scopes is the number of scopes the rule is limited to
+func (rule PricingRule) scopes() int {
+	scopes := 0
+	for _, valid := range []bool{rule.ArticleUuid.Valid, rule.ArticleTypeUuid.Valid, rule.EventUuid.Valid} {
+		if valid {
+			scopes++
+		}
+	}
+	return scopes
+}
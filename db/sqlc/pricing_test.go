@@ -0,0 +1,112 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+func TestPriceListPrice(t *testing.T) {
+	beer := Article{Uuid: uuid.New(), ArticleTypeUuid: uuid.New(), ResellPrice: 300}
+	wine := Article{Uuid: uuid.New(), ArticleTypeUuid: uuid.New(), ResellPrice: 500}
+
+	typeRule := PricingRule{Uuid: uuid.New(), ArticleTypeUuid: uuid.NullUUID{UUID: beer.ArticleTypeUuid, Valid: true}, PercentOff: null.Int32From(50)}
+	articleRule := PricingRule{Uuid: uuid.New(), ArticleUuid: uuid.NullUUID{UUID: beer.Uuid, Valid: true}, FixedPrice: util.NullMoneyFrom(200)}
+	cheaperRule := PricingRule{Uuid: uuid.New(), ArticleUuid: uuid.NullUUID{UUID: beer.Uuid, Valid: true}, FixedPrice: util.NullMoneyFrom(180)}
+	specificRule := PricingRule{Uuid: uuid.New(), ArticleUuid: uuid.NullUUID{UUID: beer.Uuid, Valid: true}, EventUuid: uuid.NullUUID{UUID: uuid.New(), Valid: true}, FixedPrice: util.NullMoneyFrom(200)}
+	priorityRule := PricingRule{Uuid: uuid.New(), FixedPrice: util.NullMoneyFrom(250), Priority: 1}
+
+	tests := []struct {
+		name    string
+		article Article
+		prices  map[uuid.UUID]util.Money
+		rules   []PricingRule
+		price   util.Money
+		rule    uuid.NullUUID
+	}{
+		{name: "resell price of the article", article: beer, price: 300},
+		{name: "price history wins over the article", article: beer, prices: map[uuid.UUID]util.Money{beer.Uuid: 320}, price: 320},
+		{name: "rule of another article", article: wine, rules: []PricingRule{articleRule}, price: 500},
+		{name: "discount on the price history", article: beer, prices: map[uuid.UUID]util.Money{beer.Uuid: 321}, rules: []PricingRule{typeRule}, price: 161, rule: uuid.NullUUID{UUID: typeRule.Uuid, Valid: true}},
+		{name: "most specific rule", article: beer, rules: []PricingRule{typeRule, specificRule}, price: 200, rule: uuid.NullUUID{UUID: specificRule.Uuid, Valid: true}},
+		{name: "cheapest of equally specific rules", article: beer, rules: []PricingRule{articleRule, cheaperRule}, price: 180, rule: uuid.NullUUID{UUID: cheaperRule.Uuid, Valid: true}},
+		{name: "highest priority", article: beer, rules: []PricingRule{articleRule, priorityRule}, price: 250, rule: uuid.NullUUID{UUID: priorityRule.Uuid, Valid: true}},
+	}
+
+	for _, tt := range tests {
+		list := PriceList{prices: tt.prices, rules: tt.rules}
+		price, rule := list.Price(tt.article)
+		if price != tt.price || rule != tt.rule {
+			t.Errorf("%s: got %v with rule %v, want %v with rule %v", tt.name, price, rule, tt.price, tt.rule)
+		}
+	}
+}
+
+func TestPricingRuleActiveAt(t *testing.T) {
+	friday := null.Int32From(1 << time.Friday)
+	happyHour := PricingRule{StartsAt: null.Int32From(17 * 60), EndsAt: null.Int32From(19 * 60)}
+	lateNight := PricingRule{StartsAt: null.Int32From(22 * 60), EndsAt: null.Int32From(2 * 60), Weekdays: friday}
+
+	// 5 January 2024 is a Friday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		rule   PricingRule
+		at     time.Time
+		active bool
+	}{
+		{name: "without limits", rule: PricingRule{}, at: at(5, 3, 0), active: true},
+		{name: "on the weekday", rule: PricingRule{Weekdays: friday}, at: at(5, 12, 0), active: true},
+		{name: "on another weekday", rule: PricingRule{Weekdays: friday}, at: at(6, 12, 0), active: false},
+		{name: "start of the window", rule: happyHour, at: at(5, 17, 0), active: true},
+		{name: "before the window", rule: happyHour, at: at(5, 16, 59), active: false},
+		{name: "end of the window", rule: happyHour, at: at(5, 19, 0), active: false},
+		{name: "window before midnight", rule: lateNight, at: at(5, 23, 30), active: true},
+		{name: "window after midnight", rule: lateNight, at: at(6, 1, 30), active: true},
+		{name: "window after midnight of another weekday", rule: lateNight, at: at(5, 1, 30), active: false},
+		{name: "outside of the wrapping window", rule: lateNight, at: at(5, 12, 0), active: false},
+	}
+
+	for _, tt := range tests {
+		if active := tt.rule.ActiveAt(tt.at); active != tt.active {
+			t.Errorf("%s: ActiveAt(%v) = %v, want %v", tt.name, tt.at, active, tt.active)
+		}
+	}
+}
+
+func TestPricingRuleActiveAtBarLocation(t *testing.T) {
+	SetBarLocation(time.FixedZone("bar", 2*60*60))
+	t.Cleanup(func() { SetBarLocation(time.UTC) })
+
+	saturday := null.Int32From(1 << time.Saturday)
+	happyHour := PricingRule{StartsAt: null.Int32From(17 * 60), EndsAt: null.Int32From(19 * 60)}
+
+	// 5 January 2024 is a Friday, the bar clock is two hours ahead of UTC
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		rule   PricingRule
+		at     time.Time
+		active bool
+	}{
+		{name: "window on the bar clock", rule: happyHour, at: at(5, 15, 30), active: true},
+		{name: "window on the server clock", rule: happyHour, at: at(5, 17, 30), active: false},
+		{name: "weekday on the bar clock", rule: PricingRule{Weekdays: saturday}, at: at(5, 22, 30), active: true},
+		{name: "weekday on the server clock", rule: PricingRule{Weekdays: saturday}, at: at(6, 22, 30), active: false},
+	}
+
+	for _, tt := range tests {
+		if active := tt.rule.ActiveAt(tt.at); active != tt.active {
+			t.Errorf("%s: ActiveAt(%v) = %v, want %v", tt.name, tt.at, active, tt.active)
+		}
+	}
+}
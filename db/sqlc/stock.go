@@ -0,0 +1,239 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// Policies for sales that take the stock of an article below zero
+const (
+	StockPolicyAllow = "allow"
+	StockPolicyWarn  = "warn"
+	StockPolicyBlock = "block"
+)
+
+// Reasons of stock movements
+const (
+	StockMovementSale       = "sale"
+	StockMovementRefund     = "refund"
+	StockMovementDelivery   = "delivery"
+	StockMovementCorrection = "correction"
+	StockMovementStocktake  = "stocktake"
+	StockMovementTransfer   = "transfer"
+)
+
+// StockAlertDebounce is the time after a low stock alert in which the same
+// article raises no further alert, so restocking and selling around the
+// threshold does not flood the purchasing team
+const StockAlertDebounce = time.Hour
+
+// ErrOutOfStock is returned when a sale would take the stock of an article
+// with the block policy below zero
+var ErrOutOfStock = errors.New("article is out of stock")
+
+// StockWarning reports an article with the warn policy that was sold below zero
+type StockWarning struct {
+	ArticleUuid uuid.UUID `json:"article_uuid"`
+	Stock       int32     `json:"stock"`
+}
+
+// IsStockPolicy reports whether the policy is one of the known stock policies
+func IsStockPolicy(policy string) bool {
+	return policy == StockPolicyAllow || policy == StockPolicyWarn || policy == StockPolicyBlock
+}
+
+// MoveStockParams contains the input parameters of a stock movement
+type MoveStockParams struct {
+	ArticleUuid            uuid.UUID
+	Quantity               int32 // negative takes units out of the stock
+	Reason                 string
+	LocationUuid           uuid.NullUUID // optional, defaults to the default location
+	ArticleTransactionUuid uuid.NullUUID
+	StocktakeUuid          uuid.NullUUID
+	Note                   null.String
+}
+
+// MoveStock changes the stock of the article at the location and records
+// the movement. The article row is locked by the update, concurrent sales
+// can not both take the last unit. A movement that takes the stock below the
+// reorder threshold of the article queues a low stock alert.
+func (q *Queries) MoveStock(ctx context.Context, arg MoveStockParams) (StockMovement, error) {
+	stock, err := q.MoveArticleStock(ctx, MoveArticleStockParams{Quantity: arg.Quantity, Uuid: arg.ArticleUuid})
+	if err != nil {
+		return StockMovement{}, err
+	}
+
+	location, _, err := q.moveLocationStock(ctx, arg.ArticleUuid, arg.LocationUuid, arg.Quantity)
+	if err != nil {
+		return StockMovement{}, err
+	}
+
+	// only the movement that crosses the threshold alerts, not every unit sold below it
+	threshold := stock.ReorderThreshold
+	if threshold.Valid && stock.Stock < threshold.Int32 && stock.Stock-arg.Quantity >= threshold.Int32 {
+		err = q.CreateStockAlert(ctx, CreateStockAlertParams{
+			ArticleUuid:      arg.ArticleUuid,
+			Stock:            stock.Stock,
+			ReorderThreshold: threshold.Int32,
+			Since:            time.Now().Add(-StockAlertDebounce),
+		})
+		if err != nil {
+			return StockMovement{}, err
+		}
+	}
+
+	return q.CreateStockMovement(ctx, CreateStockMovementParams{
+		ArticleUuid:            arg.ArticleUuid,
+		Quantity:               arg.Quantity,
+		StockAfter:             stock.Stock,
+		Reason:                 arg.Reason,
+		ArticleTransactionUuid: arg.ArticleTransactionUuid,
+		Note:                   arg.Note,
+		StocktakeUuid:          arg.StocktakeUuid,
+		LocationUuid:           uuid.NullUUID{UUID: location, Valid: true},
+	})
+}
+
+// moveLocationStock changes the stock of the article at the location, or at
+// the default location without one. It returns the location and its stock.
+func (q *Queries) moveLocationStock(ctx context.Context, articleUuid uuid.UUID, location uuid.NullUUID, quantity int32) (uuid.UUID, int32, error) {
+	if !location.Valid {
+		fallback, err := q.GetDefaultLocation(ctx)
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		location = uuid.NullUUID{UUID: fallback.Uuid, Valid: true}
+	}
+
+	stock, err := q.MoveLocationStock(ctx, MoveLocationStockParams{
+		ArticleUuid:  articleUuid,
+		LocationUuid: location.UUID,
+		Quantity:     quantity,
+	})
+	return location.UUID, stock, err
+}
+
+// MoveStockTx changes the stock of the article and records the movement in a single transaction
+func (store *Store) MoveStockTx(ctx context.Context, arg MoveStockParams) (StockMovement, error) {
+	var result StockMovement
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.MoveStock(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// moveLineStock takes the units of an article transaction out of the stock
+// at the location of its terminal, negative amounts put them back. Only sales
+// of plain articles and the component lines of bundles move stock, deposits
+// do not. With enforce a sale that takes the total stock below zero of an
+// article with the block policy fails, articles with the warn policy are
+// returned as a warning.
+func (q *Queries) moveLineStock(ctx context.Context, line ArticleTransaction, reason string, enforce bool) (*StockWarning, error) {
+	switch line.Kind {
+	case ArticleTransactionComponent:
+	case ArticleTransactionSale:
+		components, err := q.GetArticleComponents(ctx, line.ArticleUuid)
+		if err != nil {
+			return nil, err
+		}
+		if len(components) > 0 {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	return q.moveStockOfLine(ctx, line, reason, enforce)
+}
+
+// moveStockOfLine moves the units of an article transaction like
+// moveLineStock, without deciding whether the line moves stock at all
+func (q *Queries) moveStockOfLine(ctx context.Context, line ArticleTransaction, reason string, enforce bool) (*StockWarning, error) {
+	location, err := q.GetTransactionStockLocation(ctx, line.TransactionUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	movement, err := q.MoveStock(ctx, MoveStockParams{
+		ArticleUuid:            line.ArticleUuid,
+		Quantity:               -line.Amount,
+		LocationUuid:           uuid.NullUUID{UUID: location, Valid: true},
+		Reason:                 reason,
+		ArticleTransactionUuid: uuid.NullUUID{UUID: line.Uuid, Valid: true},
+	})
+	if err != nil || line.Amount <= 0 || movement.StockAfter >= 0 {
+		return nil, err
+	}
+
+	article, err := q.GetArticleById(ctx, line.ArticleUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case enforce && article.StockPolicy == StockPolicyBlock:
+		return nil, fmt.Errorf("%w: article %s is short of %d units", ErrOutOfStock, line.ArticleUuid, -movement.StockAfter)
+	case article.StockPolicy == StockPolicyWarn:
+		return &StockWarning{ArticleUuid: line.ArticleUuid, Stock: movement.StockAfter}, nil
+	}
+	return nil, nil
+}
+
+// stockLines returns the lines of a transaction that moved stock: the sales
+// of plain articles and the component lines of bundles. A sale with
+// component lines is a bundle whose components moved the stock, whether the
+// article is a bundle by now does not matter.
+func stockLines(lines []ArticleTransaction) []ArticleTransaction {
+	bundles := make(map[uuid.UUID]bool)
+	for _, line := range lines {
+		if line.BundleOf.Valid {
+			bundles[line.BundleOf.UUID] = true
+		}
+	}
+
+	result := make([]ArticleTransaction, 0, len(lines))
+	for _, line := range lines {
+		if line.Kind == ArticleTransactionComponent || line.Kind == ArticleTransactionSale && !bundles[line.Uuid] {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// checkStock returns ErrOutOfStock if selling the amount would take an
+// article with the block policy below zero, bundles are checked by their
+// components. Nothing is taken out of the stock.
+func (q *Queries) checkStock(ctx context.Context, articleUuid uuid.UUID, amount int32) error {
+	components, err := q.GetArticleComponents(ctx, articleUuid)
+	if err != nil {
+		return err
+	}
+
+	needed := map[uuid.UUID]int32{articleUuid: amount}
+	if len(components) > 0 {
+		needed = make(map[uuid.UUID]int32, len(components))
+		for _, component := range components {
+			needed[component.ComponentUuid] += component.Amount * amount
+		}
+	}
+
+	for article, amount := range needed {
+		row, err := q.GetArticleById(ctx, article)
+		if err != nil {
+			return err
+		}
+		if row.StockPolicy == StockPolicyBlock && row.Stock < amount {
+			return fmt.Errorf("%w: only %d units of article %s are left", ErrOutOfStock, row.Stock, article)
+		}
+	}
+	return nil
+}
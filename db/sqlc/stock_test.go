@@ -0,0 +1,27 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestStockLines(t *testing.T) {
+	plain := ArticleTransaction{Uuid: uuid.New(), Kind: ArticleTransactionSale, Amount: 2}
+	bundle := ArticleTransaction{Uuid: uuid.New(), Kind: ArticleTransactionSale, Amount: 1}
+	component := ArticleTransaction{Uuid: uuid.New(), Kind: ArticleTransactionComponent, Amount: 3, BundleOf: uuid.NullUUID{UUID: bundle.Uuid, Valid: true}}
+	deposit := ArticleTransaction{Uuid: uuid.New(), Kind: ArticleTransactionDeposit, Amount: 2}
+	refund := ArticleTransaction{Uuid: uuid.New(), Kind: ArticleTransactionSale, Amount: -1, RefundOf: uuid.NullUUID{UUID: plain.Uuid, Valid: true}}
+
+	got := stockLines([]ArticleTransaction{plain, bundle, component, deposit, refund})
+
+	want := []uuid.UUID{plain.Uuid, component.Uuid, refund.Uuid}
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(got), len(want))
+	}
+	for i, line := range got {
+		if line.Uuid != want[i] {
+			t.Errorf("line %d: got %s, want %s", i, line.Uuid, want[i])
+		}
+	}
+}
@@ -9,28 +9,113 @@ import (
 	"context"
 	"time"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/google/uuid"
 	null "github.com/guregu/null/v5"
 )
 
+const createRefundTransaction = `-- name: CreateRefundTransaction :one
+INSERT INTO transaction (
+    "date",
+    price,
+    refund_of,
+    resident,
+    event_uuid,
+    payment_backend,
+    terminal_uuid,
+    split_of
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after
+`
+
+type CreateRefundTransactionParams struct {
+	Date           time.Time     `json:"date"`
+	Price          util.Money    `json:"price"`
+	RefundOf       uuid.NullUUID `json:"refund_of"`
+	Resident       null.String   `json:"resident"`
+	EventUuid      uuid.NullUUID `json:"event_uuid"`
+	PaymentBackend string        `json:"payment_backend"`
+	TerminalUuid   uuid.NullUUID `json:"terminal_uuid"`
+	SplitOf        uuid.NullUUID `json:"split_of"`
+}
+
+func (q *Queries) CreateRefundTransaction(ctx context.Context, arg CreateRefundTransactionParams) (Transaction, error) {
+	row := q.queryRow(ctx, q.createRefundTransactionStmt, createRefundTransaction,
+		arg.Date,
+		arg.Price,
+		arg.RefundOf,
+		arg.Resident,
+		arg.EventUuid,
+		arg.PaymentBackend,
+		arg.TerminalUuid,
+		arg.SplitOf,
+	)
+	var i Transaction
+	err := row.Scan(
+		&i.Uuid,
+		&i.Date,
+		&i.Price,
+		&i.Status,
+		&i.RefundOf,
+		&i.Resident,
+		&i.EventUuid,
+		&i.PaymentBackend,
+		&i.TerminalUuid,
+		&i.SplitOf,
+		&i.BalanceAfter,
+	)
+	return i, err
+}
+
 const createTransaction = `-- name: CreateTransaction :one
 INSERT INTO transaction (
     "date",
-    price
+    price,
+    resident,
+    event_uuid,
+    payment_backend,
+    terminal_uuid,
+    split_of
 ) VALUES (
-    $1, $2
-) RETURNING uuid, date, price
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after
 `
 
 type CreateTransactionParams struct {
-	Date  time.Time `json:"date"`
-	Price float64   `json:"price"`
+	Date           time.Time     `json:"date"`
+	Price          util.Money    `json:"price"`
+	Resident       null.String   `json:"resident"`
+	EventUuid      uuid.NullUUID `json:"event_uuid"`
+	PaymentBackend string        `json:"payment_backend"`
+	TerminalUuid   uuid.NullUUID `json:"terminal_uuid"`
+	SplitOf        uuid.NullUUID `json:"split_of"`
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
-	row := q.queryRow(ctx, q.createTransactionStmt, createTransaction, arg.Date, arg.Price)
+	row := q.queryRow(ctx, q.createTransactionStmt, createTransaction,
+		arg.Date,
+		arg.Price,
+		arg.Resident,
+		arg.EventUuid,
+		arg.PaymentBackend,
+		arg.TerminalUuid,
+		arg.SplitOf,
+	)
 	var i Transaction
-	err := row.Scan(&i.Uuid, &i.Date, &i.Price)
+	err := row.Scan(
+		&i.Uuid,
+		&i.Date,
+		&i.Price,
+		&i.Status,
+		&i.RefundOf,
+		&i.Resident,
+		&i.EventUuid,
+		&i.PaymentBackend,
+		&i.TerminalUuid,
+		&i.SplitOf,
+		&i.BalanceAfter,
+	)
 	return i, err
 }
 
@@ -44,24 +129,170 @@ func (q *Queries) DeleteTransaction(ctx context.Context, argUuid uuid.UUID) erro
 	return err
 }
 
+const getRefundsByTransaction = `-- name: GetRefundsByTransaction :many
+SELECT uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after FROM transaction
+WHERE refund_of = $1
+ORDER BY "date"
+`
+
+func (q *Queries) GetRefundsByTransaction(ctx context.Context, refundOf uuid.NullUUID) ([]Transaction, error) {
+	rows, err := q.query(ctx, q.getRefundsByTransactionStmt, getRefundsByTransaction, refundOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.Date,
+			&i.Price,
+			&i.Status,
+			&i.RefundOf,
+			&i.Resident,
+			&i.EventUuid,
+			&i.PaymentBackend,
+			&i.TerminalUuid,
+			&i.SplitOf,
+			&i.BalanceAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getResidentSpending = `-- name: GetResidentSpending :one
+SELECT COALESCE(SUM(price), 0)::numeric AS spent FROM transaction
+WHERE resident = $1
+AND status <> 'failed'
+AND "date" >= $2::timestamp
+`
+
+type GetResidentSpendingParams struct {
+	Resident null.String `json:"resident"`
+	Since    time.Time   `json:"since"`
+}
+
+func (q *Queries) GetResidentSpending(ctx context.Context, arg GetResidentSpendingParams) (util.Money, error) {
+	row := q.queryRow(ctx, q.getResidentSpendingStmt, getResidentSpending, arg.Resident, arg.Since)
+	var spent util.Money
+	err := row.Scan(&spent)
+	return spent, err
+}
+
+const getSplitShares = `-- name: GetSplitShares :many
+SELECT uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after FROM transaction
+WHERE split_of = $1
+ORDER BY "date", uuid
+`
+
+func (q *Queries) GetSplitShares(ctx context.Context, splitOf uuid.NullUUID) ([]Transaction, error) {
+	rows, err := q.query(ctx, q.getSplitSharesStmt, getSplitShares, splitOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transaction{}
+	for rows.Next() {
+		var i Transaction
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.Date,
+			&i.Price,
+			&i.Status,
+			&i.RefundOf,
+			&i.Resident,
+			&i.EventUuid,
+			&i.PaymentBackend,
+			&i.TerminalUuid,
+			&i.SplitOf,
+			&i.BalanceAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTransactionById = `-- name: GetTransactionById :one
-SELECT uuid, date, price FROM transaction
+SELECT uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after FROM transaction
 WHERE uuid = $1 LIMIT 1
 `
 
 func (q *Queries) GetTransactionById(ctx context.Context, argUuid uuid.UUID) (Transaction, error) {
 	row := q.queryRow(ctx, q.getTransactionByIdStmt, getTransactionById, argUuid)
 	var i Transaction
-	err := row.Scan(&i.Uuid, &i.Date, &i.Price)
+	err := row.Scan(
+		&i.Uuid,
+		&i.Date,
+		&i.Price,
+		&i.Status,
+		&i.RefundOf,
+		&i.Resident,
+		&i.EventUuid,
+		&i.PaymentBackend,
+		&i.TerminalUuid,
+		&i.SplitOf,
+		&i.BalanceAfter,
+	)
+	return i, err
+}
+
+const getTransactionByIdForUpdate = `-- name: GetTransactionByIdForUpdate :one
+SELECT uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after FROM transaction
+WHERE uuid = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetTransactionByIdForUpdate(ctx context.Context, argUuid uuid.UUID) (Transaction, error) {
+	row := q.queryRow(ctx, q.getTransactionByIdForUpdateStmt, getTransactionByIdForUpdate, argUuid)
+	var i Transaction
+	err := row.Scan(
+		&i.Uuid,
+		&i.Date,
+		&i.Price,
+		&i.Status,
+		&i.RefundOf,
+		&i.Resident,
+		&i.EventUuid,
+		&i.PaymentBackend,
+		&i.TerminalUuid,
+		&i.SplitOf,
+		&i.BalanceAfter,
+	)
 	return i, err
 }
 
 const getTransactions = `-- name: GetTransactions :many
-SELECT uuid, date, price FROM transaction
+SELECT uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after FROM transaction
+WHERE ($1::varchar IS NULL OR resident = $1)
+AND ($2::uuid IS NULL OR event_uuid = $2)
+ORDER BY "date"
 `
 
-func (q *Queries) GetTransactions(ctx context.Context) ([]Transaction, error) {
-	rows, err := q.query(ctx, q.getTransactionsStmt, getTransactions)
+type GetTransactionsParams struct {
+	Resident  null.String   `json:"resident"`
+	EventUuid uuid.NullUUID `json:"event_uuid"`
+}
+
+func (q *Queries) GetTransactions(ctx context.Context, arg GetTransactionsParams) ([]Transaction, error) {
+	rows, err := q.query(ctx, q.getTransactionsStmt, getTransactions, arg.Resident, arg.EventUuid)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +300,19 @@ func (q *Queries) GetTransactions(ctx context.Context) ([]Transaction, error) {
 	items := []Transaction{}
 	for rows.Next() {
 		var i Transaction
-		if err := rows.Scan(&i.Uuid, &i.Date, &i.Price); err != nil {
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.Date,
+			&i.Price,
+			&i.Status,
+			&i.RefundOf,
+			&i.Resident,
+			&i.EventUuid,
+			&i.PaymentBackend,
+			&i.TerminalUuid,
+			&i.SplitOf,
+			&i.BalanceAfter,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -89,18 +332,64 @@ SET
     "date" = COALESCE($1, "date"),
     price = COALESCE($2, price)
 WHERE uuid = $3
-RETURNING uuid, date, price
+RETURNING uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after
 `
 
 type UpdateTransactionParams struct {
-	Date  null.Time  `json:"date"`
-	Price null.Float `json:"price"`
-	Uuid  uuid.UUID  `json:"uuid"`
+	Date  null.Time      `json:"date"`
+	Price util.NullMoney `json:"price"`
+	Uuid  uuid.UUID      `json:"uuid"`
 }
 
 func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error) {
 	row := q.queryRow(ctx, q.updateTransactionStmt, updateTransaction, arg.Date, arg.Price, arg.Uuid)
 	var i Transaction
-	err := row.Scan(&i.Uuid, &i.Date, &i.Price)
+	err := row.Scan(
+		&i.Uuid,
+		&i.Date,
+		&i.Price,
+		&i.Status,
+		&i.RefundOf,
+		&i.Resident,
+		&i.EventUuid,
+		&i.PaymentBackend,
+		&i.TerminalUuid,
+		&i.SplitOf,
+		&i.BalanceAfter,
+	)
+	return i, err
+}
+
+const updateTransactionStatus = `-- name: UpdateTransactionStatus :one
+UPDATE transaction
+SET
+    status = $1,
+    balance_after = COALESCE($2, balance_after)
+WHERE uuid = $3
+RETURNING uuid, date, price, status, refund_of, resident, event_uuid, payment_backend, terminal_uuid, split_of, balance_after
+`
+
+type UpdateTransactionStatusParams struct {
+	Status       string         `json:"status"`
+	BalanceAfter util.NullMoney `json:"balance_after"`
+	Uuid         uuid.UUID      `json:"uuid"`
+}
+
+func (q *Queries) UpdateTransactionStatus(ctx context.Context, arg UpdateTransactionStatusParams) (Transaction, error) {
+	row := q.queryRow(ctx, q.updateTransactionStatusStmt, updateTransactionStatus, arg.Status, arg.BalanceAfter, arg.Uuid)
+	var i Transaction
+	err := row.Scan(
+		&i.Uuid,
+		&i.Date,
+		&i.Price,
+		&i.Status,
+		&i.RefundOf,
+		&i.Resident,
+		&i.EventUuid,
+		&i.PaymentBackend,
+		&i.TerminalUuid,
+		&i.SplitOf,
+		&i.BalanceAfter,
+	)
 	return i, err
 }
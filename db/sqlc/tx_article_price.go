@@ -0,0 +1,211 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// ErrPriceInPast is returned when a price change is scheduled before now
+var ErrPriceInPast = errors.New("price change is in the past")
+
+// ErrPriceInEffect is returned when a price that already took effect is unscheduled
+var ErrPriceInEffect = errors.New("price is already in effect")
+
+// ArticlePriceChange is a change of the prices of an article from a point in
+// time on, a price that is not set stays as it was then
+type ArticlePriceChange struct {
+	ArticleUuid   uuid.UUID
+	PurchasePrice util.NullMoney
+	ResellPrice   util.NullMoney
+	ValidFrom     time.Time
+}
+
+// recordArticlePrice writes the change into the price history of the
+// article. The price in effect at the time of the change ends there and the
+// new one lasts until the next scheduled change. The purchase price is the
+// cost of the stock on hand, a change of it carries over to the scheduled
+// prices. Changes that are due are applied to the article right away.
+func (q *Queries) recordArticlePrice(ctx context.Context, change ArticlePriceChange, now time.Time) (ArticlePrice, error) {
+	from := change.ValidFrom.Truncate(time.Microsecond)
+
+	current, err := q.GetArticlePriceAt(ctx, GetArticlePriceAtParams{ArticleUuid: change.ArticleUuid, At: from})
+	if err != nil {
+		return ArticlePrice{}, err
+	}
+
+	price := current
+	if change.PurchasePrice.Valid {
+		price.PurchasePrice = change.PurchasePrice.Money
+	}
+	if change.ResellPrice.Valid {
+		price.ResellPrice = change.ResellPrice.Money
+	}
+	if price.PurchasePrice == current.PurchasePrice && price.ResellPrice == current.ResellPrice {
+		return current, nil
+	}
+
+	if current.ValidFrom.Equal(from) {
+		price, err = q.UpdateArticlePrice(ctx, UpdateArticlePriceParams{
+			Uuid:          current.Uuid,
+			PurchasePrice: price.PurchasePrice,
+			ResellPrice:   price.ResellPrice,
+		})
+	} else {
+		err = q.EndArticlePrice(ctx, EndArticlePriceParams{ValidTo: null.TimeFrom(from), Uuid: current.Uuid})
+		if err != nil {
+			return ArticlePrice{}, err
+		}
+
+		price, err = q.CreateArticlePrice(ctx, CreateArticlePriceParams{
+			ArticleUuid:   change.ArticleUuid,
+			PurchasePrice: price.PurchasePrice,
+			ResellPrice:   price.ResellPrice,
+			ValidFrom:     from,
+			ValidTo:       current.ValidTo,
+		})
+	}
+	if err != nil {
+		return ArticlePrice{}, err
+	}
+
+	if price.PurchasePrice != current.PurchasePrice {
+		err = q.SetLaterPurchasePrice(ctx, SetLaterPurchasePriceParams{
+			PurchasePrice: price.PurchasePrice,
+			ArticleUuid:   change.ArticleUuid,
+			ValidFrom:     from,
+		})
+		if err != nil {
+			return ArticlePrice{}, err
+		}
+	}
+
+	if !from.After(now) {
+		_, err = q.ApplyArticlePrices(ctx, ApplyArticlePricesParams{
+			At:          now,
+			ArticleUuid: uuid.NullUUID{UUID: change.ArticleUuid, Valid: true},
+		})
+	}
+
+	return price, err
+}
+
+// CreateArticleTx creates an article and starts its price history at the given time
+func (store *Store) CreateArticleTx(ctx context.Context, arg CreateArticleParams, now time.Time) (Article, error) {
+	var result Article
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.CreateArticle(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateArticlePrice(ctx, CreateArticlePriceParams{
+			ArticleUuid:   result.Uuid,
+			PurchasePrice: result.PurchasePrice,
+			ResellPrice:   result.ResellPrice,
+			ValidFrom:     now.Truncate(time.Microsecond),
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateArticleTx updates an article, changed prices are recorded in the
+// price history and take effect right away
+func (store *Store) UpdateArticleTx(ctx context.Context, arg UpdateArticleParams, now time.Time) (Article, error) {
+	var result Article
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.UpdateArticle(ctx, arg)
+		if err != nil || !arg.PurchasePrice.Valid && !arg.ResellPrice.Valid {
+			return err
+		}
+
+		_, err = q.recordArticlePrice(ctx, ArticlePriceChange{
+			ArticleUuid:   arg.Uuid,
+			PurchasePrice: arg.PurchasePrice,
+			ResellPrice:   arg.ResellPrice,
+			ValidFrom:     now,
+		}, now)
+		return err
+	})
+
+	return result, err
+}
+
+// ScheduleArticlePriceTxParams contains the input parameters of the schedule article price transaction
+type ScheduleArticlePriceTxParams struct {
+	ArticleUuid uuid.UUID
+	ResellPrice util.Money
+	ValidFrom   time.Time
+	Now         time.Time
+}
+
+// ScheduleArticlePriceTx changes the resell price of the article from the
+// given time on, a change scheduled for the same time is replaced. Past
+// prices can not be changed.
+func (store *Store) ScheduleArticlePriceTx(ctx context.Context, arg ScheduleArticlePriceTxParams) (ArticlePrice, error) {
+	var result ArticlePrice
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.GetArticleByIdForUpdate(ctx, arg.ArticleUuid); err != nil {
+			return err
+		}
+
+		if arg.ValidFrom.Before(arg.Now) {
+			return ErrPriceInPast
+		}
+
+		var err error
+		result, err = q.recordArticlePrice(ctx, ArticlePriceChange{
+			ArticleUuid: arg.ArticleUuid,
+			ResellPrice: util.NullMoneyFrom(arg.ResellPrice),
+			ValidFrom:   arg.ValidFrom,
+		}, arg.Now)
+		return err
+	})
+
+	return result, err
+}
+
+// UnscheduleArticlePriceTx removes a scheduled price change of the article,
+// the price before it stays in effect until the next change
+func (store *Store) UnscheduleArticlePriceTx(ctx context.Context, articleUuid uuid.UUID, priceUuid uuid.UUID, now time.Time) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.GetArticleByIdForUpdate(ctx, articleUuid); err != nil {
+			return err
+		}
+
+		price, err := q.GetArticlePriceById(ctx, priceUuid)
+		if err != nil {
+			return err
+		}
+		if price.ArticleUuid != articleUuid {
+			return sql.ErrNoRows
+		}
+
+		if !price.ValidFrom.After(now) {
+			return ErrPriceInEffect
+		}
+
+		err = q.ExtendArticlePrice(ctx, ExtendArticlePriceParams{
+			ValidTo:     price.ValidTo,
+			ArticleUuid: articleUuid,
+			ValidFrom:   price.ValidFrom,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteArticlePrice(ctx, price.Uuid)
+	})
+}
@@ -0,0 +1,423 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrTransactionNotPending is returned when the lines of a transaction
+	// are changed after its payment started, it has to be refunded instead
+	ErrTransactionNotPending = errors.New("transaction is not pending")
+	// ErrNotSaleLine is returned when a deposit, component or refund line is
+	// changed on its own, these lines follow their sale
+	ErrNotSaleLine = errors.New("only sale lines can be changed")
+	// ErrTotalNotPositive is returned when the lines of a sale sum up to zero
+	// or less, its payment would credit the resident
+	ErrTotalNotPositive = errors.New("total has to be positive")
+	// ErrTransactionNotDeletable is returned when a transaction that charged
+	// or may still charge something is deleted, it has to be refunded instead
+	ErrTransactionNotDeletable = errors.New("transaction can not be deleted")
+)
+
+// ArticleTransactionTxResult is an article transaction with the component and
+// deposit lines written with it and the warnings of its stock movement
+type ArticleTransactionTxResult struct {
+	ArticleTransaction
+	Lines         []ArticleTransaction `json:"lines,omitempty"`
+	StockWarnings []StockWarning       `json:"stock_warnings,omitempty"`
+}
+
+// lockPendingTransaction locks a transaction whose lines are about to
+// change. Only sales whose payment was not attempted yet can change, the
+// payment worker would charge the old total otherwise.
+func (q *Queries) lockPendingTransaction(ctx context.Context, transactionUuid uuid.UUID) (Transaction, error) {
+	transaction, err := q.GetTransactionByIdForUpdate(ctx, transactionUuid)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	if transaction.Status != TransactionStatusPending || transaction.RefundOf.Valid || transaction.SplitOf.Valid {
+		return Transaction{}, fmt.Errorf("%w: transaction %s is %s", ErrTransactionNotPending, transaction.Uuid, transaction.Status)
+	}
+
+	payment, err := q.GetPaymentOutboxByTransaction(ctx, transaction.Uuid)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("%w: transaction %s has no queued payment: %v", ErrTransactionNotPending, transaction.Uuid, err)
+	}
+	if payment.ProcessedAt.Valid || payment.Attempts > 0 || payment.NeedsReconciliation {
+		return Transaction{}, fmt.Errorf("%w: the payment of transaction %s already started", ErrTransactionNotPending, transaction.Uuid)
+	}
+
+	return transaction, nil
+}
+
+// lockDeletableTransaction locks a transaction whose lines are about to be
+// deleted. Next to pending ones, failed transactions never charged anything
+// and can lose lines too, charged ones have to be refunded instead.
+func (q *Queries) lockDeletableTransaction(ctx context.Context, transactionUuid uuid.UUID) (Transaction, error) {
+	transaction, err := q.GetTransactionByIdForUpdate(ctx, transactionUuid)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	if transaction.Status != TransactionStatusFailed {
+		return q.lockPendingTransaction(ctx, transactionUuid)
+	}
+
+	if transaction.RefundOf.Valid || transaction.SplitOf.Valid {
+		return Transaction{}, fmt.Errorf("%w: transaction %s is not a sale", ErrTransactionNotPending, transaction.Uuid)
+	}
+
+	payment, err := q.GetPaymentOutboxByTransaction(ctx, transaction.Uuid)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("%w: transaction %s has no payment: %v", ErrTransactionNotPending, transaction.Uuid, err)
+	}
+	if payment.NeedsReconciliation {
+		return Transaction{}, fmt.Errorf("%w: the payment of transaction %s needs a reconciliation", ErrTransactionNotPending, transaction.Uuid)
+	}
+
+	return transaction, nil
+}
+
+// repriceTransaction sets the price of the transaction and the amount of its
+// payment to the sum of its lines. The payment of a failed transaction is
+// updated too, a requeue charges the new total. Unless credit is nil, the
+// credit of the resident is checked against the amount the total grows by,
+// the queued payment and the spending of the day hold the old total already.
+func (q *Queries) repriceTransaction(ctx context.Context, transactionUuid uuid.UUID, credit *CheckCreditParams) error {
+	total, err := q.GetTransactionLinesTotal(ctx, transactionUuid)
+	if err != nil {
+		return err
+	}
+
+	if total <= 0 {
+		return fmt.Errorf("%w: transaction %s would cost %s", ErrTotalNotPositive, transactionUuid, total)
+	}
+
+	if credit != nil {
+		transaction, err := q.GetTransactionById(ctx, transactionUuid)
+		if err != nil {
+			return err
+		}
+
+		if total > transaction.Price {
+			check := *credit
+			check.Amount = total - transaction.Price
+			check.Date = transaction.Date
+			if err := q.CheckCredit(ctx, check); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = q.UpdateTransaction(ctx, UpdateTransactionParams{Uuid: transactionUuid, Price: util.NullMoneyFrom(total)})
+	if err != nil {
+		return err
+	}
+
+	return q.UpdatePaymentOutboxAmount(ctx, UpdatePaymentOutboxAmountParams{TransactionUuid: transactionUuid, Amount: -total})
+}
+
+// UpdateTransactionTx changes the date of a pending transaction, its price
+// always is the sum of its lines
+func (store *Store) UpdateTransactionTx(ctx context.Context, arg UpdateTransactionParams) (Transaction, error) {
+	var result Transaction
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.lockPendingTransaction(ctx, arg.Uuid); err != nil {
+			return err
+		}
+
+		if err := q.repriceTransaction(ctx, arg.Uuid, nil); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = q.UpdateTransaction(ctx, UpdateTransactionParams{Uuid: arg.Uuid, Date: arg.Date})
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteTransactionTx deletes a failed transaction together with its lines
+// and its payment. Split bills and the transactions of tabs stay, their
+// shares and items refer to them. The units of a failed transaction are back
+// in the stock already.
+func (store *Store) DeleteTransactionTx(ctx context.Context, transactionUuid uuid.UUID) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		transaction, err := q.lockDeletableTransaction(ctx, transactionUuid)
+		if err != nil {
+			return err
+		}
+
+		// deleting would lose the SavaPage adjustment, only failed payments may go
+		if transaction.Status != TransactionStatusFailed || transaction.PaymentBackend == PaymentBackendSplit {
+			return fmt.Errorf("%w: transaction %s is %s", ErrTransactionNotDeletable, transaction.Uuid, transaction.Status)
+		}
+
+		tab, err := q.GetTabByTransaction(ctx, uuid.NullUUID{UUID: transaction.Uuid, Valid: true})
+		if err == nil {
+			return fmt.Errorf("%w: transaction %s settles tab %s", ErrTransactionNotDeletable, transaction.Uuid, tab.Uuid)
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		if err := q.lockPeriodOpen(ctx, transaction.Date); err != nil {
+			return err
+		}
+
+		if err := q.DeleteArticleTransactionsByTransaction(ctx, transaction.Uuid); err != nil {
+			return err
+		}
+
+		return q.DeleteTransaction(ctx, transaction.Uuid)
+	})
+}
+
+// CreateArticleTransactionTx adds a sale line with its component and deposit
+// lines to a pending transaction, takes its units out of the stock and
+// updates the total of the transaction. The credit of the resident is checked
+// against the new total unless it is nil.
+func (store *Store) CreateArticleTransactionTx(ctx context.Context, transactionUuid uuid.UUID, line CheckoutLine, credit *CheckCreditParams) (ArticleTransactionTxResult, error) {
+	var result ArticleTransactionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.lockPendingTransaction(ctx, transactionUuid); err != nil {
+			return err
+		}
+
+		net, tax := SplitVat(line.Price.Mul(line.Amount), line.VatRate)
+
+		var err error
+		result.ArticleTransaction, err = q.CreateArticleTransaction(ctx, CreateArticleTransactionParams{
+			ArticleUuid:     line.ArticleUuid,
+			TransactionUuid: transactionUuid,
+			Amount:          line.Amount,
+			Price:           line.Price,
+			PricingRuleUuid: line.PricingRuleUuid,
+			Kind:            ArticleTransactionSale,
+			VatRate:         line.VatRate,
+			Net:             net,
+			Tax:             tax,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := result.moveStock(ctx, q, StockMovementSale); err != nil {
+			return err
+		}
+
+		if err := result.createComponents(ctx, q, StockMovementSale); err != nil {
+			return err
+		}
+
+		if err := result.createDeposit(ctx, q, line); err != nil {
+			return err
+		}
+
+		return q.repriceTransaction(ctx, transactionUuid, credit)
+	})
+
+	return result, err
+}
+
+// UpdateArticleTransactionTx replaces a sale line of a pending transaction
+// with the newly priced line, it may move to another pending transaction. The
+// stock of the old line is put back as a correction and the stock of the
+// updated line is taken as a sale, its component and deposit lines are
+// written again.
+// The totals of both transactions are updated, the credit of the resident is
+// checked against the new total of the target transaction unless it is nil.
+func (store *Store) UpdateArticleTransactionTx(ctx context.Context, articleTransactionUuid uuid.UUID, transactionUuid uuid.UUID, line CheckoutLine, credit *CheckCreditParams) (ArticleTransactionTxResult, error) {
+	var result ArticleTransactionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		existing, err := q.GetArticleTransactionById(ctx, articleTransactionUuid)
+		if err != nil {
+			return err
+		}
+
+		if existing.Kind != ArticleTransactionSale {
+			return fmt.Errorf("%w: line %s is a %s line", ErrNotSaleLine, existing.Uuid, existing.Kind)
+		}
+
+		transaction, err := q.lockPendingTransaction(ctx, existing.TransactionUuid)
+		if err != nil {
+			return err
+		}
+		if transactionUuid != existing.TransactionUuid {
+			if _, err := q.lockPendingTransaction(ctx, transactionUuid); err != nil {
+				return err
+			}
+		}
+
+		if err := q.deleteDepositLine(ctx, existing); err != nil {
+			return err
+		}
+
+		if err := q.putBackStock(ctx, transaction, existing); err != nil {
+			return err
+		}
+
+		net, tax := SplitVat(line.Price.Mul(line.Amount), line.VatRate)
+		result.ArticleTransaction, err = q.UpdateArticleTransaction(ctx, UpdateArticleTransactionParams{
+			Uuid:            articleTransactionUuid,
+			ArticleUuid:     line.ArticleUuid,
+			TransactionUuid: transactionUuid,
+			Amount:          line.Amount,
+			Price:           line.Price,
+			PricingRuleUuid: line.PricingRuleUuid,
+			VatRate:         line.VatRate,
+			Net:             net,
+			Tax:             tax,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := result.moveStock(ctx, q, StockMovementSale); err != nil {
+			return err
+		}
+
+		if err := result.createComponents(ctx, q, StockMovementSale); err != nil {
+			return err
+		}
+
+		if err := result.createDeposit(ctx, q, line); err != nil {
+			return err
+		}
+
+		if transactionUuid == existing.TransactionUuid {
+			return q.repriceTransaction(ctx, transactionUuid, credit)
+		}
+		if err := q.repriceTransaction(ctx, existing.TransactionUuid, nil); err != nil {
+			return err
+		}
+		return q.repriceTransaction(ctx, transactionUuid, credit)
+	})
+
+	return result, err
+}
+
+// DeleteArticleTransactionTx deletes a sale line of a pending or failed
+// transaction together with its component and deposit lines, puts their units
+// back into the stock unless the transaction failed and updates the total of
+// the transaction
+func (store *Store) DeleteArticleTransactionTx(ctx context.Context, articleTransactionUuid uuid.UUID) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		existing, err := q.GetArticleTransactionById(ctx, articleTransactionUuid)
+		if err != nil {
+			return err
+		}
+
+		if existing.Kind != ArticleTransactionSale {
+			return fmt.Errorf("%w: line %s is a %s line", ErrNotSaleLine, existing.Uuid, existing.Kind)
+		}
+
+		transaction, err := q.lockDeletableTransaction(ctx, existing.TransactionUuid)
+		if err != nil {
+			return err
+		}
+
+		if err := q.putBackStock(ctx, transaction, existing); err != nil {
+			return err
+		}
+
+		if err := q.deleteDepositLine(ctx, existing); err != nil {
+			return err
+		}
+
+		if err := q.DeleteArticleTransaction(ctx, articleTransactionUuid); err != nil {
+			return err
+		}
+
+		return q.repriceTransaction(ctx, existing.TransactionUuid, nil)
+	})
+}
+
+// putBackStock puts the units of a sale line back into the stock and deletes
+// its component lines. The units of a failed transaction are back already.
+func (q *Queries) putBackStock(ctx context.Context, transaction Transaction, sale ArticleTransaction) error {
+	components, err := q.GetComponentLines(ctx, uuid.NullUUID{UUID: sale.Uuid, Valid: true})
+	if err != nil {
+		return err
+	}
+
+	if transaction.Status != TransactionStatusFailed {
+		for _, line := range stockLines(append(components, sale)) {
+			line.Amount = -line.Amount
+			if _, err := q.moveStockOfLine(ctx, line, StockMovementCorrection, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, component := range components {
+		if err := q.DeleteArticleTransaction(ctx, component.Uuid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteDepositLine deletes the deposit charged for a sale line
+func (q *Queries) deleteDepositLine(ctx context.Context, sale ArticleTransaction) error {
+	return q.DeleteDepositLines(ctx, uuid.NullUUID{UUID: sale.Uuid, Valid: true})
+}
+
+// createDeposit writes the deposit of the line next to the article transaction
+func (result *ArticleTransactionTxResult) createDeposit(ctx context.Context, q *Queries, line CheckoutLine) error {
+	if line.Deposit == 0 {
+		return nil
+	}
+
+	deposit, err := q.createDepositLine(ctx, result.ArticleTransaction, line)
+	if err != nil {
+		return err
+	}
+	result.Lines = append(result.Lines, deposit)
+	return nil
+}
+
+// createComponents writes the component lines of a sold bundle and takes
+// their units out of the stock
+func (result *ArticleTransactionTxResult) createComponents(ctx context.Context, q *Queries, reason string) error {
+	components, err := q.createComponentLines(ctx, result.ArticleTransaction)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range components {
+		result.Lines = append(result.Lines, component)
+
+		warning, err := q.moveLineStock(ctx, component, reason, true)
+		if warning != nil {
+			result.StockWarnings = append(result.StockWarnings, *warning)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moveStock takes the units of the article transaction out of the stock and
+// keeps the warning
+func (result *ArticleTransactionTxResult) moveStock(ctx context.Context, q *Queries, reason string) error {
+	warning, err := q.moveLineStock(ctx, result.ArticleTransaction, reason, true)
+	if warning != nil {
+		result.StockWarnings = append(result.StockWarnings, *warning)
+	}
+	return err
+}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeleteTransactionTxFailedCheckout(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 250, Deposit: 50})
+	stock := getTestArticle(t, article.Uuid).Stock
+
+	checkout, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		PaymentBackend: "cash",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{{ArticleUuid: article.Uuid, Amount: 2, Price: 250, Deposit: 50, VatRate: 19}},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	payment, err := store.GetPaymentOutboxByTransaction(ctx, checkout.Transaction.Uuid)
+	if err != nil {
+		t.Fatalf("get payment: %v", err)
+	}
+
+	_, err = store.CompletePaymentTx(ctx, CompletePaymentTxParams{
+		OutboxUuid:      payment.Uuid,
+		TransactionUuid: checkout.Transaction.Uuid,
+		Status:          TransactionStatusFailed,
+	})
+	if err != nil {
+		t.Fatalf("fail payment: %v", err)
+	}
+
+	if err := store.DeleteTransactionTx(ctx, checkout.Transaction.Uuid); err != nil {
+		t.Fatalf("delete failed checkout: %v", err)
+	}
+
+	if _, err := store.GetTransactionById(ctx, checkout.Transaction.Uuid); err != sql.ErrNoRows {
+		t.Errorf("transaction still exists: %v", err)
+	}
+	lines, err := store.GetArticleTransactionsByTransaction(ctx, checkout.Transaction.Uuid)
+	if err != nil {
+		t.Fatalf("get lines: %v", err)
+	}
+	if len(lines) != 0 {
+		t.Errorf("got %d lines left, want none", len(lines))
+	}
+	if got := getTestArticle(t, article.Uuid).Stock; got != stock {
+		t.Errorf("stock is %d, want %d", got, stock)
+	}
+}
+
+func TestDeleteTransactionTxPendingCheckout(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 250})
+
+	checkout, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		PaymentBackend: "cash",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{{ArticleUuid: article.Uuid, Amount: 1, Price: 250, VatRate: 19}},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	err = store.DeleteTransactionTx(ctx, checkout.Transaction.Uuid)
+	if !errors.Is(err, ErrTransactionNotDeletable) {
+		t.Errorf("got %v, want %v", err, ErrTransactionNotDeletable)
+	}
+
+	if _, err := store.GetTransactionById(ctx, checkout.Transaction.Uuid); err != nil {
+		t.Errorf("pending transaction is gone: %v", err)
+	}
+}
+
+func TestDeleteArticleTransactionTxDeletesLinkedDeposit(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 250, Deposit: 50})
+	line := CheckoutLine{ArticleUuid: article.Uuid, Amount: 1, Price: 250, Deposit: 50, VatRate: 19}
+
+	checkout, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		PaymentBackend: "cash",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{line, line},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	var sales, deposits []ArticleTransaction
+	for _, l := range checkout.ArticleTransactions {
+		switch l.Kind {
+		case ArticleTransactionSale:
+			sales = append(sales, l)
+		case ArticleTransactionDeposit:
+			deposits = append(deposits, l)
+		}
+	}
+	if len(sales) != 2 || len(deposits) != 2 {
+		t.Fatalf("got %d sale and %d deposit lines, want 2 each", len(sales), len(deposits))
+	}
+
+	if err := store.DeleteArticleTransactionTx(ctx, sales[1].Uuid); err != nil {
+		t.Fatalf("delete line: %v", err)
+	}
+
+	lines, err := store.GetArticleTransactionsByTransaction(ctx, checkout.Transaction.Uuid)
+	if err != nil {
+		t.Fatalf("get lines: %v", err)
+	}
+	for _, l := range lines {
+		if l.Kind != ArticleTransactionDeposit {
+			continue
+		}
+		if l.DepositOf.UUID != sales[0].Uuid {
+			t.Errorf("deposit line %s belongs to %s, want %s", l.Uuid, l.DepositOf.UUID, sales[0].Uuid)
+		}
+	}
+	if len(lines) != 2 {
+		t.Errorf("got %d lines left, want the remaining sale and its deposit", len(lines))
+	}
+}
+
+func TestUpdateArticleTransactionTxStockReasons(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 250})
+	line := CheckoutLine{ArticleUuid: article.Uuid, Amount: 1, Price: 250, VatRate: 19}
+
+	checkout, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		PaymentBackend: "cash",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{line},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	line.Amount = 2
+	updated, err := store.UpdateArticleTransactionTx(ctx, checkout.ArticleTransactions[0].Uuid, checkout.Transaction.Uuid, line, nil)
+	if err != nil {
+		t.Fatalf("update line: %v", err)
+	}
+
+	movements, err := store.GetStockMovementsByArticle(ctx, article.Uuid)
+	if err != nil {
+		t.Fatalf("get stock movements: %v", err)
+	}
+
+	quantities := map[string]int32{}
+	for _, movement := range movements {
+		if movement.ArticleTransactionUuid.UUID == updated.Uuid {
+			quantities[movement.Reason] += movement.Quantity
+		}
+	}
+	if got := quantities[StockMovementCorrection]; got != 1 {
+		t.Errorf("corrections put back %d units, want 1", got)
+	}
+	if got := quantities[StockMovementSale]; got != -3 {
+		t.Errorf("sales took %d units, want -3", got)
+	}
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// Kinds of article transactions, deposits are liabilities and no revenue,
+// components count the units of sold bundles
+const (
+	ArticleTransactionSale          = "sale"
+	ArticleTransactionDeposit       = "deposit"
+	ArticleTransactionDepositReturn = "deposit_return"
+	ArticleTransactionComponent     = "component"
+)
+
+// CheckoutLine is a single cart position with its server side unit price and
+// the pricing rule the price was resolved with. The deposit per unit is
+// written as a separate line. Lines without a kind are sales. Prices
+// include the VAT at the rate of the line.
+type CheckoutLine struct {
+	ArticleUuid     uuid.UUID
+	Amount          int32
+	Price           util.Money
+	PricingRuleUuid uuid.NullUUID
+	Deposit         util.Money
+	Kind            string
+	VatRate         int32
+}
+
+// Total is the price of the line including its deposit
+func (line CheckoutLine) Total() util.Money {
+	return (line.Price + line.Deposit).Mul(line.Amount)
+}
+
+// checkoutTotal sums up the totals of the lines
+func checkoutTotal(lines []CheckoutLine) util.Money {
+	var total util.Money
+	for _, line := range lines {
+		total += line.Total()
+	}
+	return total
+}
+
+// CheckoutTxParams contains the input parameters of the checkout transaction
+type CheckoutTxParams struct {
+	Resident       null.String // empty for guests paying cash
+	TerminalUuid   uuid.NullUUID
+	PaymentBackend string
+	EventUuid      uuid.NullUUID // optional, defaults to the event running at the date
+	Date           time.Time
+	Lines          []CheckoutLine
+	// AllowOutOfStock sells articles with the block policy below zero, tabs
+	// check the stock when their items are added
+	AllowOutOfStock bool
+	// Credit is checked against the total of the lines, nil for guests and
+	// sales that were checked before
+	Credit *CheckCreditParams
+}
+
+// CheckoutTxResult is the result of the checkout transaction
+type CheckoutTxResult struct {
+	Transaction         Transaction          `json:"transaction"`
+	ArticleTransactions []ArticleTransaction `json:"article_transactions"`
+	Vat                 []VatBreakdown       `json:"vat"`
+	StockWarnings       []StockWarning       `json:"stock_warnings,omitempty"`
+}
+
+// CheckoutTx creates a pending transaction together with all of its article
+// transactions and queues the debit in the payment outbox. The transaction is
+// linked to the resident and to the event running at its date. Either every row is
+// written or nothing is, the payment worker charges the user afterwards.
+func (store *Store) CheckoutTx(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error) {
+	var result CheckoutTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.checkout(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// checkout writes the rows of a checkout, it has to run inside a transaction
+func (q *Queries) checkout(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error) {
+	if arg.Credit != nil {
+		credit := *arg.Credit
+		credit.Amount = checkoutTotal(arg.Lines)
+		if err := q.CheckCredit(ctx, credit); err != nil {
+			return CheckoutTxResult{}, err
+		}
+	}
+
+	result, err := q.createTransactionWithLines(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	_, err = q.CreatePaymentOutbox(ctx, CreatePaymentOutboxParams{
+		TransactionUuid: result.Transaction.Uuid,
+		Username:        arg.Resident,
+		Amount:          -result.Transaction.Price,
+		Details:         PaymentDetails(result.Transaction.Uuid),
+		Backend:         arg.PaymentBackend,
+	})
+	return result, err
+}
+
+// createTransactionWithLines writes a transaction priced at the sum of its
+// lines together with its article transactions, without queueing a payment.
+// The sold units are taken out of the stock.
+func (q *Queries) createTransactionWithLines(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error) {
+	var result CheckoutTxResult
+
+	total := checkoutTotal(arg.Lines)
+
+	if err := q.lockPeriodOpen(ctx, arg.Date); err != nil {
+		return result, err
+	}
+
+	var err error
+	event := arg.EventUuid
+	if !event.Valid {
+		if event, err = q.eventAt(ctx, arg.Date); err != nil {
+			return result, err
+		}
+	}
+
+	result.Transaction, err = q.CreateTransaction(ctx, CreateTransactionParams{
+		Date:           arg.Date,
+		Price:          total,
+		Resident:       arg.Resident,
+		EventUuid:      event,
+		PaymentBackend: arg.PaymentBackend,
+		TerminalUuid:   arg.TerminalUuid,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.ArticleTransactions = make([]ArticleTransaction, 0, len(arg.Lines))
+	for _, line := range arg.Lines {
+		kind := line.Kind
+		if kind == "" {
+			kind = ArticleTransactionSale
+		}
+
+		net, tax := SplitVat(line.Price.Mul(line.Amount), line.VatRate)
+		articleTransaction, err := q.CreateArticleTransaction(ctx, CreateArticleTransactionParams{
+			ArticleUuid:     line.ArticleUuid,
+			TransactionUuid: result.Transaction.Uuid,
+			Amount:          line.Amount,
+			Price:           line.Price,
+			PricingRuleUuid: line.PricingRuleUuid,
+			Kind:            kind,
+			VatRate:         line.VatRate,
+			Net:             net,
+			Tax:             tax,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.ArticleTransactions = append(result.ArticleTransactions, articleTransaction)
+		stockLines := []ArticleTransaction{articleTransaction}
+
+		if kind == ArticleTransactionSale {
+			components, err := q.createComponentLines(ctx, articleTransaction)
+			if err != nil {
+				return result, err
+			}
+			result.ArticleTransactions = append(result.ArticleTransactions, components...)
+			stockLines = append(stockLines, components...)
+		}
+
+		for _, stockLine := range stockLines {
+			warning, err := q.moveLineStock(ctx, stockLine, StockMovementSale, !arg.AllowOutOfStock)
+			if err != nil {
+				return result, err
+			}
+			if warning != nil {
+				result.StockWarnings = append(result.StockWarnings, *warning)
+			}
+		}
+
+		if line.Deposit == 0 {
+			continue
+		}
+
+		deposit, err := q.createDepositLine(ctx, articleTransaction, line)
+		if err != nil {
+			return result, err
+		}
+		result.ArticleTransactions = append(result.ArticleTransactions, deposit)
+	}
+	result.Vat = GetVatBreakdown(result.ArticleTransactions)
+
+	return result, nil
+}
+
+// createDepositLine writes the deposit charged for the units of a sale line,
+// the deposit line is linked to the sale line it belongs to
+func (q *Queries) createDepositLine(ctx context.Context, sale ArticleTransaction, line CheckoutLine) (ArticleTransaction, error) {
+	net, tax := SplitVat(line.Deposit.Mul(line.Amount), line.VatRate)
+	return q.CreateArticleTransaction(ctx, CreateArticleTransactionParams{
+		ArticleUuid:     line.ArticleUuid,
+		TransactionUuid: sale.TransactionUuid,
+		Amount:          line.Amount,
+		Price:           line.Deposit,
+		Kind:            ArticleTransactionDeposit,
+		VatRate:         line.VatRate,
+		Net:             net,
+		Tax:             tax,
+		DepositOf:       uuid.NullUUID{UUID: sale.Uuid, Valid: true},
+	})
+}
+
+// eventAt returns the event running at the given date, if there is one
+func (q *Queries) eventAt(ctx context.Context, date time.Time) (uuid.NullUUID, error) {
+	event, err := q.GetEventByDate(ctx, date)
+	if err == sql.ErrNoRows {
+		return uuid.NullUUID{}, nil
+	}
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: event.Uuid, Valid: true}, nil
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// ErrPeriodClosed is returned for changes dated inside a closed business day
+var ErrPeriodClosed = errors.New("period is closed")
+
+// ErrTransactionsPending is returned when a business day is closed while
+// payments of its transactions are still open
+var ErrTransactionsPending = errors.New("transactions are pending")
+
+// ClosingReport is a closing with its totals per article type and per payment backend
+type ClosingReport struct {
+	Closing
+	ArticleTypes    []ClosingArticleType    `json:"article_types"`
+	PaymentBackends []ClosingPaymentBackend `json:"payment_backends"`
+}
+
+// CloseDayTx closes the business day from the end of the last closing up to
+// the given time. The report counts charged transactions only, refunds are
+// counted on the day they were made. The day can not be closed while
+// transactions in it are pending, they would be charged after their day was
+// reported and never show up in a report. Closings are serialized, so two
+// closings can never overlap.
+func (store *Store) CloseDayTx(ctx context.Context, until time.Time) (ClosingReport, error) {
+	var result ClosingReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.LockClosings(ctx); err != nil {
+			return err
+		}
+
+		var start null.Time
+		last, err := q.GetLastClosing(ctx)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return err
+		case !until.After(last.PeriodEnd):
+			return ErrPeriodClosed
+		default:
+			start = null.TimeFrom(last.PeriodEnd)
+		}
+
+		transactions, err := q.GetClosingTransactionTotals(ctx, GetClosingTransactionTotalsParams{PeriodStart: start, PeriodEnd: until})
+		if err != nil {
+			return err
+		}
+
+		if transactions.Pending > 0 {
+			return fmt.Errorf("%w: %d transactions of the period are not charged or failed yet", ErrTransactionsPending, transactions.Pending)
+		}
+
+		articles, err := q.GetClosingArticleTotals(ctx, GetClosingArticleTotalsParams{PeriodStart: start, PeriodEnd: until})
+		if err != nil {
+			return err
+		}
+
+		result.Closing, err = q.CreateClosing(ctx, CreateClosingParams{
+			PeriodStart:      start,
+			PeriodEnd:        until,
+			Transactions:     transactions.Transactions,
+			Revenue:          articles.Revenue,
+			Refunds:          transactions.Refunds,
+			RefundTotal:      transactions.RefundTotal,
+			DepositsCharged:  articles.DepositsCharged,
+			DepositsReturned: articles.DepositsReturned,
+			Pending:          transactions.Pending,
+		})
+		if err != nil {
+			return err
+		}
+
+		articleTypes, err := q.GetClosingArticleTypeTotals(ctx, GetClosingArticleTypeTotalsParams{PeriodStart: start, PeriodEnd: until})
+		if err != nil {
+			return err
+		}
+
+		result.ArticleTypes = make([]ClosingArticleType, 0, len(articleTypes))
+		for _, articleType := range articleTypes {
+			row, err := q.CreateClosingArticleType(ctx, CreateClosingArticleTypeParams{
+				ClosingUuid:     result.Uuid,
+				ArticleTypeUuid: articleType.ArticleTypeUuid,
+				Name:            articleType.Name,
+				Amount:          articleType.Amount,
+				Revenue:         articleType.Revenue,
+			})
+			if err != nil {
+				return err
+			}
+			result.ArticleTypes = append(result.ArticleTypes, row)
+		}
+
+		backends, err := q.GetClosingPaymentBackendTotals(ctx, GetClosingPaymentBackendTotalsParams{PeriodStart: start, PeriodEnd: until})
+		if err != nil {
+			return err
+		}
+
+		result.PaymentBackends = make([]ClosingPaymentBackend, 0, len(backends))
+		for _, backend := range backends {
+			row, err := q.CreateClosingPaymentBackend(ctx, CreateClosingPaymentBackendParams{
+				ClosingUuid:    result.Uuid,
+				PaymentBackend: backend.PaymentBackend,
+				Transactions:   backend.Transactions,
+				Total:          backend.Total,
+			})
+			if err != nil {
+				return err
+			}
+			result.PaymentBackends = append(result.PaymentBackends, row)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// GetClosingReport returns a closing with its totals
+func (q *Queries) GetClosingReport(ctx context.Context, closingUuid uuid.UUID) (ClosingReport, error) {
+	var result ClosingReport
+
+	var err error
+	result.Closing, err = q.GetClosingById(ctx, closingUuid)
+	if err != nil {
+		return result, err
+	}
+
+	result.ArticleTypes, err = q.GetClosingArticleTypes(ctx, closingUuid)
+	if err != nil {
+		return result, err
+	}
+
+	result.PaymentBackends, err = q.GetClosingPaymentBackends(ctx, closingUuid)
+	return result, err
+}
+
+// lockPeriodOpen returns ErrPeriodClosed if the date lies inside a closed
+// business day, else the closings are locked until the transaction ends, so
+// the day can not be closed before the sale is written
+func (q *Queries) lockPeriodOpen(ctx context.Context, date time.Time) error {
+	if err := q.LockClosingsForSale(ctx); err != nil {
+		return err
+	}
+	return q.CheckPeriodOpen(ctx, date)
+}
+
+// CheckPeriodOpen returns ErrPeriodClosed if the date lies inside a closed business day
+func (q *Queries) CheckPeriodOpen(ctx context.Context, date time.Time) error {
+	closed, err := q.IsPeriodClosed(ctx, date)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return ErrPeriodClosed
+	}
+	return nil
+}
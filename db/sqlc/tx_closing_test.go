@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+// chargeQueuedPayments charges every queued payment like a worker whose
+// backends accept everything, so no pending transaction blocks a closing
+func chargeQueuedPayments(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+
+	for {
+		payments, err := testStore.GetDuePaymentOutbox(ctx, 100)
+		if err != nil {
+			t.Fatalf("get queued payments: %v", err)
+		}
+		if len(payments) == 0 {
+			return
+		}
+
+		for _, payment := range payments {
+			_, err := testStore.CompletePaymentTx(ctx, CompletePaymentTxParams{
+				OutboxUuid:      payment.Uuid,
+				TransactionUuid: payment.TransactionUuid,
+				Status:          TransactionStatusCharged,
+			})
+			if err != nil {
+				t.Fatalf("charge payment %s: %v", payment.Uuid, err)
+			}
+		}
+	}
+}
+
+func TestCloseDayTx(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	// start with a period that only holds the sales of this test
+	chargeQueuedPayments(t)
+	if _, err := store.CloseDayTx(ctx, time.Now()); err != nil {
+		t.Fatalf("close the previous period: %v", err)
+	}
+
+	beer := createTestArticle(t, CreateArticleParams{ResellPrice: 300, Deposit: 50})
+	snack := createTestArticle(t, CreateArticleParams{ResellPrice: 200})
+
+	chargedCheckout(t, CheckoutLine{ArticleUuid: beer.Uuid, Amount: 2, Price: 300, Deposit: 50, VatRate: 19})
+	refunded := chargedCheckout(t, CheckoutLine{ArticleUuid: snack.Uuid, Amount: 1, Price: 200, VatRate: 7})
+	if _, err := store.RefundTx(ctx, RefundTxParams{TransactionUuid: refunded.Transaction.Uuid, Date: time.Now()}); err != nil {
+		t.Fatalf("refund: %v", err)
+	}
+
+	failed, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		PaymentBackend: "cash",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{{ArticleUuid: beer.Uuid, Amount: 5, Price: 300, VatRate: 19}},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	_, err = store.CloseDayTx(ctx, time.Now())
+	if !errors.Is(err, ErrTransactionsPending) {
+		t.Fatalf("closing with queued payments: got %v, want %v", err, ErrTransactionsPending)
+	}
+
+	payment, err := store.GetPaymentOutboxByTransaction(ctx, failed.Transaction.Uuid)
+	if err != nil {
+		t.Fatalf("get payment: %v", err)
+	}
+	_, err = store.CompletePaymentTx(ctx, CompletePaymentTxParams{OutboxUuid: payment.Uuid, TransactionUuid: failed.Transaction.Uuid, Status: TransactionStatusFailed})
+	if err != nil {
+		t.Fatalf("fail payment: %v", err)
+	}
+	chargeQueuedPayments(t)
+
+	report, err := store.CloseDayTx(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("close day: %v", err)
+	}
+
+	// the refunded snack is sold and refunded inside the period
+	want := Closing{Transactions: 2, Revenue: 600, Refunds: 1, RefundTotal: 200, DepositsCharged: 100}
+	got := Closing{
+		Transactions:     report.Transactions,
+		Revenue:          report.Revenue,
+		Refunds:          report.Refunds,
+		RefundTotal:      report.RefundTotal,
+		DepositsCharged:  report.DepositsCharged,
+		DepositsReturned: report.DepositsReturned,
+		Pending:          report.Pending,
+	}
+	if got != want {
+		t.Errorf("got totals %+v, want %+v", got, want)
+	}
+
+	revenue := make(map[string]util.Money)
+	for _, articleType := range report.ArticleTypes {
+		revenue[articleType.ArticleTypeUuid.String()] = articleType.Revenue
+	}
+	if revenue[beer.ArticleTypeUuid.String()] != 600 || revenue[snack.ArticleTypeUuid.String()] != 0 {
+		t.Errorf("got revenue per article type %v, want 6.00 for beer and 0.00 for snacks", revenue)
+	}
+
+	if len(report.PaymentBackends) != 1 {
+		t.Fatalf("got %d payment backends, want cash only", len(report.PaymentBackends))
+	}
+	if backend := report.PaymentBackends[0]; backend.PaymentBackend != "cash" || backend.Transactions != 3 || backend.Total != 700 {
+		t.Errorf("got %+v, want 3 cash transactions of 7.00", backend)
+	}
+
+	_, err = store.CheckoutTx(ctx, CheckoutTxParams{
+		PaymentBackend: "cash",
+		Date:           report.PeriodEnd.Add(-time.Minute),
+		Lines:          []CheckoutLine{{ArticleUuid: beer.Uuid, Amount: 1, Price: 300, VatRate: 19}},
+	})
+	if !errors.Is(err, ErrPeriodClosed) {
+		t.Errorf("sale inside the closed period: got %v, want %v", err, ErrPeriodClosed)
+	}
+
+	_, err = store.CloseDayTx(ctx, report.PeriodEnd)
+	if !errors.Is(err, ErrPeriodClosed) {
+		t.Errorf("closing the period again: got %v, want %v", err, ErrPeriodClosed)
+	}
+}
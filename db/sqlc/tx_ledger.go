@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/guregu/null/v5"
+)
+
+// Kinds of ledger accounts, there is one wallet per resident and a single
+// account of every other kind
+const (
+	LedgerAccountWallet     = "wallet"
+	LedgerAccountRevenue    = "revenue"
+	LedgerAccountCash       = "cash"
+	LedgerAccountCorrection = "correction"
+)
+
+// ErrInsufficientFunds is returned when a posting would take a wallet below zero
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// WalletPostingTxParams contains the input parameters of the wallet posting transaction
+type WalletPostingTxParams struct {
+	Resident string
+	// Amount is added to the wallet, debits are negative
+	Amount util.Money
+	// CounterKind is the kind of the account the money comes from or goes to
+	CounterKind string
+	// Details identify the posting, a second posting with the same details is a no-op
+	Details string
+	// AllowNegative lets corrections take a wallet below zero
+	AllowNegative bool
+}
+
+// WalletPostingTx books the amount on the wallet of the resident and the
+// opposite amount on the counter account, so every posting sums up to zero.
+// The wallet is created on its first posting and locked while the balance is
+// checked.
+func (store *Store) WalletPostingTx(ctx context.Context, arg WalletPostingTxParams) (LedgerPosting, error) {
+	var result LedgerPosting
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		wallet, err := q.GetOrCreateWalletAccount(ctx, arg.Resident)
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.LockLedgerAccount(ctx, wallet.Uuid); err != nil {
+			return err
+		}
+
+		result, err = q.GetLedgerPostingByDetails(ctx, arg.Details)
+		if err == nil {
+			return nil
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		if arg.Amount < 0 && !arg.AllowNegative {
+			balance, err := q.GetLedgerAccountBalance(ctx, wallet.Uuid)
+			if err != nil {
+				return err
+			}
+			if balance+arg.Amount < 0 {
+				return fmt.Errorf("%w: balance of %s is %s", ErrInsufficientFunds, arg.Resident, balance)
+			}
+		}
+
+		counter, err := q.GetSystemLedgerAccount(ctx, arg.CounterKind)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateLedgerPosting(ctx, arg.Details)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateLedgerEntry(ctx, CreateLedgerEntryParams{
+			PostingUuid: result.Uuid,
+			AccountUuid: wallet.Uuid,
+			Amount:      arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateLedgerEntry(ctx, CreateLedgerEntryParams{
+			PostingUuid: result.Uuid,
+			AccountUuid: counter.Uuid,
+			Amount:      -arg.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// WalletBalance is the sum of all entries of the wallet of the resident, a
+// resident without a wallet has a balance of zero
+func (q *Queries) WalletBalance(ctx context.Context, resident string) (util.Money, error) {
+	wallet, err := q.GetWalletAccount(ctx, null.StringFrom(resident))
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return q.GetLedgerAccountBalance(ctx, wallet.Uuid)
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// ErrTransferExceedsStock is returned when more units are transferred than are stored at the location
+var ErrTransferExceedsStock = errors.New("transfer exceeds the stock at the location")
+
+// LocationStock is the stock of an article at a location
+type LocationStock struct {
+	LocationUuid uuid.UUID `json:"location_uuid"`
+	Location     string    `json:"location"`
+	Stock        int32     `json:"stock"`
+}
+
+// ArticleStockReport is the total stock of an article broken down by location
+type ArticleStockReport struct {
+	ArticleUuid uuid.UUID       `json:"article_uuid"`
+	Article     string          `json:"article"`
+	Stock       int32           `json:"stock"`
+	Locations   []LocationStock `json:"locations"`
+}
+
+// SetDefaultLocationTx makes the location the default location, the one
+// sales of terminals without a location and deliveries draw from
+func (store *Store) SetDefaultLocationTx(ctx context.Context, locationUuid uuid.UUID) (Location, error) {
+	var result Location
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.ClearDefaultLocation(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = q.SetDefaultLocation(ctx, locationUuid)
+		return err
+	})
+
+	return result, err
+}
+
+// TransferStockTxParams contains the input parameters of the transfer stock transaction
+type TransferStockTxParams struct {
+	ArticleUuid      uuid.UUID
+	FromLocationUuid uuid.UUID
+	ToLocationUuid   uuid.UUID
+	Quantity         int32
+	Note             null.String
+}
+
+// TransferStockTx moves units of an article from one location to another
+// and records a movement for both. The total stock of the article does not
+// change. A location can not give more units than it stores.
+func (store *Store) TransferStockTx(ctx context.Context, arg TransferStockTxParams) (StockTransfer, error) {
+	var result StockTransfer
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.CreateStockTransfer(ctx, CreateStockTransferParams{
+			ArticleUuid:      arg.ArticleUuid,
+			FromLocationUuid: uuid.NullUUID{UUID: arg.FromLocationUuid, Valid: true},
+			ToLocationUuid:   uuid.NullUUID{UUID: arg.ToLocationUuid, Valid: true},
+			Quantity:         arg.Quantity,
+			Note:             arg.Note,
+		})
+		if err != nil {
+			return err
+		}
+
+		article, err := q.GetArticleById(ctx, arg.ArticleUuid)
+		if err != nil {
+			return err
+		}
+
+		moves := []struct {
+			location uuid.UUID
+			quantity int32
+		}{
+			{arg.FromLocationUuid, -arg.Quantity},
+			{arg.ToLocationUuid, arg.Quantity},
+		}
+		for _, move := range moves {
+			stock, err := q.MoveLocationStock(ctx, MoveLocationStockParams{
+				ArticleUuid:  arg.ArticleUuid,
+				LocationUuid: move.location,
+				Quantity:     move.quantity,
+			})
+			if err != nil {
+				return err
+			}
+			if stock < 0 {
+				return fmt.Errorf("%w: only %d units are stored", ErrTransferExceedsStock, stock-move.quantity)
+			}
+
+			_, err = q.CreateStockMovement(ctx, CreateStockMovementParams{
+				ArticleUuid:       arg.ArticleUuid,
+				Quantity:          move.quantity,
+				StockAfter:        article.Stock,
+				Reason:            StockMovementTransfer,
+				Note:              arg.Note,
+				LocationUuid:      uuid.NullUUID{UUID: move.location, Valid: true},
+				StockTransferUuid: uuid.NullUUID{UUID: result.Uuid, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// GetStockReport returns the stock of every article that is not a bundle,
+// broken down by the locations that store it
+func (q *Queries) GetStockReport(ctx context.Context) ([]ArticleStockReport, error) {
+	rows, err := q.GetStockByLocation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ArticleStockReport{}
+	for _, row := range rows {
+		// the rows of an article follow each other
+		if len(result) == 0 || result[len(result)-1].ArticleUuid != row.ArticleUuid {
+			result = append(result, ArticleStockReport{
+				ArticleUuid: row.ArticleUuid,
+				Article:     row.Article,
+				Stock:       row.Stock,
+				Locations:   []LocationStock{},
+			})
+		}
+
+		if row.LocationUuid.Valid {
+			report := &result[len(result)-1]
+			report.Locations = append(report.Locations, LocationStock{
+				LocationUuid: row.LocationUuid.UUID,
+				Location:     row.Location.String,
+				Stock:        row.LocationStock.Int32,
+			})
+		}
+	}
+
+	return result, nil
+}
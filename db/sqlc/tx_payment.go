@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+// Possible states of a transaction while its payment is processed
+const (
+	TransactionStatusPending = "pending"
+	TransactionStatusCharged = "charged"
+	TransactionStatusFailed  = "failed"
+)
+
+// PaymentDetails is the idempotency marker stored with every balance
+// adjustment in SavaPage, it links the adjustment back to its transaction
+func PaymentDetails(transactionUuid uuid.UUID) string {
+	return "rupay_transaction:" + transactionUuid.String()
+}
+
+// CompletePaymentTxParams contains the input parameters of the complete payment transaction
+type CompletePaymentTxParams struct {
+	OutboxUuid      uuid.UUID
+	TransactionUuid uuid.UUID
+	Status          string
+	BalanceAfter    util.NullMoney // balance of the account after the payment, if the backend keeps one
+	// CheckPeriodOpen refuses transactions of closed business days, payments
+	// reconciled by hand set it, the worker always records what the backend did
+	CheckPeriodOpen bool
+}
+
+// CompletePaymentTx closes an outbox entry and sets the final status of its
+// transaction. Shares of a split bill also settle their parent.
+func (store *Store) CompletePaymentTx(ctx context.Context, arg CompletePaymentTxParams) (Transaction, error) {
+	var result Transaction
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		if arg.CheckPeriodOpen {
+			if err = q.lockTransactionPeriodOpen(ctx, arg.TransactionUuid); err != nil {
+				return err
+			}
+		}
+
+		if err = q.MarkPaymentOutboxProcessed(ctx, arg.OutboxUuid); err != nil {
+			return err
+		}
+
+		result, err = q.setTransactionStatus(ctx, UpdateTransactionStatusParams{
+			Status:       arg.Status,
+			BalanceAfter: arg.BalanceAfter,
+			Uuid:         arg.TransactionUuid,
+		})
+		if err != nil || !result.SplitOf.Valid {
+			return err
+		}
+
+		return q.settleSplit(ctx, result)
+	})
+
+	return result, err
+}
+
+// RequeuePaymentTx puts an outbox entry back into the queue and its
+// transaction back to pending, the units of a failed sale are taken out of
+// the stock again. Transactions of closed business days can not be requeued.
+func (store *Store) RequeuePaymentTx(ctx context.Context, outboxUuid uuid.UUID) (Transaction, error) {
+	var result Transaction
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		entry, err := q.RequeuePaymentOutbox(ctx, outboxUuid)
+		if err != nil {
+			return err
+		}
+
+		if err := q.lockTransactionPeriodOpen(ctx, entry.TransactionUuid); err != nil {
+			return err
+		}
+
+		result, err = q.setTransactionStatus(ctx, UpdateTransactionStatusParams{
+			Status: TransactionStatusPending,
+			Uuid:   entry.TransactionUuid,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// lockTransactionPeriodOpen locks the closings like lockPeriodOpen for the
+// business day of the transaction
+func (q *Queries) lockTransactionPeriodOpen(ctx context.Context, transactionUuid uuid.UUID) error {
+	transaction, err := q.GetTransactionById(ctx, transactionUuid)
+	if err != nil {
+		return err
+	}
+	return q.lockPeriodOpen(ctx, transaction.Date)
+}
+
+// setTransactionStatus changes the status of a transaction and lets its
+// stock follow. A failed transaction sold nothing, its units go back into
+// the stock and are taken again once it is pending or charged again.
+func (q *Queries) setTransactionStatus(ctx context.Context, arg UpdateTransactionStatusParams) (Transaction, error) {
+	before, err := q.GetTransactionByIdForUpdate(ctx, arg.Uuid)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	result, err := q.UpdateTransactionStatus(ctx, arg)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	failed := result.Status == TransactionStatusFailed
+	if failed == (before.Status == TransactionStatusFailed) {
+		return result, nil
+	}
+
+	lines, err := q.GetArticleTransactionsByTransaction(ctx, result.Uuid)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	for _, line := range stockLines(lines) {
+		reason := StockMovementSale
+		if line.RefundOf.Valid {
+			reason = StockMovementRefund
+		}
+		if failed {
+			line.Amount = -line.Amount
+			reason = StockMovementCorrection
+		}
+
+		if _, err := q.moveStockOfLine(ctx, line, reason, false); err != nil {
+			return Transaction{}, err
+		}
+	}
+
+	return result, nil
+}
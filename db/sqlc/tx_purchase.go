@@ -0,0 +1,281 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// Possible states of a purchase order
+const (
+	PurchaseOrderStatusOpen              = "open"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+var (
+	// ErrPurchaseOrderNotOpen is returned when goods are received for a received or cancelled order
+	ErrPurchaseOrderNotOpen = errors.New("purchase order is not open")
+	// ErrReceiptExceedsOrder is returned when more units are received than are left on the order line
+	ErrReceiptExceedsOrder = errors.New("receipt exceeds the ordered quantity")
+)
+
+// GoodsReceiptReport is a goods receipt with its lines
+type GoodsReceiptReport struct {
+	GoodsReceipt
+	Lines []GoodsReceiptLine `json:"lines"`
+}
+
+// PurchaseOrderReport is a purchase order with its lines and the goods received for it
+type PurchaseOrderReport struct {
+	PurchaseOrder
+	Lines    []PurchaseOrderLine  `json:"lines"`
+	Receipts []GoodsReceiptReport `json:"receipts"`
+}
+
+// CreatePurchaseOrderTxParams contains the input parameters of the create purchase order transaction
+type CreatePurchaseOrderTxParams struct {
+	SupplierUuid uuid.UUID
+	Note         null.String
+	Lines        []CreatePurchaseOrderLineParams // the purchase order uuid is filled in
+}
+
+// CreatePurchaseOrderTx writes an open purchase order together with its lines
+func (store *Store) CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderReport, error) {
+	var result PurchaseOrderReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.PurchaseOrder, err = q.CreatePurchaseOrder(ctx, CreatePurchaseOrderParams{
+			SupplierUuid: arg.SupplierUuid,
+			Note:         arg.Note,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Lines = make([]PurchaseOrderLine, 0, len(arg.Lines))
+		for _, line := range arg.Lines {
+			line.PurchaseOrderUuid = result.Uuid
+			row, err := q.CreatePurchaseOrderLine(ctx, line)
+			if err != nil {
+				return err
+			}
+			result.Lines = append(result.Lines, row)
+		}
+
+		result.Receipts = []GoodsReceiptReport{}
+		return nil
+	})
+
+	return result, err
+}
+
+// ReceiveGoodsLine is the quantity of an order line that arrived. Without a
+// unit cost the cost of the order line was paid.
+type ReceiveGoodsLine struct {
+	PurchaseOrderLineUuid uuid.UUID
+	Quantity              int32
+	UnitCost              util.NullMoney
+}
+
+// ReceiveGoodsTxParams contains the input parameters of the receive goods transaction
+type ReceiveGoodsTxParams struct {
+	PurchaseOrderUuid uuid.UUID
+	LocationUuid      uuid.NullUUID // optional, defaults to the default location
+	Note              null.String
+	Lines             []ReceiveGoodsLine
+}
+
+// ReceiveGoodsTx books a goods receipt for an open purchase order. The
+// received units are added to the stock at the location and the purchase
+// price of every article becomes the weighted average of the units in stock
+// and the received units at their actual cost, recorded in its price history.
+// The order is received once all of its lines are. The order is locked, so
+// concurrent receipts can not exceed the ordered quantities.
+func (store *Store) ReceiveGoodsTx(ctx context.Context, arg ReceiveGoodsTxParams) (GoodsReceiptReport, error) {
+	var result GoodsReceiptReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderByIdForUpdate(ctx, arg.PurchaseOrderUuid)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != PurchaseOrderStatusOpen && order.Status != PurchaseOrderStatusPartiallyReceived {
+			return ErrPurchaseOrderNotOpen
+		}
+
+		orderLines, err := q.GetPurchaseOrderLines(ctx, order.Uuid)
+		if err != nil {
+			return err
+		}
+
+		byUuid := make(map[uuid.UUID]PurchaseOrderLine, len(orderLines))
+		for _, line := range orderLines {
+			byUuid[line.Uuid] = line
+		}
+
+		result.GoodsReceipt, err = q.CreateGoodsReceipt(ctx, CreateGoodsReceiptParams{
+			PurchaseOrderUuid: order.Uuid,
+			Note:              arg.Note,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Lines = make([]GoodsReceiptLine, 0, len(arg.Lines))
+		for _, line := range arg.Lines {
+			orderLine, ok := byUuid[line.PurchaseOrderLineUuid]
+			if !ok {
+				return fmt.Errorf("%w: line %s is not part of the purchase order", ErrReceiptExceedsOrder, line.PurchaseOrderLineUuid)
+			}
+			if line.Quantity > orderLine.Quantity-orderLine.Received {
+				return fmt.Errorf("%w: only %d units of line %s are left", ErrReceiptExceedsOrder, orderLine.Quantity-orderLine.Received, orderLine.Uuid)
+			}
+
+			unitCost := orderLine.UnitCost
+			if line.UnitCost.Valid {
+				unitCost = line.UnitCost.Money
+			}
+
+			byUuid[orderLine.Uuid], err = q.ReceivePurchaseOrderLine(ctx, ReceivePurchaseOrderLineParams{
+				Quantity: line.Quantity,
+				Uuid:     orderLine.Uuid,
+			})
+			if err != nil {
+				return err
+			}
+
+			receiptLine, err := q.CreateGoodsReceiptLine(ctx, CreateGoodsReceiptLineParams{
+				GoodsReceiptUuid:      result.Uuid,
+				PurchaseOrderLineUuid: orderLine.Uuid,
+				ArticleUuid:           orderLine.ArticleUuid,
+				Quantity:              line.Quantity,
+				UnitCost:              unitCost,
+			})
+			if err != nil {
+				return err
+			}
+			result.Lines = append(result.Lines, receiptLine)
+
+			stock, err := q.ReceiveArticleStock(ctx, ReceiveArticleStockParams{
+				Quantity: line.Quantity,
+				UnitCost: unitCost,
+				Uuid:     orderLine.ArticleUuid,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = q.recordArticlePrice(ctx, ArticlePriceChange{
+				ArticleUuid:   orderLine.ArticleUuid,
+				PurchasePrice: util.NullMoneyFrom(stock.PurchasePrice),
+				ValidFrom:     result.ReceivedAt,
+			}, result.ReceivedAt)
+			if err != nil {
+				return err
+			}
+
+			location, _, err := q.moveLocationStock(ctx, orderLine.ArticleUuid, arg.LocationUuid, line.Quantity)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateStockMovement(ctx, CreateStockMovementParams{
+				ArticleUuid:      orderLine.ArticleUuid,
+				Quantity:         line.Quantity,
+				StockAfter:       stock.Stock,
+				Reason:           StockMovementDelivery,
+				Note:             arg.Note,
+				GoodsReceiptUuid: uuid.NullUUID{UUID: result.Uuid, Valid: true},
+				LocationUuid:     uuid.NullUUID{UUID: location, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		status := PurchaseOrderStatusReceived
+		for _, line := range byUuid {
+			if line.Received < line.Quantity {
+				status = PurchaseOrderStatusPartiallyReceived
+				break
+			}
+		}
+
+		_, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{Status: status, Uuid: order.Uuid})
+		return err
+	})
+
+	return result, err
+}
+
+// CancelPurchaseOrderTx cancels an open purchase order, goods that were
+// received already stay in the stock
+func (store *Store) CancelPurchaseOrderTx(ctx context.Context, purchaseOrderUuid uuid.UUID) (PurchaseOrder, error) {
+	var result PurchaseOrder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderByIdForUpdate(ctx, purchaseOrderUuid)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != PurchaseOrderStatusOpen && order.Status != PurchaseOrderStatusPartiallyReceived {
+			return ErrPurchaseOrderNotOpen
+		}
+
+		result, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{Status: PurchaseOrderStatusCancelled, Uuid: order.Uuid})
+		return err
+	})
+
+	return result, err
+}
+
+// GetPurchaseOrderReport returns the purchase order with its lines and goods receipts
+func (q *Queries) GetPurchaseOrderReport(ctx context.Context, purchaseOrderUuid uuid.UUID) (PurchaseOrderReport, error) {
+	var result PurchaseOrderReport
+
+	var err error
+	result.PurchaseOrder, err = q.GetPurchaseOrderById(ctx, purchaseOrderUuid)
+	if err != nil {
+		return result, err
+	}
+
+	result.Lines, err = q.GetPurchaseOrderLines(ctx, purchaseOrderUuid)
+	if err != nil {
+		return result, err
+	}
+
+	receipts, err := q.GetGoodsReceipts(ctx, purchaseOrderUuid)
+	if err != nil {
+		return result, err
+	}
+
+	lines, err := q.GetGoodsReceiptLinesOfOrder(ctx, purchaseOrderUuid)
+	if err != nil {
+		return result, err
+	}
+
+	byReceipt := make(map[uuid.UUID][]GoodsReceiptLine, len(receipts))
+	for _, line := range lines {
+		byReceipt[line.GoodsReceiptUuid] = append(byReceipt[line.GoodsReceiptUuid], line)
+	}
+
+	result.Receipts = make([]GoodsReceiptReport, 0, len(receipts))
+	for _, receipt := range receipts {
+		receiptLines := byReceipt[receipt.Uuid]
+		if receiptLines == nil {
+			receiptLines = []GoodsReceiptLine{}
+		}
+		result.Receipts = append(result.Receipts, GoodsReceiptReport{GoodsReceipt: receipt, Lines: receiptLines})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+func TestReceiveGoodsTxWeightedAverageCost(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	article := createTestArticle(t, CreateArticleParams{PurchasePrice: 100, ResellPrice: 400})
+
+	supplier, err := store.CreateSupplier(ctx, CreateSupplierParams{Name: "supplier " + uuid.NewString()})
+	if err != nil {
+		t.Fatalf("create supplier: %v", err)
+	}
+
+	order, err := store.CreatePurchaseOrderTx(ctx, CreatePurchaseOrderTxParams{
+		SupplierUuid: supplier.Uuid,
+		Lines:        []CreatePurchaseOrderLineParams{{ArticleUuid: article.Uuid, Quantity: 100, UnitCost: 200}},
+	})
+	if err != nil {
+		t.Fatalf("create purchase order: %v", err)
+	}
+	orderLine := order.Lines[0].Uuid
+
+	tests := []struct {
+		name     string
+		sell     int32 // units sold before the receipt
+		quantity int32
+		unitCost util.NullMoney
+		stock    int32
+		cost     util.Money
+	}{
+		{name: "empty stock takes the cost of the order", quantity: 10, stock: 10, cost: 200},
+		{name: "average of the stock and the receipt", quantity: 10, unitCost: util.NullMoneyFrom(300), stock: 20, cost: 250},
+		{name: "negative stock does not count", sell: 25, quantity: 10, unitCost: util.NullMoneyFrom(130), stock: 5, cost: 130},
+		{name: "rounded to the cent", quantity: 2, unitCost: util.NullMoneyFrom(101), stock: 7, cost: 122},
+	}
+
+	for _, tt := range tests {
+		if tt.sell > 0 {
+			chargedCheckout(t, CheckoutLine{ArticleUuid: article.Uuid, Amount: tt.sell, Price: 400, VatRate: 19})
+		}
+
+		_, err := store.ReceiveGoodsTx(ctx, ReceiveGoodsTxParams{
+			PurchaseOrderUuid: order.Uuid,
+			Lines:             []ReceiveGoodsLine{{PurchaseOrderLineUuid: orderLine, Quantity: tt.quantity, UnitCost: tt.unitCost}},
+		})
+		if err != nil {
+			t.Fatalf("%s: receive goods: %v", tt.name, err)
+		}
+
+		got := getTestArticle(t, article.Uuid)
+		if got.Stock != tt.stock || got.PurchasePrice != tt.cost {
+			t.Errorf("%s: got %d units at %v, want %d units at %v", tt.name, got.Stock, got.PurchasePrice, tt.stock, tt.cost)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotRefundable is returned for transactions that were not charged or are refunds themselves
+	ErrNotRefundable = errors.New("transaction can not be refunded")
+	// ErrRefundExceedsAmount is returned when more items are refunded than are left on the transaction
+	ErrRefundExceedsAmount = errors.New("refund exceeds the remaining amount")
+)
+
+// RefundLine is a single article transaction to refund, the amount is positive
+type RefundLine struct {
+	ArticleTransactionUuid uuid.UUID
+	Amount                 int32
+}
+
+// RefundTxParams contains the input parameters of the refund transaction.
+// Without lines everything that was not refunded yet is refunded.
+type RefundTxParams struct {
+	TransactionUuid uuid.UUID
+	Date            time.Time
+	Lines           []RefundLine
+}
+
+// RefundTx writes a reversing transaction with negative amounts that is linked
+// to the original transaction and queues the credit in the payment outbox.
+// The refund belongs to the resident and event of the original and is paid
+// back with the same payment backend. The original
+// transaction is locked, so concurrent refunds can not exceed the charged
+// amounts. Refunded units go back into the stock. The refund is booked at its
+// own date, which has to lie in an open business day.
+func (store *Store) RefundTx(ctx context.Context, arg RefundTxParams) (CheckoutTxResult, error) {
+	var result CheckoutTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.lockPeriodOpen(ctx, arg.Date); err != nil {
+			return err
+		}
+
+		original, err := q.GetTransactionByIdForUpdate(ctx, arg.TransactionUuid)
+		if err != nil {
+			return err
+		}
+
+		if original.Status != TransactionStatusCharged || original.RefundOf.Valid {
+			return ErrNotRefundable
+		}
+		if original.SplitOf.Valid {
+			return fmt.Errorf("%w: refund the split bill %s instead of its share", ErrNotRefundable, original.SplitOf.UUID)
+		}
+
+		lines, err := q.GetArticleTransactionsByTransaction(ctx, original.Uuid)
+		if err != nil {
+			return err
+		}
+
+		// component lines follow the refund of their bundle
+		articleTransactions := make([]ArticleTransaction, 0, len(lines))
+		components := make(map[uuid.UUID][]ArticleTransaction)
+		for _, line := range lines {
+			if line.BundleOf.Valid {
+				components[line.BundleOf.UUID] = append(components[line.BundleOf.UUID], line)
+				continue
+			}
+			articleTransactions = append(articleTransactions, line)
+		}
+
+		// remaining amount per article transaction of the original
+		remaining := make(map[uuid.UUID]int32, len(articleTransactions))
+		for _, articleTransaction := range articleTransactions {
+			refunded, err := q.GetRefundedAmount(ctx, uuid.NullUUID{UUID: articleTransaction.Uuid, Valid: true})
+			if err != nil {
+				return err
+			}
+			remaining[articleTransaction.Uuid] = articleTransaction.Amount - refunded
+		}
+
+		refundLines := arg.Lines
+		if len(refundLines) == 0 {
+			for _, articleTransaction := range articleTransactions {
+				if remaining[articleTransaction.Uuid] > 0 {
+					refundLines = append(refundLines, RefundLine{articleTransaction.Uuid, remaining[articleTransaction.Uuid]})
+				}
+			}
+			if len(refundLines) == 0 {
+				return fmt.Errorf("%w: everything is refunded already", ErrNotRefundable)
+			}
+		}
+
+		byUuid := make(map[uuid.UUID]ArticleTransaction, len(articleTransactions))
+		for _, articleTransaction := range articleTransactions {
+			byUuid[articleTransaction.Uuid] = articleTransaction
+		}
+
+		var total util.Money
+		for _, line := range refundLines {
+			articleTransaction, ok := byUuid[line.ArticleTransactionUuid]
+			if !ok {
+				return fmt.Errorf("%w: article transaction %s is not part of the transaction", ErrRefundExceedsAmount, line.ArticleTransactionUuid)
+			}
+			if line.Amount > remaining[articleTransaction.Uuid] {
+				return fmt.Errorf("%w: only %d of article transaction %s are left", ErrRefundExceedsAmount, remaining[articleTransaction.Uuid], articleTransaction.Uuid)
+			}
+			remaining[articleTransaction.Uuid] -= line.Amount
+			total += articleTransaction.Price.Mul(line.Amount)
+		}
+
+		result.Transaction, err = q.CreateRefundTransaction(ctx, CreateRefundTransactionParams{
+			Date:           arg.Date,
+			Price:          -total,
+			RefundOf:       uuid.NullUUID{UUID: original.Uuid, Valid: true},
+			Resident:       original.Resident,
+			EventUuid:      original.EventUuid,
+			PaymentBackend: original.PaymentBackend,
+			TerminalUuid:   original.TerminalUuid,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ArticleTransactions = make([]ArticleTransaction, 0, len(refundLines))
+		for _, line := range refundLines {
+			articleTransaction := byUuid[line.ArticleTransactionUuid]
+			net, tax := SplitVat(articleTransaction.Price.Mul(-line.Amount), articleTransaction.VatRate)
+			refund, err := q.CreateRefundArticleTransaction(ctx, CreateRefundArticleTransactionParams{
+				ArticleUuid:     articleTransaction.ArticleUuid,
+				TransactionUuid: result.Transaction.Uuid,
+				Amount:          -line.Amount,
+				Price:           articleTransaction.Price,
+				RefundOf:        uuid.NullUUID{UUID: articleTransaction.Uuid, Valid: true},
+				PricingRuleUuid: articleTransaction.PricingRuleUuid,
+				Kind:            articleTransaction.Kind,
+				VatRate:         articleTransaction.VatRate,
+				Net:             net,
+				Tax:             tax,
+			})
+			if err != nil {
+				return err
+			}
+			result.ArticleTransactions = append(result.ArticleTransactions, refund)
+			if _, err := q.moveLineStock(ctx, refund, StockMovementRefund, false); err != nil {
+				return err
+			}
+
+			for _, component := range components[articleTransaction.Uuid] {
+				componentRefund, err := q.CreateRefundArticleTransaction(ctx, CreateRefundArticleTransactionParams{
+					ArticleUuid:     component.ArticleUuid,
+					TransactionUuid: result.Transaction.Uuid,
+					Amount:          -component.Amount * line.Amount / articleTransaction.Amount,
+					Price:           component.Price,
+					RefundOf:        uuid.NullUUID{UUID: component.Uuid, Valid: true},
+					Kind:            component.Kind,
+					BundleOf:        uuid.NullUUID{UUID: refund.Uuid, Valid: true},
+				})
+				if err != nil {
+					return err
+				}
+				result.ArticleTransactions = append(result.ArticleTransactions, componentRefund)
+				if _, err := q.moveLineStock(ctx, componentRefund, StockMovementRefund, false); err != nil {
+					return err
+				}
+			}
+		}
+
+		result.Vat = GetVatBreakdown(result.ArticleTransactions)
+
+		if original.PaymentBackend == PaymentBackendSplit {
+			return q.refundSplitShares(ctx, original, result.Transaction, total)
+		}
+
+		payment, err := q.GetPaymentOutboxByTransaction(ctx, original.Uuid)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePaymentOutbox(ctx, CreatePaymentOutboxParams{
+			TransactionUuid: result.Transaction.Uuid,
+			Username:        payment.Username,
+			Amount:          total,
+			Details:         PaymentDetails(result.Transaction.Uuid),
+			Backend:         payment.Backend,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// refundSplitShares pays back the refund of a split bill to the residents in
+// proportion to their shares, the remaining cents go to the last share. Every
+// credit is a refund of its share linked to the refund of the bill.
+func (q *Queries) refundSplitShares(ctx context.Context, original Transaction, refund Transaction, total util.Money) error {
+	shares, err := q.GetSplitShares(ctx, uuid.NullUUID{UUID: original.Uuid, Valid: true})
+	if err != nil {
+		return err
+	}
+
+	left := total
+	for i, share := range shares {
+		amount := left
+		if i < len(shares)-1 {
+			amount = total * share.Price / original.Price
+		}
+		left -= amount
+
+		payment, err := q.GetPaymentOutboxByTransaction(ctx, share.Uuid)
+		if err != nil {
+			return err
+		}
+
+		transaction, err := q.CreateRefundTransaction(ctx, CreateRefundTransactionParams{
+			Date:           refund.Date,
+			Price:          -amount,
+			RefundOf:       uuid.NullUUID{UUID: share.Uuid, Valid: true},
+			Resident:       share.Resident,
+			EventUuid:      share.EventUuid,
+			PaymentBackend: share.PaymentBackend,
+			TerminalUuid:   share.TerminalUuid,
+			SplitOf:        uuid.NullUUID{UUID: refund.Uuid, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePaymentOutbox(ctx, CreatePaymentOutboxParams{
+			TransactionUuid: transaction.Uuid,
+			Username:        payment.Username,
+			Amount:          amount,
+			Details:         PaymentDetails(transaction.Uuid),
+			Backend:         payment.Backend,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
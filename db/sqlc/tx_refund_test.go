@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+// chargedCheckout writes a cash checkout of the lines and charges it
+func chargedCheckout(t *testing.T, lines ...CheckoutLine) CheckoutTxResult {
+	t.Helper()
+	ctx := context.Background()
+
+	checkout, err := testStore.CheckoutTx(ctx, CheckoutTxParams{PaymentBackend: "cash", Date: time.Now(), Lines: lines})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	payment, err := testStore.GetPaymentOutboxByTransaction(ctx, checkout.Transaction.Uuid)
+	if err != nil {
+		t.Fatalf("get payment: %v", err)
+	}
+
+	checkout.Transaction, err = testStore.CompletePaymentTx(ctx, CompletePaymentTxParams{
+		OutboxUuid:      payment.Uuid,
+		TransactionUuid: checkout.Transaction.Uuid,
+		Status:          TransactionStatusCharged,
+	})
+	if err != nil {
+		t.Fatalf("charge payment: %v", err)
+	}
+	return checkout
+}
+
+func TestRefundTx(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 300, Deposit: 50})
+	stock := getTestArticle(t, article.Uuid).Stock
+
+	checkout := chargedCheckout(t, CheckoutLine{ArticleUuid: article.Uuid, Amount: 3, Price: 300, Deposit: 50, VatRate: 19})
+	sale := checkout.ArticleTransactions[0]
+
+	partial, err := store.RefundTx(ctx, RefundTxParams{
+		TransactionUuid: checkout.Transaction.Uuid,
+		Date:            time.Now(),
+		Lines:           []RefundLine{{ArticleTransactionUuid: sale.Uuid, Amount: 1}},
+	})
+	if err != nil {
+		t.Fatalf("partial refund: %v", err)
+	}
+	if partial.Transaction.Price != -300 {
+		t.Errorf("partial refund is %v, want -3.00", partial.Transaction.Price)
+	}
+	if got := getTestArticle(t, article.Uuid).Stock; got != stock-2 {
+		t.Errorf("stock after the partial refund is %d, want %d", got, stock-2)
+	}
+
+	payment, err := store.GetPaymentOutboxByTransaction(ctx, partial.Transaction.Uuid)
+	if err != nil {
+		t.Fatalf("get refund payment: %v", err)
+	}
+	if payment.Amount != 300 {
+		t.Errorf("refund credits %v, want 3.00", payment.Amount)
+	}
+
+	_, err = store.RefundTx(ctx, RefundTxParams{
+		TransactionUuid: checkout.Transaction.Uuid,
+		Date:            time.Now(),
+		Lines:           []RefundLine{{ArticleTransactionUuid: sale.Uuid, Amount: 3}},
+	})
+	if !errors.Is(err, ErrRefundExceedsAmount) {
+		t.Errorf("refund of more than is left: got %v, want %v", err, ErrRefundExceedsAmount)
+	}
+
+	// without lines the remaining two units and all three deposits are refunded
+	rest, err := store.RefundTx(ctx, RefundTxParams{TransactionUuid: checkout.Transaction.Uuid, Date: time.Now()})
+	if err != nil {
+		t.Fatalf("refund of the rest: %v", err)
+	}
+	if want := util.Money(-750); rest.Transaction.Price != want {
+		t.Errorf("refund of the rest is %v, want %v", rest.Transaction.Price, want)
+	}
+	if got := getTestArticle(t, article.Uuid).Stock; got != stock {
+		t.Errorf("stock after the full refund is %d, want %d", got, stock)
+	}
+
+	_, err = store.RefundTx(ctx, RefundTxParams{TransactionUuid: checkout.Transaction.Uuid, Date: time.Now()})
+	if !errors.Is(err, ErrNotRefundable) {
+		t.Errorf("refund of a refunded transaction: got %v, want %v", err, ErrNotRefundable)
+	}
+
+	_, err = store.RefundTx(ctx, RefundTxParams{TransactionUuid: rest.Transaction.Uuid, Date: time.Now()})
+	if !errors.Is(err, ErrNotRefundable) {
+		t.Errorf("refund of a refund: got %v, want %v", err, ErrNotRefundable)
+	}
+}
+
+func TestRefundTxPendingTransaction(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	article := createTestArticle(t, CreateArticleParams{ResellPrice: 300})
+	checkout, err := store.CheckoutTx(ctx, CheckoutTxParams{
+		PaymentBackend: "cash",
+		Date:           time.Now(),
+		Lines:          []CheckoutLine{{ArticleUuid: article.Uuid, Amount: 1, Price: 300, VatRate: 19}},
+	})
+	if err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+
+	_, err = store.RefundTx(ctx, RefundTxParams{TransactionUuid: checkout.Transaction.Uuid, Date: time.Now()})
+	if !errors.Is(err, ErrNotRefundable) {
+		t.Errorf("got %v, want %v", err, ErrNotRefundable)
+	}
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrShiftNotOpen is returned when a closed shift is closed again
+	ErrShiftNotOpen = errors.New("shift is not open")
+	// ErrInvalidDenomination is returned for counts of notes or coins that do not exist
+	ErrInvalidDenomination = errors.New("invalid denomination")
+)
+
+// Denominations are the euro notes and coins a drawer is counted in
+var Denominations = []util.Money{50000, 20000, 10000, 5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5, 2, 1}
+
+// IsDenomination reports whether the value is a euro note or coin
+func IsDenomination(value util.Money) bool {
+	for _, denomination := range Denominations {
+		if value == denomination {
+			return true
+		}
+	}
+	return false
+}
+
+// ShiftReport is a shift with the counted notes and coins of its drawer.
+// For open shifts the expected cash is the drawer as of now.
+type ShiftReport struct {
+	Shift
+	Counts []ShiftCount `json:"counts"`
+}
+
+// OpenShiftParams contains the input parameters to open a shift
+type OpenShiftParams struct {
+	Bartender    string
+	TerminalUuid uuid.NullUUID
+	EventUuid    uuid.NullUUID // optional, defaults to the event running at the date
+	OpeningFloat util.Money
+	Date         time.Time
+}
+
+// OpenShift opens a shift for the drawer of the terminal, linked to the event
+func (q *Queries) OpenShift(ctx context.Context, arg OpenShiftParams) (Shift, error) {
+	var err error
+	event := arg.EventUuid
+	if !event.Valid {
+		if event, err = q.eventAt(ctx, arg.Date); err != nil {
+			return Shift{}, err
+		}
+	}
+
+	return q.CreateShift(ctx, CreateShiftParams{
+		Bartender:    arg.Bartender,
+		TerminalUuid: arg.TerminalUuid,
+		EventUuid:    event,
+		OpeningFloat: arg.OpeningFloat,
+	})
+}
+
+// CloseShiftTxParams contains the input parameters of the close shift transaction
+type CloseShiftTxParams struct {
+	ShiftUuid uuid.UUID
+	Counts    []CreateShiftCountParams // the shift uuid is filled in
+	Date      time.Time
+}
+
+// CloseShiftTx records the counted drawer and closes the shift. The expected
+// cash is the opening float plus the charged cash transactions of the
+// terminal while the shift was open, refunds paid out in cash reduce it.
+// Shifts without a terminal take the cash sold without a terminal.
+func (store *Store) CloseShiftTx(ctx context.Context, arg CloseShiftTxParams) (ShiftReport, error) {
+	var result ShiftReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		shift, err := q.GetShiftByIdForUpdate(ctx, arg.ShiftUuid)
+		if err != nil {
+			return err
+		}
+
+		if shift.ClosedAt.Valid {
+			return ErrShiftNotOpen
+		}
+
+		expected, err := q.expectedCash(ctx, shift, arg.Date)
+		if err != nil {
+			return err
+		}
+
+		var counted util.Money
+		result.Counts = make([]ShiftCount, 0, len(arg.Counts))
+		for _, count := range arg.Counts {
+			if !IsDenomination(count.Denomination) {
+				return ErrInvalidDenomination
+			}
+
+			count.ShiftUuid = shift.Uuid
+			row, err := q.CreateShiftCount(ctx, count)
+			if err != nil {
+				return err
+			}
+			result.Counts = append(result.Counts, row)
+			counted += count.Denomination.Mul(count.Count)
+		}
+
+		result.Shift, err = q.CloseShift(ctx, CloseShiftParams{
+			ClosedAt:     arg.Date,
+			ExpectedCash: expected,
+			CountedCash:  counted,
+			Uuid:         shift.Uuid,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// GetShiftReport returns the shift with its counts, open shifts with the cash expected as of now
+func (q *Queries) GetShiftReport(ctx context.Context, shiftUuid uuid.UUID) (ShiftReport, error) {
+	var result ShiftReport
+
+	var err error
+	result.Shift, err = q.GetShiftById(ctx, shiftUuid)
+	if err != nil {
+		return result, err
+	}
+
+	if !result.ClosedAt.Valid {
+		expected, err := q.expectedCash(ctx, result.Shift, time.Now())
+		if err != nil {
+			return result, err
+		}
+		result.ExpectedCash = util.NullMoneyFrom(expected)
+	}
+
+	result.Counts, err = q.GetShiftCounts(ctx, shiftUuid)
+	return result, err
+}
+
+// expectedCash is the cash that should be in the drawer of the shift at the given time
+func (q *Queries) expectedCash(ctx context.Context, shift Shift, until time.Time) (util.Money, error) {
+	taken, err := q.GetShiftCashTotal(ctx, GetShiftCashTotalParams{
+		From:         shift.OpenedAt,
+		Until:        until,
+		TerminalUuid: shift.TerminalUuid,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return shift.OpeningFloat + taken, nil
+}
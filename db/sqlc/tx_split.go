@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// PaymentBackendSplit marks the parent transaction of a split bill, it is
+// never charged itself
+const PaymentBackendSplit = "split"
+
+// SplitShare is the part of a split bill paid by one resident
+type SplitShare struct {
+	Resident       string
+	PaymentBackend string
+	Amount         util.Money
+	// Credit is checked against the amount of the share, nil to skip the check
+	Credit *CheckCreditParams
+}
+
+// SplitCheckoutTxParams contains the input parameters of the split checkout transaction
+type SplitCheckoutTxParams struct {
+	TerminalUuid uuid.NullUUID
+	Date         time.Time
+	Lines        []CheckoutLine
+	Shares       []SplitShare
+}
+
+// SplitCheckoutTxResult is the result of the split checkout transaction
+type SplitCheckoutTxResult struct {
+	Transaction         Transaction          `json:"transaction"`
+	ArticleTransactions []ArticleTransaction `json:"article_transactions"`
+	Shares              []Transaction        `json:"shares"`
+	Vat                 []VatBreakdown       `json:"vat"`
+	StockWarnings       []StockWarning       `json:"stock_warnings,omitempty"`
+}
+
+// SplitCheckoutTx writes a parent transaction with the article transactions
+// of the cart and a share transaction linked to it for every resident. Every
+// share is debited separately, if one of them fails the others are cancelled
+// or paid back, see settleSplit. The shares have to sum up to the total.
+func (store *Store) SplitCheckoutTx(ctx context.Context, arg SplitCheckoutTxParams) (SplitCheckoutTxResult, error) {
+	var result SplitCheckoutTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		parent, err := q.createTransactionWithLines(ctx, CheckoutTxParams{
+			TerminalUuid:   arg.TerminalUuid,
+			PaymentBackend: PaymentBackendSplit,
+			Date:           arg.Date,
+			Lines:          arg.Lines,
+		})
+		if err != nil {
+			return err
+		}
+		result.Transaction = parent.Transaction
+		result.ArticleTransactions = parent.ArticleTransactions
+		result.Vat = parent.Vat
+		result.StockWarnings = parent.StockWarnings
+
+		result.Shares = make([]Transaction, 0, len(arg.Shares))
+		for _, share := range arg.Shares {
+			if share.Credit != nil {
+				credit := *share.Credit
+				credit.Amount = share.Amount
+				if err := q.CheckCredit(ctx, credit); err != nil {
+					return err
+				}
+			}
+
+			transaction, err := q.CreateTransaction(ctx, CreateTransactionParams{
+				Date:           arg.Date,
+				Price:          share.Amount,
+				Resident:       null.StringFrom(share.Resident),
+				EventUuid:      parent.Transaction.EventUuid,
+				PaymentBackend: share.PaymentBackend,
+				TerminalUuid:   arg.TerminalUuid,
+				SplitOf:        uuid.NullUUID{UUID: parent.Transaction.Uuid, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreatePaymentOutbox(ctx, CreatePaymentOutboxParams{
+				TransactionUuid: transaction.Uuid,
+				Username:        transaction.Resident,
+				Amount:          -share.Amount,
+				Details:         PaymentDetails(transaction.Uuid),
+				Backend:         share.PaymentBackend,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Shares = append(result.Shares, transaction)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// settleSplit updates the parent of a share after the payment of the share
+// completed. The parent is charged once all shares are. As soon as one share
+// of a bill failed, pending shares are cancelled and charged ones are paid
+// back with a reversal, so either all residents pay or none. Refunds of split
+// bills only track their status, a failed credit is not undone.
+func (q *Queries) settleSplit(ctx context.Context, share Transaction) error {
+	parent, err := q.GetTransactionByIdForUpdate(ctx, share.SplitOf.UUID)
+	if err != nil {
+		return err
+	}
+
+	shares, err := q.GetSplitShares(ctx, uuid.NullUUID{UUID: parent.Uuid, Valid: true})
+	if err != nil {
+		return err
+	}
+
+	status := TransactionStatusCharged
+	for _, s := range shares {
+		if s.Status == TransactionStatusFailed {
+			status = TransactionStatusFailed
+			break
+		}
+		if s.Status == TransactionStatusPending {
+			status = TransactionStatusPending
+		}
+	}
+
+	if _, err = q.setTransactionStatus(ctx, UpdateTransactionStatusParams{Status: status, Uuid: parent.Uuid}); err != nil {
+		return err
+	}
+
+	if status != TransactionStatusFailed || parent.RefundOf.Valid {
+		return nil
+	}
+
+	for _, s := range shares {
+		entry, err := q.GetPaymentOutboxByTransaction(ctx, s.Uuid)
+		if err != nil {
+			return err
+		}
+
+		// already paid back, or waiting for a reconciliation that decides it
+		if entry.Reversal || entry.NeedsReconciliation {
+			continue
+		}
+
+		switch s.Status {
+		case TransactionStatusPending:
+			if err = q.MarkPaymentOutboxProcessed(ctx, entry.Uuid); err != nil {
+				return err
+			}
+			_, err = q.setTransactionStatus(ctx, UpdateTransactionStatusParams{Status: TransactionStatusFailed, Uuid: s.Uuid})
+		case TransactionStatusCharged:
+			_, err = q.CreatePaymentOutbox(ctx, CreatePaymentOutboxParams{
+				TransactionUuid: s.Uuid,
+				Username:        entry.Username,
+				Amount:          -entry.Amount,
+				Details:         "rupay_reversal:" + s.Uuid.String(),
+				Backend:         entry.Backend,
+				Reversal:        true,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// ErrStocktakeNotOpen is returned when articles are counted for a closed stocktake or it is closed again
+var ErrStocktakeNotOpen = errors.New("stocktake is not open")
+
+// StocktakeLineReport is the expected and counted stock of an article. The
+// variance is only known once the article was counted, a negative variance
+// is shrinkage. The value is the variance at the purchase price the article
+// had when the stocktake was opened.
+type StocktakeLineReport struct {
+	GetStocktakeLinesRow
+	Variance      null.Int32     `json:"variance"`
+	VarianceValue util.NullMoney `json:"variance_value"`
+}
+
+// StocktakeVariance sums up the variance of the counted articles, the
+// shrinkage is the value of the missing units only
+type StocktakeVariance struct {
+	Counted       int32      `json:"counted"`
+	Variance      int32      `json:"variance"`
+	VarianceValue util.Money `json:"variance_value"`
+	Shrinkage     util.Money `json:"shrinkage"`
+}
+
+// StocktakeArticleTypeVariance is the variance of the articles of an article type
+type StocktakeArticleTypeVariance struct {
+	ArticleTypeUuid uuid.UUID `json:"article_type_uuid"`
+	Name            string    `json:"name"`
+	StocktakeVariance
+}
+
+// StocktakeReport is a stocktake with the variance of every article, per
+// article type and in total
+type StocktakeReport struct {
+	Stocktake
+	Lines        []StocktakeLineReport          `json:"lines"`
+	ArticleTypes []StocktakeArticleTypeVariance `json:"article_types"`
+	Total        StocktakeVariance              `json:"total"`
+}
+
+func (v *StocktakeVariance) add(line StocktakeLineReport) {
+	v.Counted++
+	v.Variance += line.Variance.Int32
+	v.VarianceValue += line.VarianceValue.Money
+	if line.Variance.Int32 < 0 {
+		v.Shrinkage -= line.VarianceValue.Money
+	}
+}
+
+// OpenStocktakeTx opens a stocktake and snapshots the stock and purchase
+// price of every article, bundles have no stock of their own and are not
+// counted. A stocktake of a location expects the stock at the location,
+// otherwise the total stock of all locations.
+func (store *Store) OpenStocktakeTx(ctx context.Context, arg CreateStocktakeParams) (StocktakeReport, error) {
+	var result StocktakeReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		stocktake, err := q.CreateStocktake(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		err = q.CreateStocktakeLines(ctx, CreateStocktakeLinesParams{StocktakeUuid: stocktake.Uuid})
+		if err != nil {
+			return err
+		}
+
+		result, err = q.GetStocktakeReport(ctx, stocktake.Uuid)
+		return err
+	})
+
+	return result, err
+}
+
+// StocktakeCount is the counted stock of an article. By default the count
+// is added to what was counted before, so an article stored in several
+// places can be counted in partial submissions. Replace overwrites earlier
+// counts, e.g. to fix a mistake.
+type StocktakeCount struct {
+	ArticleUuid uuid.UUID
+	Counted     int32
+	Replace     bool
+}
+
+// CountStocktakeTx records counted stock for an open stocktake. Articles
+// created after the stocktake was opened are snapshot when they are first
+// counted. Counting an unknown article or a bundle returns sql.ErrNoRows.
+func (store *Store) CountStocktakeTx(ctx context.Context, stocktakeUuid uuid.UUID, counts []StocktakeCount) (StocktakeReport, error) {
+	var result StocktakeReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		stocktake, err := q.GetStocktakeByIdForUpdate(ctx, stocktakeUuid)
+		if err != nil {
+			return err
+		}
+
+		if stocktake.ClosedAt.Valid {
+			return ErrStocktakeNotOpen
+		}
+
+		for _, count := range counts {
+			err = q.CreateStocktakeLines(ctx, CreateStocktakeLinesParams{
+				StocktakeUuid: stocktake.Uuid,
+				ArticleUuid:   uuid.NullUUID{UUID: count.ArticleUuid, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CountStocktakeLine(ctx, CountStocktakeLineParams{
+				Replace:       count.Replace,
+				Counted:       count.Counted,
+				StocktakeUuid: stocktake.Uuid,
+				ArticleUuid:   count.ArticleUuid,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = q.GetStocktakeReport(ctx, stocktake.Uuid)
+		return err
+	})
+
+	return result, err
+}
+
+// CloseStocktakeTx closes the stocktake and sets the stock of every counted
+// article to the counted units, at the location of the stocktake or in total
+// at the default location. The count is taken as the stock at closing, units
+// sold while the stocktake was open are already out of the shelf and not
+// taken again. Articles that were not counted keep their stock.
+func (store *Store) CloseStocktakeTx(ctx context.Context, stocktakeUuid uuid.UUID) (StocktakeReport, error) {
+	var result StocktakeReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		stocktake, err := q.GetStocktakeByIdForUpdate(ctx, stocktakeUuid)
+		if err != nil {
+			return err
+		}
+
+		if stocktake.ClosedAt.Valid {
+			return ErrStocktakeNotOpen
+		}
+
+		lines, err := q.GetStocktakeLines(ctx, stocktake.Uuid)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			if !line.Counted.Valid {
+				continue
+			}
+
+			stock, err := q.GetArticleStockForUpdate(ctx, GetArticleStockForUpdateParams{
+				LocationUuid: stocktake.LocationUuid,
+				ArticleUuid:  line.ArticleUuid,
+			})
+			if err != nil {
+				return err
+			}
+			if line.Counted.Int32 == stock {
+				continue
+			}
+
+			_, err = q.MoveStock(ctx, MoveStockParams{
+				ArticleUuid:   line.ArticleUuid,
+				Quantity:      line.Counted.Int32 - stock,
+				Reason:        StockMovementStocktake,
+				LocationUuid:  stocktake.LocationUuid,
+				StocktakeUuid: uuid.NullUUID{UUID: stocktake.Uuid, Valid: true},
+				Note:          stocktake.Note,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err = q.CloseStocktake(ctx, stocktake.Uuid); err != nil {
+			return err
+		}
+
+		result, err = q.GetStocktakeReport(ctx, stocktake.Uuid)
+		return err
+	})
+
+	return result, err
+}
+
+// GetStocktakeReport returns the stocktake with the variance of the counted
+// articles, per article type and in total
+func (q *Queries) GetStocktakeReport(ctx context.Context, stocktakeUuid uuid.UUID) (StocktakeReport, error) {
+	var result StocktakeReport
+
+	var err error
+	result.Stocktake, err = q.GetStocktakeById(ctx, stocktakeUuid)
+	if err != nil {
+		return result, err
+	}
+
+	lines, err := q.GetStocktakeLines(ctx, stocktakeUuid)
+	if err != nil {
+		return result, err
+	}
+
+	result.Lines = make([]StocktakeLineReport, 0, len(lines))
+	result.ArticleTypes = []StocktakeArticleTypeVariance{}
+	byType := make(map[uuid.UUID]int)
+	for _, row := range lines {
+		line := StocktakeLineReport{GetStocktakeLinesRow: row}
+		if row.Counted.Valid {
+			variance := row.Counted.Int32 - row.Expected
+			line.Variance = null.Int32From(variance)
+			line.VarianceValue = util.NullMoneyFrom(row.UnitCost.Mul(variance))
+
+			i, ok := byType[row.ArticleTypeUuid]
+			if !ok {
+				i = len(result.ArticleTypes)
+				byType[row.ArticleTypeUuid] = i
+				result.ArticleTypes = append(result.ArticleTypes, StocktakeArticleTypeVariance{ArticleTypeUuid: row.ArticleTypeUuid, Name: row.ArticleType})
+			}
+			result.ArticleTypes[i].add(line)
+			result.Total.add(line)
+		}
+		result.Lines = append(result.Lines, line)
+	}
+
+	return result, nil
+}
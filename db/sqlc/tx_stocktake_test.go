@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+func TestStocktakeVarianceAdd(t *testing.T) {
+	lines := []StocktakeLineReport{
+		{Variance: null.Int32From(-2), VarianceValue: util.NullMoneyFrom(-300)},
+		{Variance: null.Int32From(1), VarianceValue: util.NullMoneyFrom(150)},
+		{Variance: null.Int32From(0), VarianceValue: util.NullMoneyFrom(0)},
+	}
+
+	var got StocktakeVariance
+	for _, line := range lines {
+		got.add(line)
+	}
+
+	// surplus makes up for missing units in the variance but not in the shrinkage
+	want := StocktakeVariance{Counted: 3, Variance: -1, VarianceValue: -150, Shrinkage: 300}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCloseStocktakeTx(t *testing.T) {
+	store := requireStore(t)
+	ctx := context.Background()
+
+	counted := createTestArticle(t, CreateArticleParams{PurchasePrice: 150, ResellPrice: 300})
+	replaced := createTestArticle(t, CreateArticleParams{PurchasePrice: 100, ResellPrice: 200})
+	uncounted := createTestArticle(t, CreateArticleParams{PurchasePrice: 100, ResellPrice: 200})
+
+	for _, delivery := range []struct {
+		article  Article
+		quantity int32
+	}{{counted, 10}, {replaced, 6}, {uncounted, 4}} {
+		_, err := store.MoveStock(ctx, MoveStockParams{ArticleUuid: delivery.article.Uuid, Quantity: delivery.quantity, Reason: StockMovementDelivery})
+		if err != nil {
+			t.Fatalf("deliver stock: %v", err)
+		}
+	}
+
+	stocktake, err := store.OpenStocktakeTx(ctx, CreateStocktakeParams{})
+	if err != nil {
+		t.Fatalf("open stocktake: %v", err)
+	}
+
+	// partial counts add up, a replacing count fixes a mistake
+	counts := [][]StocktakeCount{
+		{{ArticleUuid: counted.Uuid, Counted: 4}, {ArticleUuid: replaced.Uuid, Counted: 9}},
+		{{ArticleUuid: counted.Uuid, Counted: 3}, {ArticleUuid: replaced.Uuid, Counted: 5, Replace: true}},
+	}
+	for _, count := range counts {
+		if _, err := store.CountStocktakeTx(ctx, stocktake.Uuid, count); err != nil {
+			t.Fatalf("count: %v", err)
+		}
+	}
+
+	// units sold while the stocktake is open are off the shelf already
+	chargedCheckout(t, CheckoutLine{ArticleUuid: counted.Uuid, Amount: 2, Price: 300, VatRate: 19})
+
+	report, err := store.CloseStocktakeTx(ctx, stocktake.Uuid)
+	if err != nil {
+		t.Fatalf("close stocktake: %v", err)
+	}
+
+	lines := make(map[uuid.UUID]StocktakeLineReport)
+	for _, line := range report.Lines {
+		lines[line.ArticleUuid] = line
+	}
+
+	tests := []struct {
+		article  Article
+		variance null.Int32
+		value    util.NullMoney
+		stock    int32
+	}{
+		{article: counted, variance: null.Int32From(-3), value: util.NullMoneyFrom(-450), stock: 7},
+		{article: replaced, variance: null.Int32From(-1), value: util.NullMoneyFrom(-100), stock: 5},
+		{article: uncounted, stock: 4},
+	}
+
+	for _, tt := range tests {
+		line := lines[tt.article.Uuid]
+		if line.Variance != tt.variance || line.VarianceValue != tt.value {
+			t.Errorf("%s: got a variance of %v worth %v, want %v worth %v", tt.article.Name, line.Variance, line.VarianceValue, tt.variance, tt.value)
+		}
+		if got := getTestArticle(t, tt.article.Uuid).Stock; got != tt.stock {
+			t.Errorf("%s: stock is %d, want %d", tt.article.Name, got, tt.stock)
+		}
+	}
+
+	if _, err := store.CloseStocktakeTx(ctx, stocktake.Uuid); !errors.Is(err, ErrStocktakeNotOpen) {
+		t.Errorf("closing again: got %v, want %v", err, ErrStocktakeNotOpen)
+	}
+	if _, err := store.CountStocktakeTx(ctx, stocktake.Uuid, counts[0]); !errors.Is(err, ErrStocktakeNotOpen) {
+		t.Errorf("counting a closed stocktake: got %v, want %v", err, ErrStocktakeNotOpen)
+	}
+}
@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+// Possible states of a tab, flagged tabs were left open after their event
+// ended and could not be closed automatically
+const (
+	TabStatusOpen    = "open"
+	TabStatusFlagged = "flagged"
+	TabStatusClosed  = "closed"
+)
+
+// ErrTabNotOpen is returned when items are added to or a closed tab is closed again
+var ErrTabNotOpen = errors.New("tab is not open")
+
+// TabTxResult is a tab with all of its items
+type TabTxResult struct {
+	Tab   Tab       `json:"tab"`
+	Items []TabItem `json:"items"`
+}
+
+// OpenTabParams contains the input parameters to open a tab
+type OpenTabParams struct {
+	Resident       string
+	TerminalUuid   uuid.NullUUID
+	PaymentBackend string
+	Date           time.Time
+}
+
+// OpenTab opens a tab for the resident that belongs to the event running at the date
+func (q *Queries) OpenTab(ctx context.Context, arg OpenTabParams) (Tab, error) {
+	event, err := q.eventAt(ctx, arg.Date)
+	if err != nil {
+		return Tab{}, err
+	}
+
+	return q.CreateTab(ctx, CreateTabParams{
+		Resident:       arg.Resident,
+		TerminalUuid:   arg.TerminalUuid,
+		EventUuid:      event,
+		PaymentBackend: arg.PaymentBackend,
+	})
+}
+
+// AddTabItemsTx adds the lines to an open tab. The prices of the lines are
+// reserved, closing the tab charges them even if the article got more expensive.
+// The stock is checked when the items are added, it is taken when the tab is closed.
+// The credit of the resident is checked against the lines unless it is nil.
+func (store *Store) AddTabItemsTx(ctx context.Context, tabUuid uuid.UUID, lines []CheckoutLine, credit *CheckCreditParams) (TabTxResult, error) {
+	var result TabTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Tab, err = q.GetTabByIdForUpdate(ctx, tabUuid)
+		if err != nil {
+			return err
+		}
+
+		if result.Tab.Status != TabStatusOpen {
+			return ErrTabNotOpen
+		}
+
+		if credit != nil {
+			check := *credit
+			check.Amount = checkoutTotal(lines)
+			if err := q.CheckCredit(ctx, check); err != nil {
+				return err
+			}
+		}
+
+		for _, line := range lines {
+			if err := q.checkStock(ctx, line.ArticleUuid, line.Amount); err != nil {
+				return err
+			}
+
+			_, err = q.CreateTabItem(ctx, CreateTabItemParams{
+				TabUuid:         tabUuid,
+				ArticleUuid:     line.ArticleUuid,
+				Amount:          line.Amount,
+				Price:           line.Price,
+				PricingRuleUuid: line.PricingRuleUuid,
+				Deposit:         line.Deposit,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Items, err = q.GetTabItemsByTab(ctx, tabUuid)
+		return err
+	})
+
+	return result, err
+}
+
+// CloseTabTxParams contains the input parameters of the close tab transaction
+type CloseTabTxParams struct {
+	TabUuid uuid.UUID
+	Date    time.Time
+}
+
+// CloseTabTx settles all items of an open or flagged tab with a single
+// transaction and a single debit. Items of the same article and price are
+// merged into one article transaction. An empty tab is closed without a
+// transaction. Like every sale the settlement can not be dated inside a
+// closed business day.
+func (store *Store) CloseTabTx(ctx context.Context, arg CloseTabTxParams) (TabTxResult, error) {
+	var result TabTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		tab, err := q.GetTabByIdForUpdate(ctx, arg.TabUuid)
+		if err != nil {
+			return err
+		}
+
+		if tab.Status == TabStatusClosed {
+			return ErrTabNotOpen
+		}
+
+		result.Items, err = q.GetTabItemsByTab(ctx, tab.Uuid)
+		if err != nil {
+			return err
+		}
+
+		if len(result.Items) == 0 {
+			result.Tab, err = q.CloseTab(ctx, CloseTabParams{Uuid: tab.Uuid})
+			return err
+		}
+
+		lines := make([]CheckoutLine, 0, len(result.Items))
+		merged := make(map[CheckoutLine]int)
+		for _, item := range result.Items {
+			key := CheckoutLine{ArticleUuid: item.ArticleUuid, Price: item.Price, PricingRuleUuid: item.PricingRuleUuid, Deposit: item.Deposit}
+			if i, ok := merged[key]; ok {
+				lines[i].Amount += item.Amount
+				continue
+			}
+			merged[key] = len(lines)
+			key.Amount = item.Amount
+			lines = append(lines, key)
+		}
+
+		// the VAT is due when the tab is charged, so the rates of today apply
+		for i := range lines {
+			article, err := q.GetArticleById(ctx, lines[i].ArticleUuid)
+			if err != nil {
+				return err
+			}
+			if lines[i].VatRate, err = q.GetVatRate(ctx, article); err != nil {
+				return err
+			}
+		}
+
+		checkout, err := q.checkout(ctx, CheckoutTxParams{
+			Resident:        null.StringFrom(tab.Resident),
+			TerminalUuid:    tab.TerminalUuid,
+			PaymentBackend:  tab.PaymentBackend,
+			EventUuid:       tab.EventUuid,
+			Date:            arg.Date,
+			Lines:           lines,
+			AllowOutOfStock: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Tab, err = q.CloseTab(ctx, CloseTabParams{
+			TransactionUuid: uuid.NullUUID{UUID: checkout.Transaction.Uuid, Valid: true},
+			Uuid:            tab.Uuid,
+		})
+		return err
+	})
+
+	return result, err
+}
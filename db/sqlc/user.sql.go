@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	null "github.com/guregu/null/v5"
 )
 
@@ -17,7 +18,7 @@ INSERT INTO resident (
     code
 ) VALUES (
     $1, $2
-) RETURNING name, code
+) RETURNING name, code, payment_backend, group_name, min_balance, daily_cap
 `
 
 type CreateUserParams struct {
@@ -28,7 +29,14 @@ type CreateUserParams struct {
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (Resident, error) {
 	row := q.queryRow(ctx, q.createUserStmt, createUser, arg.Name, arg.Code)
 	var i Resident
-	err := row.Scan(&i.Name, &i.Code)
+	err := row.Scan(
+		&i.Name,
+		&i.Code,
+		&i.PaymentBackend,
+		&i.GroupName,
+		&i.MinBalance,
+		&i.DailyCap,
+	)
 	return i, err
 }
 
@@ -43,31 +51,65 @@ func (q *Queries) DeleteUser(ctx context.Context, name string) error {
 }
 
 const getUserByCode = `-- name: GetUserByCode :one
-SELECT name, code FROM resident
+SELECT name, code, payment_backend, group_name, min_balance, daily_cap FROM resident
 WHERE code = $1 LIMIT 1
 `
 
 func (q *Queries) GetUserByCode(ctx context.Context, code string) (Resident, error) {
 	row := q.queryRow(ctx, q.getUserByCodeStmt, getUserByCode, code)
 	var i Resident
-	err := row.Scan(&i.Name, &i.Code)
+	err := row.Scan(
+		&i.Name,
+		&i.Code,
+		&i.PaymentBackend,
+		&i.GroupName,
+		&i.MinBalance,
+		&i.DailyCap,
+	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT name, code FROM resident
+SELECT name, code, payment_backend, group_name, min_balance, daily_cap FROM resident
 WHERE name = $1 LIMIT 1
 `
 
 func (q *Queries) GetUserById(ctx context.Context, name string) (Resident, error) {
 	row := q.queryRow(ctx, q.getUserByIdStmt, getUserById, name)
 	var i Resident
-	err := row.Scan(&i.Name, &i.Code)
+	err := row.Scan(
+		&i.Name,
+		&i.Code,
+		&i.PaymentBackend,
+		&i.GroupName,
+		&i.MinBalance,
+		&i.DailyCap,
+	)
+	return i, err
+}
+
+const getUserByIdForUpdate = `-- name: GetUserByIdForUpdate :one
+SELECT name, code, payment_backend, group_name, min_balance, daily_cap FROM resident
+WHERE name = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetUserByIdForUpdate(ctx context.Context, name string) (Resident, error) {
+	row := q.queryRow(ctx, q.getUserByIdForUpdateStmt, getUserByIdForUpdate, name)
+	var i Resident
+	err := row.Scan(
+		&i.Name,
+		&i.Code,
+		&i.PaymentBackend,
+		&i.GroupName,
+		&i.MinBalance,
+		&i.DailyCap,
+	)
 	return i, err
 }
 
 const getUsers = `-- name: GetUsers :many
-SELECT name, code FROM resident
+SELECT name, code, payment_backend, group_name, min_balance, daily_cap FROM resident
 `
 
 func (q *Queries) GetUsers(ctx context.Context) ([]Resident, error) {
@@ -79,7 +121,14 @@ func (q *Queries) GetUsers(ctx context.Context) ([]Resident, error) {
 	items := []Resident{}
 	for rows.Next() {
 		var i Resident
-		if err := rows.Scan(&i.Name, &i.Code); err != nil {
+		if err := rows.Scan(
+			&i.Name,
+			&i.Code,
+			&i.PaymentBackend,
+			&i.GroupName,
+			&i.MinBalance,
+			&i.DailyCap,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -97,19 +146,41 @@ const updateUser = `-- name: UpdateUser :one
 UPDATE resident
 SET
     name = COALESCE($1, "name"),
-    code = COALESCE($2, code)
+    code = COALESCE($2, code),
+    payment_backend = COALESCE($3, payment_backend),
+    group_name = COALESCE($4, group_name),
+    min_balance = COALESCE($5, min_balance),
+    daily_cap = COALESCE($6, daily_cap)
 WHERE name = $1
-RETURNING name, code
+RETURNING name, code, payment_backend, group_name, min_balance, daily_cap
 `
 
 type UpdateUserParams struct {
-	Name null.String `json:"name"`
-	Code null.String `json:"code"`
+	Name           null.String    `json:"name"`
+	Code           null.String    `json:"code"`
+	PaymentBackend null.String    `json:"payment_backend"`
+	GroupName      null.String    `json:"group_name"`
+	MinBalance     util.NullMoney `json:"min_balance"`
+	DailyCap       util.NullMoney `json:"daily_cap"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (Resident, error) {
-	row := q.queryRow(ctx, q.updateUserStmt, updateUser, arg.Name, arg.Code)
+	row := q.queryRow(ctx, q.updateUserStmt, updateUser,
+		arg.Name,
+		arg.Code,
+		arg.PaymentBackend,
+		arg.GroupName,
+		arg.MinBalance,
+		arg.DailyCap,
+	)
 	var i Resident
-	err := row.Scan(&i.Name, &i.Code)
+	err := row.Scan(
+		&i.Name,
+		&i.Code,
+		&i.PaymentBackend,
+		&i.GroupName,
+		&i.MinBalance,
+		&i.DailyCap,
+	)
 	return i, err
 }
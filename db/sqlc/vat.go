@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"sort"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+// DefaultVatRate is the rate of article types created without one
+const DefaultVatRate int32 = 19
+
+// VatBreakdown sums up the lines of a transaction sold at the same VAT rate
+type VatBreakdown struct {
+	VatRate int32      `json:"vat_rate"`
+	Gross   util.Money `json:"gross"`
+	Net     util.Money `json:"net"`
+	Tax     util.Money `json:"tax"`
+}
+
+// TransactionWithVat is a transaction with the VAT breakdown of its lines
+type TransactionWithVat struct {
+	Transaction
+	Vat []VatBreakdown `json:"vat"`
+}
+
+// GetVatRate returns the VAT rate of the article, else the one of its type
+func (q *Queries) GetVatRate(ctx context.Context, article Article) (int32, error) {
+	if article.VatRate.Valid {
+		return article.VatRate.Int32, nil
+	}
+
+	articleType, err := q.GetArticleTypeById(ctx, article.ArticleTypeUuid)
+	if err != nil {
+		return 0, err
+	}
+	return articleType.VatRate, nil
+}
+
+// SplitVat splits a gross amount into its net and tax amounts, the net
+// amount is rounded to the nearest cent
+func SplitVat(gross util.Money, rate int32) (net util.Money, tax util.Money) {
+	divisor := util.Money(100 + rate)
+	if gross < 0 {
+		net = -((-gross*100 + divisor/2) / divisor)
+	} else {
+		net = (gross*100 + divisor/2) / divisor
+	}
+	return net, gross - net
+}
+
+// GetVatBreakdown sums up the stored net and tax amounts of the lines per
+// rate. The amounts were fixed when the lines were written, so later rate
+// changes do not alter them.
+func GetVatBreakdown(lines []ArticleTransaction) []VatBreakdown {
+	byRate := make(map[int32]VatBreakdown)
+	for _, line := range lines {
+		if line.Kind == ArticleTransactionComponent {
+			continue
+		}
+		rate := byRate[line.VatRate]
+		rate.VatRate = line.VatRate
+		rate.Gross += line.Price.Mul(line.Amount)
+		rate.Net += line.Net
+		rate.Tax += line.Tax
+		byRate[line.VatRate] = rate
+	}
+
+	breakdown := make([]VatBreakdown, 0, len(byRate))
+	for _, rate := range byRate {
+		breakdown = append(breakdown, rate)
+	}
+	sort.Slice(breakdown, func(i, j int) bool { return breakdown[i].VatRate < breakdown[j].VatRate })
+
+	return breakdown
+}
+
+// GetTransactionWithVat returns the transaction with the VAT breakdown of its lines
+func (q *Queries) GetTransactionWithVat(ctx context.Context, transactionUuid uuid.UUID) (TransactionWithVat, error) {
+	transaction, err := q.GetTransactionById(ctx, transactionUuid)
+	if err != nil {
+		return TransactionWithVat{}, err
+	}
+
+	lines, err := q.GetArticleTransactionsByTransaction(ctx, transactionUuid)
+	if err != nil {
+		return TransactionWithVat{}, err
+	}
+
+	return TransactionWithVat{transaction, GetVatBreakdown(lines)}, nil
+}
+
+// GetTransactionsWithVat returns the filtered transactions with the VAT
+// breakdown of their lines
+func (q *Queries) GetTransactionsWithVat(ctx context.Context, arg GetTransactionsParams) ([]TransactionWithVat, error) {
+	transactions, err := q.GetTransactions(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := q.GetArticleTransactionsOfTransactions(ctx, GetArticleTransactionsOfTransactionsParams{
+		Resident:  arg.Resident,
+		EventUuid: arg.EventUuid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byTransaction := make(map[uuid.UUID][]ArticleTransaction)
+	for _, line := range lines {
+		byTransaction[line.TransactionUuid] = append(byTransaction[line.TransactionUuid], line)
+	}
+
+	result := make([]TransactionWithVat, 0, len(transactions))
+	for _, transaction := range transactions {
+		result = append(result, TransactionWithVat{transaction, GetVatBreakdown(byTransaction[transaction.Uuid])})
+	}
+
+	return result, nil
+}
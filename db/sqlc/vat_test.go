@@ -0,0 +1,34 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+func TestSplitVat(t *testing.T) {
+	tests := []struct {
+		gross util.Money
+		rate  int32
+		net   util.Money
+		tax   util.Money
+	}{
+		{gross: 119, rate: 19, net: 100, tax: 19},
+		{gross: 100, rate: 19, net: 84, tax: 16},
+		{gross: -100, rate: 19, net: -84, tax: -16},
+		{gross: 107, rate: 7, net: 100, tax: 7},
+		{gross: 1, rate: 19, net: 1, tax: 0},
+		{gross: 350, rate: 0, net: 350, tax: 0},
+		{gross: 0, rate: 19, net: 0, tax: 0},
+	}
+
+	for _, tt := range tests {
+		net, tax := SplitVat(tt.gross, tt.rate)
+		if net != tt.net || tax != tt.tax {
+			t.Errorf("SplitVat(%v, %d) = %v, %v, want %v, %v", tt.gross, tt.rate, net, tax, tt.net, tt.tax)
+		}
+		if net+tax != tt.gross {
+			t.Errorf("SplitVat(%v, %d) loses cents: %v + %v", tt.gross, tt.rate, net, tax)
+		}
+	}
+}
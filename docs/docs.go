@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/article": {
             "post": {
-                "description": "Create a new article with the provided payload",
+                "description": "Create a new article with the provided payload, its prices start the price history",
                 "consumes": [
                     "application/json"
                 ],
@@ -93,7 +93,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new article transaction with the provided payload",
+                "description": "Add a sale line with its component and deposit lines to a pending transaction whose payment did not start yet, the total of the transaction is updated.\nThe price is calculated by the server, an optional price sent by the client has to match. The new total is checked against the credit of the resident.",
                 "consumes": [
                     "application/json"
                 ],
@@ -111,15 +111,15 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/db.ArticleTransaction"
+                            "$ref": "#/definitions/schemas.CreateArticleTransaction"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ArticleTransaction data",
+                        "description": "ArticleTransaction data with its component and deposit lines and stock warnings",
                         "schema": {
-                            "$ref": "#/definitions/db.ArticleTransaction"
+                            "$ref": "#/definitions/db.ArticleTransactionTxResult"
                         }
                     },
                     "400": {
@@ -127,6 +127,117 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
+                    },
+                    "402": {
+                        "description": "Credit policy blocks the new total",
+                        "schema": {
+                            "$ref": "#/definitions/e.InsufficientFundsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Article or transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction is not pending, business day is closed or article is out of stock or archived",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Price mismatch or total not positive",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/article-transaction/deposits": {
+            "get": {
+                "description": "Sum up the deposits charged minus the deposits returned per article of charged transactions, these are liabilities and not part of the revenue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ArticleTransactions"
+                ],
+                "summary": "Retrieve the outstanding deposits",
+                "responses": {
+                    "200": {
+                        "description": "Outstanding bottles and deposit per article",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.GetDepositLiabilitiesRow"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve ArticleTransactions",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/article-transaction/grouped-by-article": {
+            "get": {
+                "description": "Sum up the amount and revenue sold per article. Bundles are counted as their components,\nthe revenue of a bundle is split in proportion to the resell price of its components at the time of the sale.\nThe cost is the purchase price of the units at the time of their sale, the profit is the revenue minus the cost.\nOnly charged transactions are counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ArticleTransactions"
+                ],
+                "summary": "Retrieve article transactions grouped by article",
+                "responses": {
+                    "200": {
+                        "description": "Amount, revenue, cost and profit per article",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.GetArticleTransactionsGroupedByArticleRow"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve ArticleTransactions",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/article-transaction/grouped-by-pricing-rule": {
+            "get": {
+                "description": "Sum up the amount and revenue sold with every pricing rule in charged transactions, lines sold at the resell price have no rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ArticleTransactions"
+                ],
+                "summary": "Retrieve article transactions grouped by pricing rule",
+                "responses": {
+                    "200": {
+                        "description": "Amount and revenue per pricing rule",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.GetArticleTransactionsGroupedByPricingRuleRow"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve ArticleTransactions",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -175,7 +286,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an article transaction with the provided payload",
+                "description": "Update a sale line of a pending transaction whose payment did not start yet. The line is priced again by the server,\nan optional price sent by the client has to match. The totals of the transactions are updated, the new total is checked against the credit of the resident.",
                 "consumes": [
                     "application/json"
                 ],
@@ -206,9 +317,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "ArticleTransaction data",
+                        "description": "ArticleTransaction data with its component and deposit lines and stock warnings",
                         "schema": {
-                            "$ref": "#/definitions/db.ArticleTransaction"
+                            "$ref": "#/definitions/db.ArticleTransactionTxResult"
                         }
                     },
                     "400": {
@@ -217,16 +328,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
+                    "402": {
+                        "description": "Credit policy blocks the new total",
+                        "schema": {
+                            "$ref": "#/definitions/e.InsufficientFundsResponse"
+                        }
+                    },
                     "404": {
                         "description": "Article transaction not found",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Transaction is not pending, business day is closed or article is out of stock or archived",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Price mismatch, not a sale line or total not positive",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete an article transaction by the provided ID",
+                "description": "Delete a sale line of a pending or failed transaction by the provided ID, the total of the transaction is updated.\nLines of charged transactions can not be deleted, refund the transaction instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -256,6 +385,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Transaction is charged or its business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Line is not a sale line or total not positive",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Failed to delete ArticleTransaction",
                         "schema": {
@@ -267,7 +408,7 @@ const docTemplate = `{
         },
         "/article-type": {
             "get": {
-                "description": "Get a list of all article types",
+                "description": "Get a list of all article types, archived ones only on request",
                 "produces": [
                     "application/json"
                 ],
@@ -275,6 +416,14 @@ const docTemplate = `{
                     "ArticleTypes"
                 ],
                 "summary": "Retrieve all article types",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include archived article types",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Successfully retrieved all article types",
@@ -285,6 +434,12 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Failed to retrieve article types",
                         "schema": {
@@ -338,6 +493,49 @@ const docTemplate = `{
                 }
             }
         },
+        "/article-type/article": {
+            "get": {
+                "description": "Get all article types with their articles and the price they are sold at right now, archived ones are hidden.\nWith at the menu is shown as it was at that moment, with the prices and articles of then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ArticleTypes"
+                ],
+                "summary": "Retrieve the menu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp, defaults to now",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the menu",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schemas.ArticleTypeWithArticles"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve article types",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/article-type/{articleTypeId}": {
             "get": {
                 "description": "Retrieve an article type using its ID",
@@ -436,14 +634,14 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Remove an article type using its ID",
+                "description": "Archive an article type instead of deleting it. Archived article types are hidden from the menu together with their articles,\ntheir sales stay in the reports and receipts.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "ArticleTypes"
                 ],
-                "summary": "Delete an article type by ID",
+                "summary": "Archive an article type by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -455,7 +653,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Successfully deleted article type"
+                        "description": "Successfully archived article type"
                     },
                     "404": {
                         "description": "Article type not found",
@@ -464,7 +662,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to delete article type",
+                        "description": "Failed to archive article type",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
@@ -472,28 +670,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/articles": {
-            "get": {
-                "description": "Get a list of all articles",
+        "/article-type/{articleTypeId}/restore": {
+            "post": {
+                "description": "Put an archived article type back on the menu with its articles that are not archived themselves",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles"
+                    "ArticleTypes"
+                ],
+                "summary": "Restore an archived article type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Article Type ID",
+                        "name": "articleTypeId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Retrieve all articles",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved all articles",
+                        "description": "Successfully restored article type",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.Article"
-                            }
+                            "$ref": "#/definitions/db.ArticleType"
+                        }
+                    },
+                    "404": {
+                        "description": "Article type not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve articles",
+                        "description": "Failed to restore article type",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
@@ -501,16 +711,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/articles/{articleId}": {
+        "/article/{articleId}/components": {
             "get": {
-                "description": "Retrieve an article using its ID",
+                "description": "Get the component articles of a bundle with their amount per bundle, plain articles have none",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Articles"
                 ],
-                "summary": "Get an article by ID",
+                "summary": "Retrieve the components of a bundle",
                 "parameters": [
                     {
                         "type": "string",
@@ -522,19 +732,16 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved article",
+                        "description": "Successfully retrieved the components",
                         "schema": {
-                            "$ref": "#/definitions/db.Article"
-                        }
-                    },
-                    "404": {
-                        "description": "Article not found",
-                        "schema": {
-                            "$ref": "#/definitions/e.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ArticleComponent"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve article",
+                        "description": "Failed to retrieve components",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
@@ -542,7 +749,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an article with the provided ID and payload",
+                "description": "Replace the components of a bundle. The bundle is sold at its own resell price, every sale also counts the units of its components.\nThe revenue of a bundle is split across its components in proportion to their resell price. Bundles can not be nested.",
                 "consumes": [
                     "application/json"
                 ],
@@ -552,7 +759,7 @@ const docTemplate = `{
                 "tags": [
                     "Articles"
                 ],
-                "summary": "Update an existing article",
+                "summary": "Define an article as a bundle",
                 "parameters": [
                     {
                         "type": "string",
@@ -562,24 +769,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update article payload",
-                        "name": "article",
+                        "description": "Components payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateArticle"
+                            "$ref": "#/definitions/schemas.SetArticleComponents"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated article",
+                        "description": "Successfully set the components",
                         "schema": {
-                            "$ref": "#/definitions/db.Article"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ArticleComponent"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid payload",
+                        "description": "Invalid payload or nested bundle",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
@@ -589,24 +799,20 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Failed to update article",
-                        "schema": {
-                            "$ref": "#/definitions/e.ErrorResponse"
-                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove an article using its ID",
+            }
+        },
+        "/article/{articleId}/price": {
+            "get": {
+                "description": "Retrieve all prices of an article with the time they are valid, including the scheduled ones",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Articles"
                 ],
-                "summary": "Delete an article by ID",
+                "summary": "Get the price history of an article",
                 "parameters": [
                     {
                         "type": "string",
@@ -617,8 +823,14 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted article"
+                    "200": {
+                        "description": "Successfully retrieved the price history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ArticlePrice"
+                            }
+                        }
                     },
                     "404": {
                         "description": "Article not found",
@@ -627,17 +839,15 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to delete article",
+                        "description": "Failed to retrieve the price history",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/event": {
+            },
             "post": {
-                "description": "Create a new event with the provided details",
+                "description": "Change the resell price of an article from the given time on, for example new prices from the first of the month.\nA change scheduled for the same time is replaced. The purchase price follows the stock and can not be scheduled.",
                 "consumes": [
                     "application/json"
                 ],
@@ -645,136 +855,146 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Articles"
                 ],
-                "summary": "Create a new event",
+                "summary": "Schedule a price change of an article",
                 "parameters": [
                     {
-                        "description": "CreateEvent payload",
+                        "type": "string",
+                        "description": "Article ID",
+                        "name": "articleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ScheduleArticlePrice payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateEvent"
+                            "$ref": "#/definitions/schemas.ScheduleArticlePrice"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event data",
+                        "description": "Successfully scheduled the price",
                         "schema": {
-                            "$ref": "#/definitions/db.Event"
+                            "$ref": "#/definitions/db.ArticlePrice"
                         }
                     },
                     "400": {
-                        "description": "Invalid Payload",
+                        "description": "Invalid payload",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/event/{id}": {
-            "get": {
-                "description": "Retrieve an event by the provided ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Events"
-                ],
-                "summary": "Retrieve an event by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Event data",
+                    },
+                    "404": {
+                        "description": "Article not found",
                         "schema": {
-                            "$ref": "#/definitions/db.Event"
+                            "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Event not found",
+                    "422": {
+                        "description": "Price change is in the past",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to schedule the price",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/article/{articleId}/price/{priceId}": {
             "delete": {
-                "description": "Delete an event by the provided ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove a price change that did not take effect yet, the price before it stays in effect",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Articles"
                 ],
-                "summary": "Delete an event by ID",
+                "summary": "Remove a scheduled price change",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "id",
+                        "description": "Article ID",
+                        "name": "articleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price ID",
+                        "name": "priceId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Event deleted successfully"
+                        "description": "Successfully removed the price change"
                     },
                     "404": {
-                        "description": "Event not found",
+                        "description": "Article or price not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Price is already in effect",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to remove the price change",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update an event by the provided id and details",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/article/{articleId}/restore": {
+            "post": {
+                "description": "Put an archived article back on the menu, it stays hidden while its article type is archived",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Articles"
                 ],
-                "summary": "Update an event",
+                "summary": "Restore an archived article",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "id",
+                        "description": "Article ID",
+                        "name": "articleId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event data",
+                        "description": "Successfully restored article",
                         "schema": {
-                            "$ref": "#/definitions/db.Event"
+                            "$ref": "#/definitions/db.Article"
                         }
                     },
-                    "400": {
-                        "description": "Invalid Payload",
+                    "404": {
+                        "description": "Article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to restore article",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
@@ -782,9 +1002,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/events": {
-            "get": {
-                "description": "Retrieve a list of all events",
+        "/article/{articleId}/stock/correction": {
+            "post": {
+                "description": "Add or remove units from the stock of the article at the location, or at the default location, e.g. for breakage or after counting.\nThe note is the reason of the correction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -792,21 +1012,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Articles"
+                ],
+                "summary": "Correct the stock of an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Article ID",
+                        "name": "articleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Correction payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.StockCorrection"
+                        }
+                    }
                 ],
-                "summary": "Retrieve all events",
                 "responses": {
                     "200": {
-                        "description": "List of events",
+                        "description": "Successfully corrected the stock",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.Event"
-                            }
+                            "$ref": "#/definitions/db.StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Article or location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to correct the stock",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
@@ -814,9 +1061,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/transaction": {
-            "get": {
-                "description": "Retrieve a list of all transactions",
+        "/article/{articleId}/stock/delivery": {
+            "post": {
+                "description": "Add the delivered units to the stock of the article at the location, or at the default location",
                 "consumes": [
                     "application/json"
                 ],
@@ -824,97 +1071,129 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Articles"
+                ],
+                "summary": "Book a delivery of an article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Article ID",
+                        "name": "articleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.StockDelivery"
+                        }
+                    }
                 ],
-                "summary": "Retrieve all transactions",
                 "responses": {
                     "200": {
-                        "description": "List of transactions",
+                        "description": "Successfully booked the delivery",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.Transaction"
-                            }
+                            "$ref": "#/definitions/db.StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Article or location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to book the delivery",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new transaction with the provided date and price",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/article/{articleId}/stock/movements": {
+            "get": {
+                "description": "Get every change of the stock of the article with its reason, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Articles"
                 ],
-                "summary": "Create a new transaction",
+                "summary": "Retrieve the stock history of an article",
                 "parameters": [
                     {
-                        "description": "CreateTransaction payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.CreateTransaction"
-                        }
+                        "type": "string",
+                        "description": "Article ID",
+                        "name": "articleId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction data",
+                        "description": "Successfully retrieved the stock movements",
                         "schema": {
-                            "$ref": "#/definitions/db.Transaction"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.StockMovement"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid Payload",
+                    "500": {
+                        "description": "Failed to retrieve the stock movements",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update a transaction with the provided date and price",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/articles": {
+            "get": {
+                "description": "Get a list of all articles, archived ones only on request",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Articles"
                 ],
-                "summary": "Update a transaction",
+                "summary": "Retrieve all articles",
                 "parameters": [
                     {
-                        "description": "UpdateTransaction payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.UpdateTransaction"
-                        }
+                        "type": "boolean",
+                        "description": "Include archived articles",
+                        "name": "archived",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction data",
+                        "description": "Successfully retrieved all articles",
                         "schema": {
-                            "$ref": "#/definitions/db.Transaction"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Article"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid Payload",
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve articles",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
@@ -922,51 +1201,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/transaction/{id}": {
+        "/articles/{articleId}": {
             "get": {
-                "description": "Retrieve a transaction by the provided id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve an article using its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Articles"
                 ],
-                "summary": "Retrieve a transaction",
+                "summary": "Get an article by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transaction ID",
-                        "name": "id",
+                        "description": "Article ID",
+                        "name": "articleId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction data",
+                        "description": "Successfully retrieved article",
                         "schema": {
-                            "$ref": "#/definitions/db.Transaction"
+                            "$ref": "#/definitions/db.Article"
                         }
                     },
                     "404": {
-                        "description": "Transaction not found",
+                        "description": "Article not found",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to retrieve article",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Delete a transaction by the provided id",
+            "put": {
+                "description": "Update an article with the provided ID and payload. Changed prices take effect right away and are recorded in the price history.",
                 "consumes": [
                     "application/json"
                 ],
@@ -974,248 +1250,5702 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Articles"
                 ],
-                "summary": "Delete a transaction",
+                "summary": "Update an existing article",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transaction ID",
-                        "name": "id",
+                        "description": "Article ID",
+                        "name": "articleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update article payload",
+                        "name": "article",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateArticle"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated article",
+                        "schema": {
+                            "$ref": "#/definitions/db.Article"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update article",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Archive an article instead of deleting it. Archived articles are hidden from the menu and can not be sold,\ntheir sales stay in the reports and receipts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Archive an article by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Article ID",
+                        "name": "articleId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Transaction deleted successfully"
+                        "description": "Successfully archived article"
                     },
                     "404": {
-                        "description": "Transaction not found",
+                        "description": "Article not found",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete Transaction",
+                        "description": "Failed to archive article",
                         "schema": {
                             "$ref": "#/definitions/e.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "db.Article": {
-            "type": "object",
-            "properties": {
-                "article_type_uuid": {
-                    "type": "string"
-                },
-                "desc": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "purchase_price": {
-                    "type": "number"
-                },
-                "resell_price": {
-                    "type": "number"
+        },
+        "/checkout": {
+            "post": {
+                "description": "Create a transaction with all of its article transactions in one step.\nPrices are calculated by the server, an optional total sent by the client has to match.\nThe transaction starts as pending, poll its status until the resident has been charged.\nThe payment backend is the one of the resident, else the one of the terminal. Guests without a resident pay cash.\nSales of residents have to stay within their minimum balance and daily cap.\nSold units are taken out of the stock, articles with the warn policy that are sold below zero are listed in stock_warnings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout"
+                ],
+                "summary": "Checkout a cart",
+                "parameters": [
+                    {
+                        "description": "Checkout payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.Checkout"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction with its article transactions",
+                        "schema": {
+                            "$ref": "#/definitions/db.CheckoutTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Credit policy blocks the sale",
+                        "schema": {
+                            "$ref": "#/definitions/e.InsufficientFundsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident, terminal or article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Article is out of stock or archived",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Price mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout/deposit-return": {
+            "post": {
+                "description": "Pay back the deposit of returned bottles or crates. The returned items are written as negative deposit lines of a transaction and credited to the resident.\nThe payment backend is resolved like for a sale, guests without a resident are paid out in cash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout"
+                ],
+                "summary": "Return deposit",
+                "parameters": [
+                    {
+                        "description": "Deposit return payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DepositReturn"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction with its deposit return lines",
+                        "schema": {
+                            "$ref": "#/definitions/db.CheckoutTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload or article without deposit",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident, terminal or article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkout/split": {
+            "post": {
+                "description": "Checkout a cart paid by several residents. Every resident is charged for a share with their own payment backend.\nIn even mode the total is divided equally, the remaining cents go to the first shares.\nIn amounts mode every share names its amount, they have to add up to the total.\nIn items mode every share lists the indices of the items it pays, every item has to be assigned exactly once.\nThe bill is charged once all shares are. If one share fails, the others are cancelled or paid back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Checkout"
+                ],
+                "summary": "Split a bill",
+                "parameters": [
+                    {
+                        "description": "Split checkout payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SplitCheckout"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bill with its article transactions and shares",
+                        "schema": {
+                            "$ref": "#/definitions/db.SplitCheckoutTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Credit policy blocks the share of a resident",
+                        "schema": {
+                            "$ref": "#/definitions/e.InsufficientFundsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident, terminal or article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Article is out of stock or archived",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Price or split mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/closing": {
+            "get": {
+                "description": "Retrieve the closings without their totals, the latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Closings"
+                ],
+                "summary": "Retrieve all closings",
+                "responses": {
+                    "200": {
+                        "description": "List of closings",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Closing"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Close everything since the last closing and write the Z-report: revenue, transactions, refunds, totals per article type,\ntotals per payment backend and deposits. Only charged transactions are counted. The report can not be changed afterwards,\ntransactions dated inside the closed period can no longer be updated or deleted.\nThe day can not be closed while transactions in it are pending, reconcile them first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Closings"
+                ],
+                "summary": "Close the business day",
+                "parameters": [
+                    {
+                        "description": "CloseDay payload",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CloseDay"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Z-report",
+                        "schema": {
+                            "$ref": "#/definitions/db.ClosingReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Period is already closed or transactions are pending",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/closing/{closingId}": {
+            "get": {
+                "description": "Retrieve a closing with its totals per article type and per payment backend",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Closings"
+                ],
+                "summary": "Retrieve a Z-report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Closing ID",
+                        "name": "closingId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Z-report",
+                        "schema": {
+                            "$ref": "#/definitions/db.ClosingReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Closing not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/event": {
+            "post": {
+                "description": "Create a new event with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Create a new event",
+                "parameters": [
+                    {
+                        "description": "CreateEvent payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/event/{id}": {
+            "get": {
+                "description": "Retrieve an event by the provided ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Retrieve an event by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Event"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an event by the provided ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Delete an event by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Event deleted successfully"
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update an event by the provided id and details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Update an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Retrieve a list of all events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Retrieve all events",
+                "responses": {
+                    "200": {
+                        "description": "List of events",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Event"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/group": {
+            "get": {
+                "description": "Retrieve a list of all resident groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Retrieve all resident groups",
+                "responses": {
+                    "200": {
+                        "description": "List of groups",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ResidentGroup"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a group of residents sharing a credit policy, the minimum balance and daily cap are optional",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Create a new resident group",
+                "parameters": [
+                    {
+                        "description": "CreateResidentGroup payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateResidentGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group data",
+                        "schema": {
+                            "$ref": "#/definitions/db.ResidentGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/group/{name}": {
+            "get": {
+                "description": "Retrieve a resident group by the provided name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Retrieve a resident group by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group data",
+                        "schema": {
+                            "$ref": "#/definitions/db.ResidentGroup"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a resident group, its residents keep only their own limits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Delete a resident group by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Group deleted successfully"
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the credit policy of a group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Update a resident group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateResidentGroup payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateResidentGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group data",
+                        "schema": {
+                            "$ref": "#/definitions/db.ResidentGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location": {
+            "get": {
+                "description": "Retrieve a list of all locations ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Retrieve all storage locations",
+                "responses": {
+                    "200": {
+                        "description": "List of locations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Location"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new location stock is stored at, e.g. a cellar or a fridge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Create a new storage location",
+                "parameters": [
+                    {
+                        "description": "CreateLocation payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/stock": {
+            "get": {
+                "description": "Retrieve the stock of every article broken down by the locations that store it. Bundles have no stock of their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Retrieve the stock report",
+                "responses": {
+                    "200": {
+                        "description": "Stock per article and location",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ArticleStockReport"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/transfer": {
+            "get": {
+                "description": "Retrieve a list of all stock transfers, newest first, optionally only those of an article or from or to a location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Retrieve the stock transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Article ID",
+                        "name": "article_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "location_uuid",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stock transfers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.StockTransfer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Move units of an article from one location to another, e.g. from the cellar to a bar fridge.\nThe total stock of the article does not change. A location can not give more units than it stores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Transfer stock between storage locations",
+                "parameters": [
+                    {
+                        "description": "TransferStock payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TransferStock"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock transfer",
+                        "schema": {
+                            "$ref": "#/definitions/db.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Article or location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Transfer exceeds the stock at the location",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/{locationId}": {
+            "get": {
+                "description": "Retrieve a location by the provided ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Retrieve a storage location by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Location"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a location that stores no stock, the default location can not be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Delete a storage location by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Location deleted successfully"
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Location is the default or stores stock",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a location by the provided id and details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Update a storage location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateLocation payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/{locationId}/default": {
+            "post": {
+                "description": "The default location is used by sales of terminals without a location and by deliveries, corrections and stocktakes without one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Make a storage location the default",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location ID",
+                        "name": "locationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Location"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing-rule": {
+            "get": {
+                "description": "Retrieve a list of all pricing rules, the highest priority first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PricingRules"
+                ],
+                "summary": "Retrieve all pricing rules",
+                "responses": {
+                    "200": {
+                        "description": "List of pricing rules",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.PricingRule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a rule overriding the resell price, scoped to an article, an article type and/or an event.\nThe rule can be limited to weekdays and a time window and is either a fixed price or a discount in percent.\nOf all matching rules the one with the highest priority wins, then the most specific one, then the cheapest.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PricingRules"
+                ],
+                "summary": "Create a new pricing rule",
+                "parameters": [
+                    {
+                        "description": "CreatePricingRule payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreatePricingRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PricingRule data",
+                        "schema": {
+                            "$ref": "#/definitions/db.PricingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing-rule/{id}": {
+            "get": {
+                "description": "Retrieve a pricing rule by the provided ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PricingRules"
+                ],
+                "summary": "Retrieve a pricing rule by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "PricingRule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PricingRule data",
+                        "schema": {
+                            "$ref": "#/definitions/db.PricingRule"
+                        }
+                    },
+                    "404": {
+                        "description": "PricingRule not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a pricing rule by the provided ID, past sales keep their price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PricingRules"
+                ],
+                "summary": "Delete a pricing rule by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "PricingRule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "PricingRule deleted successfully"
+                    },
+                    "404": {
+                        "description": "PricingRule not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a pricing rule by the provided id and details, setting a fixed price clears the discount and the other way round",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PricingRules"
+                ],
+                "summary": "Update a pricing rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "PricingRule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdatePricingRule payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdatePricingRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PricingRule data",
+                        "schema": {
+                            "$ref": "#/definitions/db.PricingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "PricingRule not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-order": {
+            "get": {
+                "description": "Retrieve a list of all purchase orders, newest first, optionally only those of a supplier or in a status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PurchaseOrders"
+                ],
+                "summary": "Retrieve all purchase orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplier_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "open, partially_received, received or cancelled",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of purchase orders",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.PurchaseOrder"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Order articles from a supplier at an agreed cost per unit. The order is open until all of its lines are received.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PurchaseOrders"
+                ],
+                "summary": "Create a purchase order",
+                "parameters": [
+                    {
+                        "description": "CreatePurchaseOrder payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreatePurchaseOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order with its lines",
+                        "schema": {
+                            "$ref": "#/definitions/db.PurchaseOrderReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier or article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-order/{purchaseOrderId}": {
+            "get": {
+                "description": "Retrieve a purchase order with its lines and the goods received for it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PurchaseOrders"
+                ],
+                "summary": "Retrieve a purchase order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "purchaseOrderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order with its lines and receipts",
+                        "schema": {
+                            "$ref": "#/definitions/db.PurchaseOrderReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-order/{purchaseOrderId}/cancel": {
+            "post": {
+                "description": "Cancel an open purchase order, goods that were received already stay in the stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PurchaseOrders"
+                ],
+                "summary": "Cancel a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "purchaseOrderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cancelled purchase order",
+                        "schema": {
+                            "$ref": "#/definitions/db.PurchaseOrder"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is not open",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-order/{purchaseOrderId}/receipt": {
+            "post": {
+                "description": "Book the goods that arrived for an open purchase order, a delivery may cover only part of the order.\nThe units are added to the stock at the location. The purchase price of every article becomes the weighted average\nof the units in stock and the received units at the cost actually paid, past sales keep their cost.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PurchaseOrders"
+                ],
+                "summary": "Receive goods for a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "purchaseOrderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ReceiveGoods payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ReceiveGoods"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goods receipt with its lines",
+                        "schema": {
+                            "$ref": "#/definitions/db.GoodsReceiptReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order or location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is not open",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Receipt exceeds the ordered quantity",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift": {
+            "get": {
+                "description": "Retrieve a list of all shifts, optionally only those of an event or only the open ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "Retrieve all shifts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only open shifts",
+                        "name": "open",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of shifts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Shift"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift/close": {
+            "post": {
+                "description": "Close a shift with the cash counted in the drawer per denomination. The expected cash is the opening float\nplus the cash transactions of the terminal during the shift, the difference is counted minus expected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "Close a shift",
+                "parameters": [
+                    {
+                        "description": "CloseShift payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CloseShift"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Closed shift with its counts",
+                        "schema": {
+                            "$ref": "#/definitions/db.ShiftReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload or denomination",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Shift is not open",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift/open": {
+            "post": {
+                "description": "Open a bartender shift with the cash float put into the drawer. The drawer belongs to the terminal,\nevery drawer has at most one open shift. The shift is linked to the given event or the one running now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "Open a shift",
+                "parameters": [
+                    {
+                        "description": "OpenShift payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.OpenShift"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shift data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Shift"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Terminal or event not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Drawer already has an open shift",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shift/{shiftId}": {
+            "get": {
+                "description": "Retrieve a shift with its counted denominations, for open shifts the expected cash is the drawer as of now",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "Retrieve a shift by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shift ID",
+                        "name": "shiftId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shift with its counts",
+                        "schema": {
+                            "$ref": "#/definitions/db.ShiftReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-alert": {
+            "get": {
+                "description": "Retrieve the latest alerts raised when the stock of an article fell below its reorder threshold, newest first.\nAn alert is processed once it was delivered to all channels or its delivery was given up, see last_error.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "StockAlerts"
+                ],
+                "summary": "Retrieve the low stock alerts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of alerts, defaults to 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stock alerts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.StockAlert"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktake": {
+            "get": {
+                "description": "Retrieve a list of all stocktakes, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktakes"
+                ],
+                "summary": "Retrieve all stocktakes",
+                "responses": {
+                    "200": {
+                        "description": "List of stocktakes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Stocktake"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Open a stocktake, the stock and purchase price of every article are snapshot as the expected stock.\nA stocktake of a location expects the stock at the location, otherwise the total stock of all locations.\nBundles have no stock of their own and are not counted. Only one stocktake can be open at a time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktakes"
+                ],
+                "summary": "Open a stocktake",
+                "parameters": [
+                    {
+                        "description": "OpenStocktake payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.OpenStocktake"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Opened stocktake with the expected stock",
+                        "schema": {
+                            "$ref": "#/definitions/db.StocktakeReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A stocktake is already open",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktake/{stocktakeId}": {
+            "get": {
+                "description": "Retrieve a stocktake with its variance report, for open stocktakes the variance counted so far",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktakes"
+                ],
+                "summary": "Retrieve a stocktake by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stocktake with its variance report",
+                        "schema": {
+                            "$ref": "#/definitions/db.StocktakeReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Stocktake not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktake/{stocktakeId}/close": {
+            "post": {
+                "description": "Close a stocktake and set the stock of every counted article to the counted units, articles that were not counted keep their stock.\nThe count is taken as the stock at closing, units sold while the stocktake was open are not taken out again.\nClose a stocktake and correct the stock of every counted article by its variance, articles that were not counted keep their stock.\nThe corrections are booked at the location of the stocktake, or at the default location.\nThe report shows the variance in units and at purchase price per article, per article type and in total.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktakes"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Variance report of the closed stocktake",
+                        "schema": {
+                            "$ref": "#/definitions/db.StocktakeReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Stocktake not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Stocktake is not open",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktake/{stocktakeId}/count": {
+            "post": {
+                "description": "Submit the counted stock of articles, a stocktake can be counted in several partial submissions.\nA count is added to earlier counts of the article, e.g. for articles stored in several places, unless replace is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stocktakes"
+                ],
+                "summary": "Submit counted stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stocktake ID",
+                        "name": "stocktakeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "CountStocktake payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CountStocktake"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stocktake with the variance counted so far",
+                        "schema": {
+                            "$ref": "#/definitions/db.StocktakeReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stocktake or article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Stocktake is not open",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier": {
+            "get": {
+                "description": "Retrieve a list of all suppliers ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Retrieve all suppliers",
+                "responses": {
+                    "200": {
+                        "description": "List of suppliers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Supplier"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new supplier that articles are ordered from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Create a new supplier",
+                "parameters": [
+                    {
+                        "description": "CreateSupplier payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateSupplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/{supplierId}": {
+            "get": {
+                "description": "Retrieve a supplier by the provided ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Retrieve a supplier by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplierId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Supplier"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a supplier that has no purchase orders",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Delete a supplier by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplierId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Supplier deleted successfully"
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier has purchase orders",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a supplier by the provided id and details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Update a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplierId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateSupplier payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateSupplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tab": {
+            "get": {
+                "description": "Retrieve a list of all tabs, optionally only those with the given status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tabs"
+                ],
+                "summary": "Retrieve all tabs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open, flagged or closed",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tabs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Tab"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Open a tab for a resident, the orders of the evening are collected on it and settled at once when it is closed.\nThe tab belongs to the event running when it is opened and is closed automatically at the end of the event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tabs"
+                ],
+                "summary": "Open a tab",
+                "parameters": [
+                    {
+                        "description": "OpenTab payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.OpenTab"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tab data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tab"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident or terminal not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Resident already has a tab",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tab/{tabId}": {
+            "get": {
+                "description": "Retrieve a tab with all of its items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tabs"
+                ],
+                "summary": "Retrieve a tab by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tab ID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tab with its items",
+                        "schema": {
+                            "$ref": "#/definitions/db.TabTxResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Tab not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tab/{tabId}/close": {
+            "post": {
+                "description": "Settle all items of an open or flagged tab with a single transaction and a single debit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tabs"
+                ],
+                "summary": "Close a tab",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tab ID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Closed tab with its items, the tab references the transaction",
+                        "schema": {
+                            "$ref": "#/definitions/db.TabTxResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Tab not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tab is already closed or its business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tab/{tabId}/items": {
+            "post": {
+                "description": "Order items on an open tab. Their current price is reserved until the tab is closed.\nThe items count against the credit of the resident right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tabs"
+                ],
+                "summary": "Add items to a tab",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tab ID",
+                        "name": "tabId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "AddTabItems payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.AddTabItems"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tab with its items",
+                        "schema": {
+                            "$ref": "#/definitions/db.TabTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Credit policy blocks the items",
+                        "schema": {
+                            "$ref": "#/definitions/e.InsufficientFundsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tab or article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tab is not open or article is out of stock or archived",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Price mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal": {
+            "get": {
+                "description": "Retrieve a list of all terminals",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminals"
+                ],
+                "summary": "Retrieve all terminals",
+                "responses": {
+                    "200": {
+                        "description": "List of terminals",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Terminal"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new bar terminal, an optional payment backend is used for the sales of residents without their own.\nThe sales of the terminal draw from its location, or from the default location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminals"
+                ],
+                "summary": "Create a new terminal",
+                "parameters": [
+                    {
+                        "description": "CreateTerminal payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateTerminal"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Terminal data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Terminal"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal/{id}": {
+            "get": {
+                "description": "Retrieve a terminal by the provided ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminals"
+                ],
+                "summary": "Retrieve a terminal by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Terminal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Terminal data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Terminal"
+                        }
+                    },
+                    "404": {
+                        "description": "Terminal not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a terminal by the provided ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminals"
+                ],
+                "summary": "Delete a terminal by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Terminal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Terminal deleted successfully"
+                    },
+                    "404": {
+                        "description": "Terminal not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a terminal by the provided id and details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminals"
+                ],
+                "summary": "Update a terminal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Terminal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateTerminal payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateTerminal"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Terminal data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Terminal"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Terminal or location not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction": {
+            "get": {
+                "description": "Retrieve a list of all transactions with their VAT breakdown, optionally only those of a resident or an event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Retrieve all transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the resident who paid",
+                        "name": "resident",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_uuid",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of transactions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.TransactionWithVat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Filter",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the date of a pending transaction whose payment did not start yet. The price is always the sum of its lines.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Update a transaction",
+                "parameters": [
+                    {
+                        "description": "UpdateTransaction payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction is not pending or business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/checkout": {
+            "post": {
+                "description": "Create a new transaction for the given items of the resident and queue the payment. The transaction is priced and dated at the time it is made.\nPrices are calculated by the server, an optional price sent by the client has to match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Checkout a transaction",
+                "parameters": [
+                    {
+                        "description": "CheckoutTransaction payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CheckoutTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction data with its VAT breakdown",
+                        "schema": {
+                            "$ref": "#/definitions/db.TransactionWithVat"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Credit policy blocks the sale",
+                        "schema": {
+                            "$ref": "#/definitions/e.InsufficientFundsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident or article not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Article is out of stock or archived",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Price mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/reconciliation": {
+            "get": {
+                "description": "Retrieve all payments where SavaPage did not answer in time, so it is unknown whether the resident was charged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Retrieve payments that need a reconciliation",
+                "responses": {
+                    "200": {
+                        "description": "List of open payments",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.PaymentOutbox"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/{id}": {
+            "get": {
+                "description": "Retrieve a transaction by the provided id together with its VAT breakdown per rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Retrieve a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction data with its VAT breakdown",
+                        "schema": {
+                            "$ref": "#/definitions/db.TransactionWithVat"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a transaction by the provided id.\nOnly failed transactions can be deleted together with their lines, refund charged ones instead.\nSplit bills and the transactions of tabs can not be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Delete a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Transaction deleted successfully"
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction is not failed, belongs to a split bill or tab, or its business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete Transaction",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/{transactionId}/receipt": {
+            "get": {
+                "description": "Render the receipt of a transaction with its lines, the VAT breakdown and the balance after the payment.\nThe header holds the configured bar name and address.",
+                "produces": [
+                    "text/plain",
+                    "text/html",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Retrieve the receipt of a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "txt",
+                            "html",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Format of the receipt, txt by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/{transactionId}/reconcile": {
+            "post": {
+                "description": "Resolve a failed or timed out payment after looking up its details marker in SavaPage.\n\"pending\" queues the payment again, \"charged\" and \"failed\" close it with that status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Reconcile the payment of a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ReconcileTransaction payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ReconcileTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction is already charged or its business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/{transactionId}/refund": {
+            "post": {
+                "description": "Refund a charged transaction, either completely or only the given amounts of its article transactions.\nA reversing transaction linked to the original is written and the amount is credited back in SavaPage.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Refund a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "RefundTransaction payload",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RefundTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund transaction with its article transactions",
+                        "schema": {
+                            "$ref": "#/definitions/db.CheckoutTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction can not be refunded or the business day is closed",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Refund exceeds the remaining amount",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/{username}": {
+            "post": {
+                "description": "Deprecated, transactions with a price sent by the client are no longer accepted. The endpoint still takes the date and price\nof the old contract and answers with 410, use POST /transaction/checkout with the items of the sale instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Create a new transaction",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SavaPage user name",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "CreateTransaction payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Endpoint is deprecated",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{username}": {
+            "get": {
+                "description": "Retrieve the prepaid wallet balance of a resident, it is the sum of all ledger entries of the wallet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Retrieve the wallet of a resident",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resident name",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet data",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.Wallet"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{username}/correction": {
+            "post": {
+                "description": "Book a manual correction onto the wallet of a resident, a correction may take the wallet below zero",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Correct a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resident name",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "CorrectWallet payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CorrectWallet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet data",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{username}/entries": {
+            "get": {
+                "description": "Retrieve all ledger entries of the wallet of a resident, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Retrieve the ledger entries of a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resident name",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.GetLedgerEntriesByAccountRow"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Resident not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{username}/topup": {
+            "post": {
+                "description": "Book cash paid at the bar onto the wallet of a resident. Guests get a resident with a card code and the wallet payment backend.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallets"
+                ],
+                "summary": "Top up a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resident name",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "TopUpWallet payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TopUpWallet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet data",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resident not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook": {
+            "get": {
+                "description": "Retrieve a list of all registered webhooks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Retrieve all webhooks",
+                "responses": {
+                    "200": {
+                        "description": "List of webhooks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register an URL the low stock alerts are posted to as JSON. With a secret the body is signed\nwith HMAC-SHA256, the signature is sent in the X-Rupay-Signature header as sha256=\u003chex\u003e.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "CreateWebhook payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook data",
+                        "schema": {
+                            "$ref": "#/definitions/db.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Payload",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook/{webhookId}": {
+            "delete": {
+                "description": "Delete a webhook, no more alerts are posted to it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Webhook deleted successfully"
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/e.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "db.Article": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "article_type_uuid": {
+                    "type": "string"
+                },
+                "deposit": {
+                    "type": "number"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "type": "number"
+                },
+                "reorder_threshold": {
+                    "type": "integer"
+                },
+                "resell_price": {
+                    "type": "number"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "stock_policy": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ArticleComponent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "bundle_uuid": {
+                    "type": "string"
+                },
+                "component_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ArticlePrice": {
+            "type": "object",
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "type": "number"
+                },
+                "resell_price": {
+                    "type": "number"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ArticleStockReport": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.LocationStock"
+                    }
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ArticleTransaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "bundle_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "deposit_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "net": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "pricing_rule_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "refund_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "transaction_uuid": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ArticleTransactionTxResult": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "bundle_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "deposit_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ArticleTransaction"
+                    }
+                },
+                "net": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "pricing_rule_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "refund_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "stock_warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.StockWarning"
+                    }
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "transaction_uuid": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ArticleType": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "icon_codepoint": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.CheckoutTxResult": {
+            "type": "object",
+            "properties": {
+                "article_transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ArticleTransaction"
+                    }
+                },
+                "stock_warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.StockWarning"
+                    }
+                },
+                "transaction": {
+                    "$ref": "#/definitions/db.Transaction"
+                },
+                "vat": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.VatBreakdown"
+                    }
+                }
+            }
+        },
+        "db.Closing": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deposits_charged": {
+                    "type": "number"
+                },
+                "deposits_returned": {
+                    "type": "number"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "refund_total": {
+                    "type": "number"
+                },
+                "refunds": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ClosingArticleType": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "article_type_uuid": {
+                    "type": "string"
+                },
+                "closing_uuid": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.ClosingPaymentBackend": {
+            "type": "object",
+            "properties": {
+                "closing_uuid": {
+                    "type": "string"
+                },
+                "payment_backend": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ClosingReport": {
+            "type": "object",
+            "properties": {
+                "article_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ClosingArticleType"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposits_charged": {
+                    "type": "number"
+                },
+                "deposits_returned": {
+                    "type": "number"
+                },
+                "payment_backends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ClosingPaymentBackend"
+                    }
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "refund_total": {
+                    "type": "number"
+                },
+                "refunds": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "transactions": {
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Event": {
+            "type": "object",
+            "properties": {
+                "desc": {
+                    "type": "string"
+                },
+                "from_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "to_date": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.GetArticleTransactionsGroupedByArticleRow": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "profit": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.GetArticleTransactionsGroupedByPricingRuleRow": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "pricing_rule_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "revenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.GetDepositLiabilitiesRow": {
+            "type": "object",
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "bottles": {
+                    "type": "integer"
+                },
+                "liability": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.GetLedgerEntriesByAccountRow": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.GoodsReceiptLine": {
+            "type": "object",
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "goods_receipt_uuid": {
+                    "type": "string"
+                },
+                "purchase_order_line_uuid": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.GoodsReceiptReport": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.GoodsReceiptLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "purchase_order_uuid": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Location": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.LocationStock": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "location_uuid": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.PaymentOutbox": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "backend": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "needs_reconciliation": {
+                    "type": "boolean"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "reversal": {
+                    "type": "boolean"
+                },
+                "transaction_uuid": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.PricingRule": {
+            "type": "object",
+            "properties": {
+                "article_type_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "article_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "integer"
+                },
+                "event_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "fixed_price": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent_off": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "weekdays": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.PurchaseOrderLine": {
+            "type": "object",
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "purchase_order_uuid": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.PurchaseOrderReport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.PurchaseOrderLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.GoodsReceiptReport"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ResidentGroup": {
+            "type": "object",
+            "properties": {
+                "daily_cap": {
+                    "type": "number"
+                },
+                "min_balance": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Shift": {
+            "type": "object",
+            "properties": {
+                "bartender": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "counted_cash": {
+                    "type": "number"
+                },
+                "difference": {
+                    "type": "number"
+                },
+                "event_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "expected_cash": {
+                    "type": "number"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "opening_float": {
+                    "type": "number"
+                },
+                "terminal_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ShiftCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "denomination": {
+                    "type": "number"
+                },
+                "shift_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ShiftReport": {
+            "type": "object",
+            "properties": {
+                "bartender": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "counted_cash": {
+                    "type": "number"
+                },
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ShiftCount"
+                    }
+                },
+                "difference": {
+                    "type": "number"
+                },
+                "event_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "expected_cash": {
+                    "type": "number"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "opening_float": {
+                    "type": "number"
+                },
+                "terminal_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.SplitCheckoutTxResult": {
+            "type": "object",
+            "properties": {
+                "article_transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ArticleTransaction"
+                    }
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Transaction"
+                    }
+                },
+                "stock_warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.StockWarning"
+                    }
+                },
+                "transaction": {
+                    "$ref": "#/definitions/db.Transaction"
+                },
+                "vat": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.VatBreakdown"
+                    }
+                }
+            }
+        },
+        "db.StockAlert": {
+            "type": "object",
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "reorder_threshold": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.StockMovement": {
+            "type": "object",
+            "properties": {
+                "article_transaction_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "goods_receipt_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "location_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "stock_after": {
+                    "type": "integer"
+                },
+                "stock_transfer_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "stocktake_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.StockTransfer": {
+            "type": "object",
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_location_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "to_location_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.StockWarning": {
+            "type": "object",
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Stocktake": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "location_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.StocktakeArticleTypeVariance": {
+            "type": "object",
+            "properties": {
+                "article_type_uuid": {
+                    "type": "string"
+                },
+                "counted": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shrinkage": {
+                    "type": "number"
+                },
+                "variance": {
+                    "type": "integer"
+                },
+                "variance_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.StocktakeLineReport": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "article_type": {
+                    "type": "string"
+                },
+                "article_type_uuid": {
+                    "type": "string"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "counted": {
+                    "type": "integer"
+                },
+                "expected": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "variance": {
+                    "type": "integer"
+                },
+                "variance_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.StocktakeReport": {
+            "type": "object",
+            "properties": {
+                "article_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.StocktakeArticleTypeVariance"
+                    }
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.StocktakeLineReport"
+                    }
+                },
+                "location_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/db.StocktakeVariance"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.StocktakeVariance": {
+            "type": "object",
+            "properties": {
+                "counted": {
+                    "type": "integer"
+                },
+                "shrinkage": {
+                    "type": "number"
+                },
+                "variance": {
+                    "type": "integer"
+                },
+                "variance_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.Supplier": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Tab": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "event_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "payment_backend": {
+                    "type": "string"
+                },
+                "resident": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "terminal_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "transaction_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.TabItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "pricing_rule_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "tab_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.TabTxResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.TabItem"
+                    }
+                },
+                "tab": {
+                    "$ref": "#/definitions/db.Tab"
+                }
+            }
+        },
+        "db.Terminal": {
+            "type": "object",
+            "properties": {
+                "location_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payment_backend": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Transaction": {
+            "type": "object",
+            "properties": {
+                "balance_after": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "event_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "payment_backend": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "refund_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "resident": {
+                    "type": "string"
+                },
+                "split_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "terminal_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.TransactionWithVat": {
+            "type": "object",
+            "properties": {
+                "balance_after": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "event_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "payment_backend": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "refund_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "resident": {
+                    "type": "string"
+                },
+                "split_of": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "terminal_uuid": {
+                    "$ref": "#/definitions/uuid.NullUUID"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vat": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.VatBreakdown"
+                    }
+                }
+            }
+        },
+        "db.VatBreakdown": {
+            "type": "object",
+            "properties": {
+                "gross": {
+                    "type": "number"
+                },
+                "net": {
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "e.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Field causing the error",
+                    "type": "string"
+                },
+                "issue": {
+                    "description": "Description of the issue with the field",
+                    "type": "string"
+                }
+            }
+        },
+        "e.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Error code",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "List of field-specific errors",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/e.ErrorDetail"
+                    }
+                },
+                "error": {
+                    "description": "Stack Trace",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Human-readable error message",
+                    "type": "string"
+                }
+            }
+        },
+        "e.InsufficientFundsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Error code",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "List of field-specific errors",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/e.ErrorDetail"
+                    }
+                },
+                "error": {
+                    "description": "Stack Trace",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Human-readable error message",
+                    "type": "string"
+                },
+                "shortfall": {
+                    "description": "Amount missing to allow the sale",
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.AddTabItems": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.CheckoutItem"
+                    }
+                },
+                "total": {
+                    "description": "optional, has to match the server side total of the added items",
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.ArticleComponent": {
+            "type": "object",
+            "required": [
+                "amount",
+                "article_uuid"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "article_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.ArticleTypeWithArticles": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "articles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.MenuArticle"
+                    }
+                },
+                "color": {
+                    "type": "string"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "icon_codepoint": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.Checkout": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.CheckoutItem"
+                    }
+                },
+                "resident": {
+                    "description": "optional, guests without a resident pay cash",
+                    "type": "string"
+                },
+                "terminal_uuid": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "optional, has to match the server side total",
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.CheckoutItem": {
+            "type": "object",
+            "required": [
+                "amount",
+                "article_uuid"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "article_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CheckoutTransaction": {
+            "type": "object",
+            "required": [
+                "items",
+                "resident"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.CheckoutItem"
+                    }
+                },
+                "price": {
+                    "description": "optional, has to match the server side total",
+                    "type": "number"
+                },
+                "resident": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CloseDay": {
+            "type": "object",
+            "properties": {
+                "until": {
+                    "description": "optional, defaults to now",
+                    "type": "string",
+                    "example": "2024-01-25T06:00:00Z"
+                }
+            }
+        },
+        "schemas.CloseShift": {
+            "type": "object",
+            "required": [
+                "shift_uuid"
+            ],
+            "properties": {
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DenominationCount"
+                    }
+                },
+                "shift_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CorrectWallet": {
+            "type": "object",
+            "required": [
+                "amount",
+                "reason"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "negative to take money from the wallet",
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CountStocktake": {
+            "type": "object",
+            "required": [
+                "counts"
+            ],
+            "properties": {
+                "counts": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.StocktakeCount"
+                    }
+                }
+            }
+        },
+        "schemas.CreateArticle": {
+            "type": "object",
+            "required": [
+                "article_type_uuid",
+                "name",
+                "purchase_price",
+                "resell_price"
+            ],
+            "properties": {
+                "article_type_uuid": {
+                    "type": "string"
+                },
+                "deposit": {
+                    "description": "optional, charged as a separate line with every unit",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "reorder_threshold": {
+                    "description": "optional, the purchasing team is alerted when the stock falls below it",
+                    "type": "integer"
+                },
+                "resell_price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "stock_policy": {
+                    "description": "optional, defaults to allow",
+                    "type": "string",
+                    "enum": [
+                        "allow",
+                        "warn",
+                        "block"
+                    ]
+                },
+                "vat_rate": {
+                    "description": "optional, overrides the VAT rate of the article type",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.CreateArticleTransaction": {
+            "type": "object",
+            "required": [
+                "amount",
+                "article_uuid",
+                "transaction_uuid"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "optional, has to match the server side unit price",
+                    "type": "number"
+                },
+                "transaction_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateArticleType": {
+            "type": "object",
+            "required": [
+                "color",
+                "icon_codepoint",
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "icon_codepoint": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "description": "optional, defaults to the standard rate",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.CreateEvent": {
+            "type": "object",
+            "required": [
+                "from_date",
+                "name",
+                "to_date"
+            ],
+            "properties": {
+                "desc": {
+                    "type": "string"
+                },
+                "from_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "to_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateLocation": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreatePricingRule": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "article_type_uuid": {
+                    "type": "string"
+                },
+                "article_uuid": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "description": "minutes since midnight, before starts_at wraps midnight",
+                    "type": "integer"
+                },
+                "event_uuid": {
+                    "type": "string"
+                },
+                "fixed_price": {
+                    "description": "either a fixed price",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent_off": {
+                    "description": "or a discount in percent",
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "description": "minutes since midnight",
+                    "type": "integer"
+                },
+                "weekdays": {
+                    "description": "bit n for weekday n, sunday is 0, empty is every day",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.CreatePurchaseOrder": {
+            "type": "object",
+            "required": [
+                "lines",
+                "supplier_uuid"
+            ],
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.PurchaseOrderLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "supplier_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateResidentGroup": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "daily_cap": {
+                    "description": "optional, most a resident may spend per day",
+                    "type": "number"
+                },
+                "min_balance": {
+                    "description": "optional, lowest balance a sale may leave",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateSupplier": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "contact": {
+                    "description": "contact person",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateTerminal": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "location_uuid": {
+                    "description": "optional, the location sales draw from, defaults to the default location",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payment_backend": {
+                    "description": "savapage, wallet or cash",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateTransaction": {
+            "type": "object",
+            "required": [
+                "date",
+                "price"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-24T00:00:00Z"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.CreateWebhook": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "secret": {
+                    "description": "optional, the body is signed with it in the X-Rupay-Signature header",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DenominationCount": {
+            "type": "object",
+            "required": [
+                "denomination"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "denomination": {
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.DepositReturn": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.CheckoutItem"
+                    }
+                },
+                "resident": {
+                    "description": "optional, guests are paid out in cash",
+                    "type": "string"
+                },
+                "terminal_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GoodsReceiptLine": {
+            "type": "object",
+            "required": [
+                "purchase_order_line_uuid",
+                "quantity"
+            ],
+            "properties": {
+                "purchase_order_line_uuid": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "unit_cost": {
+                    "description": "optional, the actual cost per unit if it differs from the order",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "schemas.MenuArticle": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "article_type_uuid": {
+                    "type": "string"
+                },
+                "deposit": {
+                    "type": "number"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "pricing_rule_uuid": {
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "type": "number"
+                },
+                "reorder_threshold": {
+                    "type": "integer"
+                },
+                "resell_price": {
+                    "type": "number"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "stock_policy": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.OpenShift": {
+            "type": "object",
+            "required": [
+                "bartender"
+            ],
+            "properties": {
+                "bartender": {
+                    "type": "string"
+                },
+                "event_uuid": {
+                    "description": "optional, defaults to the running event",
+                    "type": "string"
+                },
+                "opening_float": {
+                    "type": "number"
+                },
+                "terminal_uuid": {
+                    "description": "optional, the drawer of the terminal",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.OpenStocktake": {
+            "type": "object",
+            "properties": {
+                "location_uuid": {
+                    "description": "optional, counts only the location instead of the total stock",
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.OpenTab": {
+            "type": "object",
+            "required": [
+                "resident"
+            ],
+            "properties": {
+                "resident": {
+                    "type": "string"
                 },
-                "uuid": {
+                "terminal_uuid": {
                     "type": "string"
                 }
             }
         },
-        "db.ArticleTransaction": {
+        "schemas.PurchaseOrderLine": {
             "type": "object",
+            "required": [
+                "article_uuid",
+                "quantity"
+            ],
             "properties": {
-                "amount": {
-                    "type": "integer"
-                },
                 "article_uuid": {
                     "type": "string"
                 },
-                "transaction_uuid": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "uuid": {
-                    "type": "string"
+                "unit_cost": {
+                    "description": "agreed cost per unit",
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
-        "db.ArticleType": {
+        "schemas.ReceiveGoods": {
             "type": "object",
+            "required": [
+                "lines"
+            ],
             "properties": {
-                "desc": {
-                    "type": "string"
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.GoodsReceiptLine"
+                    }
                 },
-                "name": {
+                "location_uuid": {
+                    "description": "optional, where the goods are stored, defaults to the default location",
                     "type": "string"
                 },
-                "uuid": {
+                "note": {
+                    "description": "e.g. the delivery note number",
                     "type": "string"
                 }
             }
         },
-        "db.Event": {
+        "schemas.ReconcileTransaction": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "desc": {
-                    "type": "string"
-                },
-                "from_date": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "to_date": {
-                    "type": "string"
-                },
-                "uuid": {
-                    "type": "string"
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "charged",
+                        "failed"
+                    ]
                 }
             }
         },
-        "db.Transaction": {
+        "schemas.RefundItem": {
             "type": "object",
+            "required": [
+                "amount",
+                "article_transaction_uuid"
+            ],
             "properties": {
-                "date": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "uuid": {
+                "article_transaction_uuid": {
                     "type": "string"
                 }
             }
         },
-        "e.ErrorDetail": {
+        "schemas.RefundTransaction": {
             "type": "object",
             "properties": {
-                "field": {
-                    "description": "Field causing the error",
-                    "type": "string"
+                "items": {
+                    "description": "optional, refunds everything that is left when empty",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RefundItem"
+                    }
+                }
+            }
+        },
+        "schemas.ScheduleArticlePrice": {
+            "type": "object",
+            "required": [
+                "resell_price",
+                "valid_from"
+            ],
+            "properties": {
+                "resell_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "issue": {
-                    "description": "Description of the issue with the field",
-                    "type": "string"
+                "valid_from": {
+                    "type": "string",
+                    "example": "2024-11-01T00:00:00Z"
                 }
             }
         },
-        "e.ErrorResponse": {
+        "schemas.SetArticleComponents": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Error code",
-                    "type": "string"
+                "components": {
+                    "description": "empty turns the bundle into a plain article",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ArticleComponent"
+                    }
+                }
+            }
+        },
+        "schemas.SplitCheckout": {
+            "type": "object",
+            "required": [
+                "items",
+                "mode",
+                "shares"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schemas.CheckoutItem"
+                    }
                 },
-                "details": {
-                    "description": "List of field-specific errors",
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "even",
+                        "amounts",
+                        "items"
+                    ]
+                },
+                "shares": {
                     "type": "array",
+                    "minItems": 2,
                     "items": {
-                        "$ref": "#/definitions/e.ErrorDetail"
+                        "$ref": "#/definitions/schemas.SplitShare"
                     }
                 },
-                "error": {
-                    "description": "Stack Trace",
+                "terminal_uuid": {
                     "type": "string"
                 },
-                "message": {
-                    "description": "Human-readable error message",
-                    "type": "string"
+                "total": {
+                    "description": "optional, has to match the server side total",
+                    "type": "number"
                 }
             }
         },
-        "schemas.CreateArticle": {
+        "schemas.SplitShare": {
             "type": "object",
             "required": [
-                "article_type_uuid",
-                "name",
-                "purchase_price",
-                "resell_price"
+                "resident"
             ],
             "properties": {
-                "article_type_uuid": {
-                    "type": "string"
+                "amount": {
+                    "description": "required in amounts mode",
+                    "type": "number"
                 },
-                "desc": {
-                    "type": "string"
+                "items": {
+                    "description": "indices into the items of the cart, required in items mode",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "name": {
+                "resident": {
                     "type": "string"
-                },
-                "purchase_price": {
-                    "type": "number"
-                },
-                "resell_price": {
-                    "type": "number"
                 }
             }
         },
-        "schemas.CreateArticleType": {
+        "schemas.StockCorrection": {
             "type": "object",
             "required": [
-                "name"
+                "note",
+                "quantity"
             ],
             "properties": {
-                "desc": {
+                "location_uuid": {
+                    "description": "optional, defaults to the default location",
                     "type": "string"
                 },
-                "name": {
+                "note": {
+                    "description": "reason of the correction, e.g. breakage",
                     "type": "string"
+                },
+                "quantity": {
+                    "description": "negative takes units out of the stock",
+                    "type": "integer"
                 }
             }
         },
-        "schemas.CreateEvent": {
+        "schemas.StockDelivery": {
             "type": "object",
             "required": [
-                "from_date",
-                "name",
-                "to_date"
+                "quantity"
             ],
             "properties": {
-                "desc": {
+                "location_uuid": {
+                    "description": "optional, defaults to the default location",
                     "type": "string"
                 },
-                "from_date": {
+                "note": {
                     "type": "string"
                 },
-                "name": {
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "schemas.StocktakeCount": {
+            "type": "object",
+            "required": [
+                "article_uuid"
+            ],
+            "properties": {
+                "article_uuid": {
                     "type": "string"
                 },
-                "to_date": {
-                    "type": "string"
+                "counted": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "replace": {
+                    "description": "optional, overwrites earlier counts of the article instead of adding to them",
+                    "type": "boolean"
                 }
             }
         },
-        "schemas.CreateTransaction": {
+        "schemas.TopUpWallet": {
             "type": "object",
             "required": [
-                "date",
-                "price"
+                "amount"
             ],
             "properties": {
-                "date": {
-                    "type": "string",
-                    "example": "2024-01-24T00:00:00Z"
-                },
-                "price": {
+                "amount": {
                     "type": "number"
                 }
             }
         },
+        "schemas.TransferStock": {
+            "type": "object",
+            "required": [
+                "article_uuid",
+                "from_location_uuid",
+                "quantity",
+                "to_location_uuid"
+            ],
+            "properties": {
+                "article_uuid": {
+                    "type": "string"
+                },
+                "from_location_uuid": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "to_location_uuid": {
+                    "type": "string"
+                }
+            }
+        },
         "schemas.UpdateArticle": {
             "type": "object",
             "properties": {
                 "article_type_uuid": {
                     "$ref": "#/definitions/uuid.NullUUID"
                 },
+                "deposit": {
+                    "type": "number",
+                    "minimum": 0
+                },
                 "desc": {
                     "type": "string"
                 },
@@ -1223,10 +6953,21 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "purchase_price": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
+                },
+                "reorder_threshold": {
+                    "type": "integer"
                 },
                 "resell_price": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
+                },
+                "stock_policy": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
                 }
             }
         },
@@ -1234,11 +6975,16 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "amount": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "article_uuid": {
                     "$ref": "#/definitions/uuid.NullUUID"
                 },
+                "price": {
+                    "description": "optional, has to match the server side unit price",
+                    "type": "number"
+                },
                 "transaction_uuid": {
                     "$ref": "#/definitions/uuid.NullUUID"
                 }
@@ -1247,11 +6993,96 @@ const docTemplate = `{
         "schemas.UpdateArticleType": {
             "type": "object",
             "properties": {
+                "color": {
+                    "type": "string"
+                },
                 "desc": {
                     "type": "string"
                 },
+                "icon_codepoint": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.UpdateLocation": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.UpdatePricingRule": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "integer"
+                },
+                "fixed_price": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent_off": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "integer"
+                },
+                "weekdays": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.UpdateResidentGroup": {
+            "type": "object",
+            "properties": {
+                "daily_cap": {
+                    "type": "number"
+                },
+                "min_balance": {
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.UpdateSupplier": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.UpdateTerminal": {
+            "type": "object",
+            "properties": {
+                "location_uuid": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "payment_backend": {
+                    "type": "string"
                 }
             }
         },
@@ -1261,9 +7092,17 @@ const docTemplate = `{
                 "date": {
                     "type": "string",
                     "example": "2024-01-24T00:00:00Z"
-                },
-                "price": {
+                }
+            }
+        },
+        "schemas.Wallet": {
+            "type": "object",
+            "properties": {
+                "balance": {
                     "type": "number"
+                },
+                "resident": {
+                    "type": "string"
                 }
             }
         },
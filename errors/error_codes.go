@@ -4,7 +4,54 @@ const (
 	// Payload Errors
 	InvalidPayload = "INVALID_PAYLOAD"
 
+	EndpointDeprecated = "ENDPOINT_DEPRECATED"
+
 	InternalServerError = "INTERNAL_SERVER_ERROR"
 
 	NotFound = "NOT_FOUND"
+
+	// Pricing Errors
+	PriceMismatch    = "PRICE_MISMATCH"
+	SplitMismatch    = "SPLIT_MISMATCH"
+	TotalNotPositive = "TOTAL_NOT_POSITIVE"
+
+	// Payment Errors
+	AlreadyCharged = "ALREADY_CHARGED"
+	NotRefundable  = "NOT_REFUNDABLE"
+	RefundExceeded = "REFUND_EXCEEDED"
+	NotDeletable   = "NOT_DELETABLE"
+	PeriodClosed   = "PERIOD_CLOSED"
+	Pending        = "TRANSACTIONS_PENDING"
+	NotPending     = "TRANSACTION_NOT_PENDING"
+	NotSaleLine    = "NOT_SALE_LINE"
+
+	// Credit Errors
+	InsufficientFunds = "INSUFFICIENT_FUNDS"
+
+	// Tab Errors
+	TabAlreadyOpen = "TAB_ALREADY_OPEN"
+	TabNotOpen     = "TAB_NOT_OPEN"
+
+	// Shift Errors
+	ShiftAlreadyOpen = "SHIFT_ALREADY_OPEN"
+	ShiftNotOpen     = "SHIFT_NOT_OPEN"
+
+	// Stock Errors
+	OutOfStock = "OUT_OF_STOCK"
+
+	// Article Errors
+	ArticleArchived = "ARTICLE_ARCHIVED"
+	PriceInPast     = "PRICE_IN_PAST"
+	PriceInEffect   = "PRICE_IN_EFFECT"
+
+	// Purchasing Errors
+	PurchaseOrderNotOpen = "PURCHASE_ORDER_NOT_OPEN"
+	ReceiptExceeded      = "RECEIPT_EXCEEDED"
+
+	// Location Errors
+	TransferExceeded = "TRANSFER_EXCEEDED"
+
+	// Stocktake Errors
+	StocktakeAlreadyOpen = "STOCKTAKE_ALREADY_OPEN"
+	StocktakeNotOpen     = "STOCKTAKE_NOT_OPEN"
 )
@@ -1,5 +1,7 @@
 package e
 
+import "github.com/KevinGruber2001/rupay-bar-backend/util"
+
 
 type ErrorDetail struct {
 	Field string `json:"field,omitempty"` // Field causing the error
@@ -12,4 +14,9 @@ type ErrorResponse struct {
 	Message       string        `json:"message"`                 // Human-readable error message
 	Error			string		`json:"error"`                 	// Stack Trace
 	Details       []ErrorDetail `json:"details,omitempty"`       // List of field-specific errors
-}
\ No newline at end of file
+}
+// InsufficientFundsResponse is returned when the credit policy of a resident blocks a sale
+type InsufficientFundsResponse struct {
+	ErrorResponse
+	Shortfall util.Money `json:"shortfall"` // Amount missing to allow the sale
+}
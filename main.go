@@ -5,11 +5,17 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/alert"
 	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
 	dbCon "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/KevinGruber2001/rupay-bar-backend/payment"
+	"github.com/KevinGruber2001/rupay-bar-backend/receipt"
 	"github.com/KevinGruber2001/rupay-bar-backend/routes"
 	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/KevinGruber2001/rupay-bar-backend/worker"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-migrate/migrate"
@@ -28,23 +34,53 @@ import (
 )
 
 var (
-	server *gin.Engine
-	db     *dbCon.Queries
-	ctx    context.Context
+	server   *gin.Engine
+	db       *dbCon.Queries
+	store    *dbCon.Store
+	payments *payment.Registry
+	ctx      context.Context
 
 	ArticleController            controllers.ArticleController
 	ArticleTransactionController controllers.ArticleTransactionController
 	ArticleTypeController        controllers.ArticleTypeController
+	CheckoutController           controllers.CheckoutController
+	ClosingController            controllers.ClosingController
 	EventController              controllers.EventController
+	LocationController           controllers.LocationController
+	PricingRuleController        controllers.PricingRuleController
+	PurchaseOrderController      controllers.PurchaseOrderController
+	ResidentGroupController      controllers.ResidentGroupController
+	ShiftController              controllers.ShiftController
+	StockAlertController         controllers.StockAlertController
+	StocktakeController          controllers.StocktakeController
+	SupplierController           controllers.SupplierController
+	TabController                controllers.TabController
+	TerminalController           controllers.TerminalController
 	TransactionController        controllers.TransactionController
 	UserController               controllers.UserController
+	WalletController             controllers.WalletController
+	WebhookController            controllers.WebhookController
 
 	ArticleRoutes            routes.ArticleRoutes
 	ArticleTransactionRoutes routes.ArticleTransactionRoutes
 	ArticleTypeRoutes        routes.ArticleTypeRoutes
+	CheckoutRoutes           routes.CheckoutRoutes
+	ClosingRoutes            routes.ClosingRoutes
 	EventRoutes              routes.EventRoutes
+	LocationRoutes           routes.LocationRoutes
+	PricingRuleRoutes        routes.PricingRuleRoutes
+	PurchaseOrderRoutes      routes.PurchaseOrderRoutes
+	ResidentGroupRoutes      routes.ResidentGroupRoutes
+	ShiftRoutes              routes.ShiftRoutes
+	StockAlertRoutes         routes.StockAlertRoutes
+	StocktakeRoutes          routes.StocktakeRoutes
+	SupplierRoutes           routes.SupplierRoutes
+	TabRoutes                routes.TabRoutes
+	TerminalRoutes           routes.TerminalRoutes
 	TransactionRoutes        routes.TransactionRoutes
 	UserRoutes               routes.UserRoutes
+	WalletRoutes             routes.WalletRoutes
+	WebhookRoutes            routes.WebhookRoutes
 )
 
 func runMigrations() {
@@ -76,6 +112,24 @@ func init() {
 	}
 
 	db = dbCon.New(conn)
+	store = dbCon.NewStore(conn)
+
+	// happy hours and weekdays of the pricing rules are on the clock of the bar
+	barLocation, err := time.LoadLocation(config.BarTimezone)
+	if err != nil {
+		log.Fatalf("could not load the bar time zone: %v", err)
+	}
+	dbCon.SetBarLocation(barLocation)
+
+	// residents and terminals without a payment backend use the configured one
+	if config.PaymentBackend == "" {
+		config.PaymentBackend = payment.SavaPage
+	}
+	payments = payment.NewRegistry(config.PaymentBackend,
+		payment.NewSavaPageBackend(),
+		payment.NewWalletBackend(store),
+		payment.NewCashBackend(),
+	)
 
 	fmt.Println("PostgreSql connected successfully...")
 
@@ -111,24 +165,66 @@ func init() {
 		panic(err.Error())
 	}
 
-	ArticleController = *controllers.NewArticleController(db, ctx)
+	ArticleController = *controllers.NewArticleController(store, ctx)
 	ArticleRoutes = routes.NewRouteArticle(ArticleController)
 
 	ArticleTypeController = *controllers.NewArticleTypeController(db, ctx)
 	ArticleTypeRoutes = routes.NewRouteArticleType(ArticleTypeController)
 
-	ArticleTransactionController = *controllers.NewArticleTransactionController(db, ctx)
+	ArticleTransactionController = *controllers.NewArticleTransactionController(store, payments, ctx)
 	ArticleTransactionRoutes = routes.NewRouteArticleTransaction(ArticleTransactionController)
 
+	CheckoutController = *controllers.NewCheckoutController(store, payments, ctx)
+	CheckoutRoutes = routes.NewRouteCheckout(CheckoutController)
+
+	ClosingController = *controllers.NewClosingController(store, ctx)
+	ClosingRoutes = routes.NewRouteClosing(ClosingController)
+
 	EventController = *controllers.NewEventController(db, ctx)
 	EventRoutes = routes.NewRouteEvent(EventController)
 
-	TransactionController = *controllers.NewTransactionController(db, ctx)
+	LocationController = *controllers.NewLocationController(store, ctx)
+	LocationRoutes = routes.NewRouteLocation(LocationController)
+
+	PricingRuleController = *controllers.NewPricingRuleController(db, ctx)
+	PricingRuleRoutes = routes.NewRoutePricingRule(PricingRuleController)
+
+	PurchaseOrderController = *controllers.NewPurchaseOrderController(store, ctx)
+	PurchaseOrderRoutes = routes.NewRoutePurchaseOrder(PurchaseOrderController)
+
+	ResidentGroupController = *controllers.NewResidentGroupController(db, ctx)
+	ResidentGroupRoutes = routes.NewRouteResidentGroup(ResidentGroupController)
+
+	ShiftController = *controllers.NewShiftController(store, ctx)
+	ShiftRoutes = routes.NewRouteShift(ShiftController)
+
+	StockAlertController = *controllers.NewStockAlertController(db, ctx)
+	StockAlertRoutes = routes.NewRouteStockAlert(StockAlertController)
+
+	StocktakeController = *controllers.NewStocktakeController(store, ctx)
+	StocktakeRoutes = routes.NewRouteStocktake(StocktakeController)
+
+	SupplierController = *controllers.NewSupplierController(db, ctx)
+	SupplierRoutes = routes.NewRouteSupplier(SupplierController)
+
+	TabController = *controllers.NewTabController(store, payments, ctx)
+	TabRoutes = routes.NewRouteTab(TabController)
+
+	TerminalController = *controllers.NewTerminalController(db, ctx)
+	TerminalRoutes = routes.NewRouteTerminal(TerminalController)
+
+	TransactionController = *controllers.NewTransactionController(store, payments, receipt.Header{Name: config.ReceiptName, Address: config.ReceiptAddress}, ctx)
 	TransactionRoutes = routes.NewRouteTransaction(TransactionController)
 
 	UserController = *controllers.NewUserController(db, ctx)
 	UserRoutes = routes.NewRouteUser(UserController)
 
+	WalletController = *controllers.NewWalletController(store, ctx)
+	WalletRoutes = routes.NewRouteWallet(WalletController)
+
+	WebhookController = *controllers.NewWebhookController(db, ctx)
+	WebhookRoutes = routes.NewRouteWebhook(WebhookController)
+
 	server = gin.Default()
 
 	// CORS
@@ -170,6 +266,44 @@ func main() {
 		log.Fatalf("failed to connect to mqtt broker: %v", e)
 	}
 
+	// applies the queued balance adjustments
+	worker.NewPaymentWorker(store, payments, 5*time.Second).Start(ctx)
+
+	// settles the tabs left open after their event
+	worker.NewTabWorker(store, time.Minute).Start(ctx)
+
+	// closes the business day at the configured time
+	if config.ClosingTime != "" {
+		closings, err := worker.NewClosingWorker(store, config.ClosingTime)
+		if err != nil {
+			log.Fatalf("failed to start the closing worker: %v", err)
+		}
+		closings.Start(ctx)
+	}
+
+	// delivers the low stock alerts, by mail only if SMTP is configured
+	if config.StockAlertTopic == "" {
+		config.StockAlertTopic = "rupay/stock/low"
+	}
+	channels := []alert.Channel{
+		alert.NewMQTTChannel(util.GetClient(), config.StockAlertTopic),
+		alert.NewWebhookChannel(db),
+	}
+	if config.SmtpHost != "" && config.PurchasingEmail != "" {
+		channels = append(channels, alert.NewMailChannel(alert.MailConfig{
+			Host:     config.SmtpHost,
+			Port:     config.SmtpPort,
+			Username: config.SmtpUsername,
+			Password: config.SmtpPassword,
+			From:     config.SmtpFrom,
+			To:       strings.Split(strings.ReplaceAll(config.PurchasingEmail, " ", ""), ","),
+		}))
+	}
+	worker.NewStockAlertWorker(store, channels, 30*time.Second).Start(ctx)
+
+	// applies the scheduled price changes once they are due
+	worker.NewPriceWorker(store, time.Minute).Start(ctx)
+
 	router := server.Group("/api")
 
 	// swagger middleware to serve the API docs
@@ -178,9 +312,23 @@ func main() {
 	ArticleRoutes.ArticleRoute(router)
 	ArticleTypeRoutes.ArticleTypeRoute(router)
 	ArticleTransactionRoutes.ArticleTransactionRoute(router)
+	CheckoutRoutes.CheckoutRoute(router)
+	ClosingRoutes.ClosingRoute(router)
 	EventRoutes.EventRoute(router)
+	LocationRoutes.LocationRoute(router)
+	PricingRuleRoutes.PricingRuleRoute(router)
+	PurchaseOrderRoutes.PurchaseOrderRoute(router)
+	ResidentGroupRoutes.ResidentGroupRoute(router)
+	ShiftRoutes.ShiftRoute(router)
+	StockAlertRoutes.StockAlertRoute(router)
+	StocktakeRoutes.StocktakeRoute(router)
+	SupplierRoutes.SupplierRoute(router)
+	TabRoutes.TabRoute(router)
+	TerminalRoutes.TerminalRoute(router)
 	TransactionRoutes.TransactionRoute(router)
 	UserRoutes.UserRoute(router)
+	WalletRoutes.WalletRoute(router)
+	WebhookRoutes.WebhookRoute(router)
 
 	// server.NoRoute(func(ctx *gin.Context) {
 	//     ctx.JSON(http.StatusNotFound, gin.H{"status": "failed", "message": fmt.Sprintf("The specified route %s not found", ctx.Request.URL)})
@@ -0,0 +1,80 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/guregu/null/v5"
+)
+
+// Names of the available payment backends, stored with every transaction
+const (
+	SavaPage = "savapage"
+	Wallet   = "wallet"
+	Cash     = "cash"
+)
+
+var (
+	// ErrUnknownBackend is returned for backend names that are not registered
+	ErrUnknownBackend = errors.New("unknown payment backend")
+	// ErrInsufficientFunds is returned when a debit would exceed the balance of the account
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrNoBalance is returned by backends that do not keep a balance
+	ErrNoBalance = errors.New("payment backend has no balance")
+	// ErrNotApplied marks errors after which the backend certainly did not
+	// apply the adjustment, so it can be retried without charging twice
+	ErrNotApplied = errors.New("payment was not applied")
+)
+
+// PaymentBackend moves money from and to the account of a resident. Amounts
+// passed to Debit and Credit are positive, the details are stored with the
+// booking where the backend supports it.
+type PaymentBackend interface {
+	Name() string
+	Balance(ctx context.Context, account string) (util.Money, error)
+	Debit(ctx context.Context, account string, amount util.Money, details string) error
+	Credit(ctx context.Context, account string, amount util.Money, details string) error
+}
+
+// IsBackend reports whether name is one of the known payment backends
+func IsBackend(name string) bool {
+	return name == SavaPage || name == Wallet || name == Cash
+}
+
+// Registry holds the available backends and picks the one a sale is paid with
+type Registry struct {
+	backends map[string]PaymentBackend
+	fallback string
+}
+
+func NewRegistry(fallback string, backends ...PaymentBackend) *Registry {
+	registry := &Registry{make(map[string]PaymentBackend, len(backends)), fallback}
+	for _, backend := range backends {
+		registry.backends[backend.Name()] = backend
+	}
+	return registry
+}
+
+// Get returns the backend with the given name
+func (r *Registry) Get(name string) (PaymentBackend, error) {
+	backend, ok := r.backends[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, name)
+	}
+	return backend, nil
+}
+
+// Resolve returns the backend configured for the resident, else the one of
+// the terminal, else the configured fallback
+func (r *Registry) Resolve(resident null.String, terminal null.String) (PaymentBackend, error) {
+	switch {
+	case resident.Valid:
+		return r.Get(resident.String)
+	case terminal.Valid:
+		return r.Get(terminal.String)
+	default:
+		return r.Get(r.fallback)
+	}
+}
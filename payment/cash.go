@@ -0,0 +1,31 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+// cashBackend is used when the money is handed over at the bar, there is no
+// account to book on
+type cashBackend struct{}
+
+func NewCashBackend() PaymentBackend {
+	return cashBackend{}
+}
+
+func (cashBackend) Name() string {
+	return Cash
+}
+
+func (cashBackend) Balance(ctx context.Context, account string) (util.Money, error) {
+	return 0, ErrNoBalance
+}
+
+func (cashBackend) Debit(ctx context.Context, account string, amount util.Money, details string) error {
+	return nil
+}
+
+func (cashBackend) Credit(ctx context.Context, account string, amount util.Money, details string) error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+// savaPageBackend books on the SavaPage account of the resident
+type savaPageBackend struct{}
+
+func NewSavaPageBackend() PaymentBackend {
+	return savaPageBackend{}
+}
+
+func (savaPageBackend) Name() string {
+	return SavaPage
+}
+
+func (savaPageBackend) Balance(ctx context.Context, account string) (util.Money, error) {
+	return util.GetSavaPageBalance(account)
+}
+
+func (savaPageBackend) Debit(ctx context.Context, account string, amount util.Money, details string) error {
+	return adjustSavaPage(account, -amount, details)
+}
+
+func (savaPageBackend) Credit(ctx context.Context, account string, amount util.Money, details string) error {
+	return adjustSavaPage(account, amount, details)
+}
+
+// adjustSavaPage marks the errors after which SavaPage did not apply the adjustment
+func adjustSavaPage(account string, amount util.Money, details string) error {
+	err := util.AdjustSavaPageBalance(account, amount, details)
+	if errors.Is(err, util.ErrSavaPageNotApplied) {
+		return fmt.Errorf("%w: %v", ErrNotApplied, err)
+	}
+	return err
+}
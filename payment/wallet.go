@@ -0,0 +1,61 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+// walletBackend books on the prepaid wallet of the resident in the internal
+// ledger. Sales are booked against the revenue account, a wallet can not go
+// below zero. Postings are idempotent by their details.
+type walletBackend struct {
+	store *db.Store
+}
+
+func NewWalletBackend(store *db.Store) PaymentBackend {
+	return walletBackend{store}
+}
+
+func (walletBackend) Name() string {
+	return Wallet
+}
+
+func (b walletBackend) Balance(ctx context.Context, account string) (util.Money, error) {
+	return b.store.WalletBalance(ctx, account)
+}
+
+func (b walletBackend) Debit(ctx context.Context, account string, amount util.Money, details string) error {
+	_, err := b.store.WalletPostingTx(ctx, db.WalletPostingTxParams{
+		Resident:    account,
+		Amount:      -amount,
+		CounterKind: db.LedgerAccountRevenue,
+		Details:     details,
+	})
+	if errors.Is(err, db.ErrInsufficientFunds) {
+		return ErrInsufficientFunds
+	}
+	return notApplied(err)
+}
+
+func (b walletBackend) Credit(ctx context.Context, account string, amount util.Money, details string) error {
+	_, err := b.store.WalletPostingTx(ctx, db.WalletPostingTxParams{
+		Resident:    account,
+		Amount:      amount,
+		CounterKind: db.LedgerAccountRevenue,
+		Details:     details,
+	})
+	return notApplied(err)
+}
+
+// notApplied marks a failed posting, it was rolled back and postings are
+// idempotent by their details, so retrying can not book twice
+func notApplied(err error) error {
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotApplied, err)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package receipt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+// Formats a receipt can be rendered in
+const (
+	Text = "txt"
+	HTML = "html"
+	PDF  = "pdf"
+)
+
+// width of text and PDF receipts in characters
+const width = 48
+
+// Header is printed on top of every receipt
+type Header struct {
+	Name    string
+	Address string
+}
+
+// Line is a single position of a receipt
+type Line struct {
+	Article string
+	Kind    string
+	Amount  int32
+	Price   util.Money
+	VatRate int32
+}
+
+// Label is the text printed for the line, deposits are named after their article
+func (line Line) Label() string {
+	switch line.Kind {
+	case db.ArticleTransactionDeposit:
+		return "Deposit " + line.Article
+	case db.ArticleTransactionDepositReturn:
+		return "Deposit return " + line.Article
+	default:
+		return line.Article
+	}
+}
+
+// Total is the price of all units of the line
+func (line Line) Total() util.Money {
+	return line.Price.Mul(line.Amount)
+}
+
+// Receipt holds everything printed on the receipt of a transaction. Shares
+// of a split bill are printed with the lines of the whole bill.
+type Receipt struct {
+	Header      Header
+	Transaction db.Transaction
+	Lines       []Line
+	Vat         []db.VatBreakdown
+	Shares      []db.Transaction
+}
+
+// Load collects the receipt of a transaction. The lines of a split bill
+// belong to the bill, so a share is printed with the lines of its bill.
+func Load(ctx context.Context, q *db.Queries, header Header, transactionUuid uuid.UUID) (Receipt, error) {
+	r := Receipt{Header: header}
+
+	var err error
+	r.Transaction, err = q.GetTransactionById(ctx, transactionUuid)
+	if err != nil {
+		return r, err
+	}
+
+	bill := r.Transaction.Uuid
+	if r.Transaction.SplitOf.Valid {
+		bill = r.Transaction.SplitOf.UUID
+	} else if r.Transaction.PaymentBackend == db.PaymentBackendSplit {
+		r.Shares, err = q.GetSplitShares(ctx, uuid.NullUUID{UUID: bill, Valid: true})
+		if err != nil {
+			return r, err
+		}
+	}
+
+	rows, err := q.GetReceiptLines(ctx, bill)
+	if err != nil {
+		return r, err
+	}
+	r.Lines = make([]Line, 0, len(rows))
+	for _, row := range rows {
+		r.Lines = append(r.Lines, Line{Article: row.ArticleName, Kind: row.Kind, Amount: row.Amount, Price: row.Price, VatRate: row.VatRate})
+	}
+
+	articleTransactions, err := q.GetArticleTransactionsByTransaction(ctx, bill)
+	if err != nil {
+		return r, err
+	}
+	r.Vat = db.GetVatBreakdown(articleTransactions)
+
+	return r, nil
+}
+
+// Resident is the name printed for the payer
+func (r Receipt) Resident() string {
+	if r.Transaction.Resident.Valid {
+		return r.Transaction.Resident.String
+	}
+	if len(r.Shares) > 0 {
+		return "split bill"
+	}
+	return "guest"
+}
+
+// Render renders the receipt in the given format and returns it with its content type
+func Render(r Receipt, format string) ([]byte, string, error) {
+	switch format {
+	case Text, "":
+		return []byte(strings.Join(r.textLines(), "\n") + "\n"), "text/plain; charset=utf-8", nil
+	case HTML:
+		var buf bytes.Buffer
+		if err := htmlTemplate.Execute(&buf, r); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "text/html; charset=utf-8", nil
+	case PDF:
+		return renderPDF(r.textLines()), "application/pdf", nil
+	default:
+		return nil, "", fmt.Errorf("unknown receipt format %q", format)
+	}
+}
+
+// textLines lays out the receipt in lines of a fixed width, they are used
+// for text and PDF receipts
+func (r Receipt) textLines() []string {
+	var lines []string
+	separator := strings.Repeat("-", width)
+	row := func(left string, right string) {
+		lines = append(lines, fmt.Sprintf("%-*s%*s", width-len([]rune(right)), left, len([]rune(right)), right))
+	}
+	center := func(text string) {
+		pad := (width - len([]rune(text))) / 2
+		if pad < 0 {
+			pad = 0
+		}
+		lines = append(lines, strings.Repeat(" ", pad)+text)
+	}
+
+	if r.Header.Name != "" {
+		center(r.Header.Name)
+	}
+	if r.Header.Address != "" {
+		center(r.Header.Address)
+	}
+	lines = append(lines, separator)
+
+	row("Date", r.Transaction.Date.Format("2006-01-02 15:04"))
+	row("Resident", r.Resident())
+	row("Receipt", r.Transaction.Uuid.String()[:8])
+	if r.Transaction.RefundOf.Valid {
+		row("Refund of", r.Transaction.RefundOf.UUID.String()[:8])
+	}
+	if r.Transaction.Status != db.TransactionStatusCharged {
+		row("Status", r.Transaction.Status)
+	}
+	lines = append(lines, separator)
+
+	for _, line := range r.Lines {
+		lines = append(lines, line.Label())
+		row(fmt.Sprintf("  %d x %s", line.Amount, line.Price), line.Total().String())
+	}
+	lines = append(lines, separator)
+
+	if r.Transaction.SplitOf.Valid {
+		row("Total of the bill", total(r.Lines).String())
+		row("Your share", r.Transaction.Price.String())
+	} else {
+		row("Total", r.Transaction.Price.String())
+	}
+	for _, share := range r.Shares {
+		row("  paid by "+share.Resident.String, share.Price.String())
+	}
+
+	if len(r.Vat) > 0 {
+		lines = append(lines, "")
+		for _, vat := range r.Vat {
+			row(fmt.Sprintf("VAT %d%% of %s", vat.VatRate, vat.Gross), vat.Tax.String())
+			row("  net", vat.Net.String())
+		}
+	}
+
+	if r.Transaction.BalanceAfter.Valid {
+		lines = append(lines, "")
+		row("Balance after payment", r.Transaction.BalanceAfter.Money.String())
+	}
+
+	return lines
+}
+
+func total(lines []Line) util.Money {
+	var sum util.Money
+	for _, line := range lines {
+		sum += line.Total()
+	}
+	return sum
+}
+
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{"total": total}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Receipt {{.Transaction.Uuid}}</title>
+<style>
+body { font-family: sans-serif; max-width: 28em; margin: 2em auto; }
+header { text-align: center; }
+table { width: 100%; border-collapse: collapse; }
+td { padding: 0.2em 0; }
+td.amount { text-align: right; }
+tr.total td { border-top: 1px solid; font-weight: bold; }
+</style>
+</head>
+<body>
+<header>
+{{with .Header.Name}}<h1>{{.}}</h1>{{end}}
+{{with .Header.Address}}<p>{{.}}</p>{{end}}
+</header>
+<table>
+<tr><td>Date</td><td class="amount">{{.Transaction.Date.Format "2006-01-02 15:04"}}</td></tr>
+<tr><td>Resident</td><td class="amount">{{.Resident}}</td></tr>
+<tr><td>Receipt</td><td class="amount">{{.Transaction.Uuid}}</td></tr>
+{{if .Transaction.RefundOf.Valid}}<tr><td>Refund of</td><td class="amount">{{.Transaction.RefundOf.UUID}}</td></tr>{{end}}
+{{if ne .Transaction.Status "charged"}}<tr><td>Status</td><td class="amount">{{.Transaction.Status}}</td></tr>{{end}}
+</table>
+<table>
+{{range .Lines}}<tr><td>{{.Label}}</td><td class="amount">{{.Amount}} x {{.Price}}</td><td class="amount">{{.Total}}</td></tr>
+{{end}}
+{{if .Transaction.SplitOf.Valid}}<tr class="total"><td>Total of the bill</td><td></td><td class="amount">{{total .Lines}}</td></tr>
+<tr><td>Your share</td><td></td><td class="amount">{{.Transaction.Price}}</td></tr>
+{{else}}<tr class="total"><td>Total</td><td></td><td class="amount">{{.Transaction.Price}}</td></tr>
+{{end}}
+{{range .Shares}}<tr><td>paid by {{.Resident.String}}</td><td></td><td class="amount">{{.Price}}</td></tr>
+{{end}}
+</table>
+{{if .Vat}}<table>
+<tr><td>VAT</td><td class="amount">Gross</td><td class="amount">Net</td><td class="amount">Tax</td></tr>
+{{range .Vat}}<tr><td>{{.VatRate}}%</td><td class="amount">{{.Gross}}</td><td class="amount">{{.Net}}</td><td class="amount">{{.Tax}}</td></tr>
+{{end}}
+</table>{{end}}
+{{if .Transaction.BalanceAfter.Valid}}<table>
+<tr><td>Balance after payment</td><td class="amount">{{.Transaction.BalanceAfter.Money}}</td></tr>
+</table>{{end}}
+</body>
+</html>
+`))
@@ -20,5 +20,14 @@ func (cr *ArticleRoutes) ArticleRoute(rg *gin.RouterGroup) {
     router.GET("/", cr.ArticleController.GetAllArticles)
     router.PATCH("/:articleId", cr.ArticleController.UpdateArticle)
     router.GET("/:articleId", cr.ArticleController.GetArticleById)
-    router.DELETE("/:articleId", cr.ArticleController.DeleteArticleById)
+    router.DELETE("/:articleId", cr.ArticleController.ArchiveArticleById)
+    router.POST("/:articleId/restore", cr.ArticleController.RestoreArticleById)
+    router.GET("/:articleId/price", cr.ArticleController.GetArticlePrices)
+    router.POST("/:articleId/price", cr.ArticleController.ScheduleArticlePrice)
+    router.DELETE("/:articleId/price/:priceId", cr.ArticleController.UnscheduleArticlePrice)
+    router.GET("/:articleId/components", cr.ArticleController.GetArticleComponents)
+    router.PUT("/:articleId/components", cr.ArticleController.SetArticleComponents)
+    router.POST("/:articleId/stock/delivery", cr.ArticleController.DeliverStock)
+    router.POST("/:articleId/stock/correction", cr.ArticleController.CorrectStock)
+    router.GET("/:articleId/stock/movements", cr.ArticleController.GetStockMovements)
 }
\ No newline at end of file
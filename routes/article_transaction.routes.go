@@ -19,6 +19,8 @@ func (cr *ArticleTransactionRoutes) ArticleTransactionRoute(rg *gin.RouterGroup)
 	router.POST("/", cr.ArticleTransactionController.CreateArticleTransaction)
 	router.GET("/", cr.ArticleTransactionController.GetAllArticleTransactions)
 	router.GET("/grouped-by-article", cr.ArticleTransactionController.GetAllArticleTransactionsGroupedByArticle)
+	router.GET("/grouped-by-pricing-rule", cr.ArticleTransactionController.GetAllArticleTransactionsGroupedByPricingRule)
+	router.GET("/deposits", cr.ArticleTransactionController.GetDepositLiabilities)
 	router.PATCH("/:articleTransactionId", cr.ArticleTransactionController.UpdateArticleTransaction)
 	router.GET("/:articleTransactionId", cr.ArticleTransactionController.GetArticleTransactionById)
 	router.DELETE("/:articleTransactionId", cr.ArticleTransactionController.DeleteArticleTransactionById)
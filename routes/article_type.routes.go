@@ -21,5 +21,6 @@ func (cr *ArticleTypeRoutes) ArticleTypeRoute(rg *gin.RouterGroup) {
 	router.GET("/article", cr.ArticleTypeController.GetAllArticleTypesWithArticles)
 	router.PATCH("/:articleTypeId", cr.ArticleTypeController.UpdateArticleType)
 	router.GET("/:articleTypeId", cr.ArticleTypeController.GetArticleTypeById)
-	router.DELETE("/:articleTypeId", cr.ArticleTypeController.DeleteArticleTypeById)
+	router.DELETE("/:articleTypeId", cr.ArticleTypeController.ArchiveArticleTypeById)
+	router.POST("/:articleTypeId/restore", cr.ArticleTypeController.RestoreArticleTypeById)
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type CheckoutRoutes struct {
+	CheckoutController controllers.CheckoutController
+}
+
+func NewRouteCheckout(CheckoutController controllers.CheckoutController) CheckoutRoutes {
+	return CheckoutRoutes{CheckoutController}
+}
+
+func (cr *CheckoutRoutes) CheckoutRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("checkout")
+	router.POST("/", cr.CheckoutController.Checkout)
+	router.POST("/split", cr.CheckoutController.SplitCheckout)
+	router.POST("/deposit-return", cr.CheckoutController.DepositReturn)
+}
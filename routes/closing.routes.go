@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type ClosingRoutes struct {
+	ClosingController controllers.ClosingController
+}
+
+func NewRouteClosing(ClosingController controllers.ClosingController) ClosingRoutes {
+	return ClosingRoutes{ClosingController}
+}
+
+func (cr *ClosingRoutes) ClosingRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("closing")
+	router.POST("/", cr.ClosingController.CloseDay)
+	router.GET("/", cr.ClosingController.GetAllClosings)
+	router.GET("/:closingId", cr.ClosingController.GetClosingById)
+}
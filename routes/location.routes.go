@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type LocationRoutes struct {
+	LocationController controllers.LocationController
+}
+
+func NewRouteLocation(LocationController controllers.LocationController) LocationRoutes {
+	return LocationRoutes{LocationController}
+}
+
+func (cr *LocationRoutes) LocationRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("location")
+	router.POST("/", cr.LocationController.CreateLocation)
+	router.GET("/", cr.LocationController.GetAllLocations)
+	router.GET("/stock", cr.LocationController.GetStockReport)
+	router.POST("/transfer", cr.LocationController.TransferStock)
+	router.GET("/transfer", cr.LocationController.GetStockTransfers)
+	router.PATCH("/:locationId", cr.LocationController.UpdateLocation)
+	router.GET("/:locationId", cr.LocationController.GetLocationById)
+	router.DELETE("/:locationId", cr.LocationController.DeleteLocationById)
+	router.POST("/:locationId/default", cr.LocationController.SetDefaultLocation)
+}
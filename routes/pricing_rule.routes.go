@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type PricingRuleRoutes struct {
+	PricingRuleController controllers.PricingRuleController
+}
+
+func NewRoutePricingRule(PricingRuleController controllers.PricingRuleController) PricingRuleRoutes {
+	return PricingRuleRoutes{PricingRuleController}
+}
+
+func (cr *PricingRuleRoutes) PricingRuleRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("pricing-rule")
+	router.POST("/", cr.PricingRuleController.CreatePricingRule)
+	router.GET("/", cr.PricingRuleController.GetAllPricingRules)
+	router.PATCH("/:pricingRuleId", cr.PricingRuleController.UpdatePricingRule)
+	router.GET("/:pricingRuleId", cr.PricingRuleController.GetPricingRuleById)
+	router.DELETE("/:pricingRuleId", cr.PricingRuleController.DeletePricingRuleById)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderRoutes struct {
+	PurchaseOrderController controllers.PurchaseOrderController
+}
+
+func NewRoutePurchaseOrder(PurchaseOrderController controllers.PurchaseOrderController) PurchaseOrderRoutes {
+	return PurchaseOrderRoutes{PurchaseOrderController}
+}
+
+func (cr *PurchaseOrderRoutes) PurchaseOrderRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("purchase-order")
+	router.POST("/", cr.PurchaseOrderController.CreatePurchaseOrder)
+	router.GET("/", cr.PurchaseOrderController.GetAllPurchaseOrders)
+	router.GET("/:purchaseOrderId", cr.PurchaseOrderController.GetPurchaseOrderById)
+	router.POST("/:purchaseOrderId/receipt", cr.PurchaseOrderController.ReceiveGoods)
+	router.POST("/:purchaseOrderId/cancel", cr.PurchaseOrderController.CancelPurchaseOrder)
+}
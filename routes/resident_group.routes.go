@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type ResidentGroupRoutes struct {
+	ResidentGroupController controllers.ResidentGroupController
+}
+
+func NewRouteResidentGroup(ResidentGroupController controllers.ResidentGroupController) ResidentGroupRoutes {
+	return ResidentGroupRoutes{ResidentGroupController}
+}
+
+func (cr *ResidentGroupRoutes) ResidentGroupRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("group")
+	router.POST("/", cr.ResidentGroupController.CreateResidentGroup)
+	router.GET("/", cr.ResidentGroupController.GetAllResidentGroups)
+	router.PATCH("/:name", cr.ResidentGroupController.UpdateResidentGroup)
+	router.GET("/:name", cr.ResidentGroupController.GetResidentGroupById)
+	router.DELETE("/:name", cr.ResidentGroupController.DeleteResidentGroupById)
+}
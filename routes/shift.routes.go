@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type ShiftRoutes struct {
+	ShiftController controllers.ShiftController
+}
+
+func NewRouteShift(ShiftController controllers.ShiftController) ShiftRoutes {
+	return ShiftRoutes{ShiftController}
+}
+
+func (cr *ShiftRoutes) ShiftRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("shift")
+	router.POST("/open", cr.ShiftController.OpenShift)
+	router.POST("/close", cr.ShiftController.CloseShift)
+	router.GET("/", cr.ShiftController.GetAllShifts)
+	router.GET("/:shiftId", cr.ShiftController.GetShiftById)
+}
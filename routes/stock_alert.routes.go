@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type StockAlertRoutes struct {
+	StockAlertController controllers.StockAlertController
+}
+
+func NewRouteStockAlert(StockAlertController controllers.StockAlertController) StockAlertRoutes {
+	return StockAlertRoutes{StockAlertController}
+}
+
+func (cr *StockAlertRoutes) StockAlertRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("stock-alert")
+	router.GET("/", cr.StockAlertController.GetAllStockAlerts)
+}
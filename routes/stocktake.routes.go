@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type StocktakeRoutes struct {
+	StocktakeController controllers.StocktakeController
+}
+
+func NewRouteStocktake(StocktakeController controllers.StocktakeController) StocktakeRoutes {
+	return StocktakeRoutes{StocktakeController}
+}
+
+func (cr *StocktakeRoutes) StocktakeRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("stocktake")
+	router.POST("/", cr.StocktakeController.OpenStocktake)
+	router.GET("/", cr.StocktakeController.GetAllStocktakes)
+	router.GET("/:stocktakeId", cr.StocktakeController.GetStocktakeById)
+	router.POST("/:stocktakeId/count", cr.StocktakeController.CountStocktake)
+	router.POST("/:stocktakeId/close", cr.StocktakeController.CloseStocktake)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type SupplierRoutes struct {
+	SupplierController controllers.SupplierController
+}
+
+func NewRouteSupplier(SupplierController controllers.SupplierController) SupplierRoutes {
+	return SupplierRoutes{SupplierController}
+}
+
+func (cr *SupplierRoutes) SupplierRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("supplier")
+	router.POST("/", cr.SupplierController.CreateSupplier)
+	router.GET("/", cr.SupplierController.GetAllSuppliers)
+	router.PATCH("/:supplierId", cr.SupplierController.UpdateSupplier)
+	router.GET("/:supplierId", cr.SupplierController.GetSupplierById)
+	router.DELETE("/:supplierId", cr.SupplierController.DeleteSupplierById)
+}
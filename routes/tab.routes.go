@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type TabRoutes struct {
+	TabController controllers.TabController
+}
+
+func NewRouteTab(TabController controllers.TabController) TabRoutes {
+	return TabRoutes{TabController}
+}
+
+func (cr *TabRoutes) TabRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("tab")
+	router.POST("/", cr.TabController.OpenTab)
+	router.GET("/", cr.TabController.GetAllTabs)
+	router.GET("/:tabId", cr.TabController.GetTabById)
+	router.POST("/:tabId/items", cr.TabController.AddTabItems)
+	router.POST("/:tabId/close", cr.TabController.CloseTab)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type TerminalRoutes struct {
+	TerminalController controllers.TerminalController
+}
+
+func NewRouteTerminal(TerminalController controllers.TerminalController) TerminalRoutes {
+	return TerminalRoutes{TerminalController}
+}
+
+func (cr *TerminalRoutes) TerminalRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("terminal")
+	router.POST("/", cr.TerminalController.CreateTerminal)
+	router.GET("/", cr.TerminalController.GetAllTerminals)
+	router.PATCH("/:terminalId", cr.TerminalController.UpdateTerminal)
+	router.GET("/:terminalId", cr.TerminalController.GetTerminalById)
+	router.DELETE("/:terminalId", cr.TerminalController.DeleteTerminalById)
+}
@@ -16,10 +16,15 @@ func NewRouteTransaction(TransactionController controllers.TransactionController
 func (cr *TransactionRoutes) TransactionRoute(rg *gin.RouterGroup) {
 
 	router := rg.Group("transaction")
-	router.POST("/:username", cr.TransactionController.CreateTransaction)
+	router.POST("/checkout", cr.TransactionController.CheckoutTransaction)
+	router.POST("/:transactionId", cr.TransactionController.CreateTransaction) // deprecated, the segment is the username
 	router.GET("/", cr.TransactionController.GetAllTransactions)
 	router.PATCH("/:transactionId", cr.TransactionController.UpdateTransaction)
 	router.GET("/:transactionId", cr.TransactionController.GetTransactionById)
+	router.GET("/:transactionId/receipt", cr.TransactionController.GetTransactionReceipt)
 	router.DELETE("/:transactionId", cr.TransactionController.DeleteTransactionById)
 	router.GET("/sava", cr.TransactionController.GetSavaPageUser)
+	router.GET("/reconciliation", cr.TransactionController.GetPaymentsNeedingReconciliation)
+	router.POST("/:transactionId/reconcile", cr.TransactionController.ReconcileTransaction)
+	router.POST("/:transactionId/refund", cr.TransactionController.RefundTransaction)
 }
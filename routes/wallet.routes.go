@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type WalletRoutes struct {
+	WalletController controllers.WalletController
+}
+
+func NewRouteWallet(WalletController controllers.WalletController) WalletRoutes {
+	return WalletRoutes{WalletController}
+}
+
+func (cr *WalletRoutes) WalletRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("wallet")
+	router.GET("/:username", cr.WalletController.GetWallet)
+	router.GET("/:username/entries", cr.WalletController.GetWalletEntries)
+	router.POST("/:username/topup", cr.WalletController.TopUpWallet)
+	router.POST("/:username/correction", cr.WalletController.CorrectWallet)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookRoutes struct {
+	WebhookController controllers.WebhookController
+}
+
+func NewRouteWebhook(WebhookController controllers.WebhookController) WebhookRoutes {
+	return WebhookRoutes{WebhookController}
+}
+
+func (cr *WebhookRoutes) WebhookRoute(rg *gin.RouterGroup) {
+
+	router := rg.Group("webhook")
+	router.POST("/", cr.WebhookController.CreateWebhook)
+	router.GET("/", cr.WebhookController.GetAllWebhooks)
+	router.DELETE("/:webhookId", cr.WebhookController.DeleteWebhookById)
+}
@@ -1,22 +1,67 @@
 package schemas
 
 import (
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/google/uuid"
 	"github.com/guregu/null/v5"
 )
 
 type CreateArticle struct {
-	Name            string      `json:"name" binding:"required"`
-	Desc            null.String `json:"desc"`
-	PurchasePrice   float64     `json:"purchase_price" binding:"required"`
-	ResellPrice     float64     `json:"resell_price" binding:"required"`
-	ArticleTypeUuid uuid.UUID   `json:"article_type_uuid" binding:"required"`
+	Name             string      `json:"name" binding:"required"`
+	Desc             null.String `json:"desc"`
+	PurchasePrice    util.Money  `json:"purchase_price" binding:"required,min=0"`
+	ResellPrice      util.Money  `json:"resell_price" binding:"required,min=0"`
+	ArticleTypeUuid  uuid.UUID   `json:"article_type_uuid" binding:"required"`
+	Deposit          util.Money  `json:"deposit" binding:"min=0"`                                 // optional, charged as a separate line with every unit
+	VatRate          null.Int32  `json:"vat_rate"`                                                // optional, overrides the VAT rate of the article type
+	StockPolicy      string      `json:"stock_policy" binding:"omitempty,oneof=allow warn block"` // optional, defaults to allow
+	ReorderThreshold null.Int32  `json:"reorder_threshold"`                                       // optional, the purchasing team is alerted when the stock falls below it
 }
 
 type UpdateArticle struct {
-	Name            null.String   `json:"name"`
-	Desc            null.String   `json:"desc"`
-	PurchasePrice   null.Float    `json:"purchase_price"`
-	ResellPrice     null.Float    `json:"resell_price"`
-	ArticleTypeUuid uuid.NullUUID `json:"article_type_uuid"`
+	Name             null.String    `json:"name"`
+	Desc             null.String    `json:"desc"`
+	PurchasePrice    util.NullMoney `json:"purchase_price" binding:"omitempty,min=0"`
+	ResellPrice      util.NullMoney `json:"resell_price" binding:"omitempty,min=0"`
+	ArticleTypeUuid  uuid.NullUUID  `json:"article_type_uuid"`
+	Deposit          util.NullMoney `json:"deposit" binding:"omitempty,min=0"`
+	VatRate          null.Int32     `json:"vat_rate"`
+	StockPolicy      null.String    `json:"stock_policy"`
+	ReorderThreshold null.Int32     `json:"reorder_threshold"`
+}
+
+type ArchivedFilter struct {
+	Archived bool `form:"archived"` // include archived items
+}
+
+type MenuFilter struct {
+	At time.Time `form:"at"` // RFC 3339, defaults to now
+}
+
+type ScheduleArticlePrice struct {
+	ResellPrice util.Money `json:"resell_price" binding:"required,min=0"`
+	ValidFrom   time.Time  `json:"valid_from" binding:"required" example:"2024-11-01T00:00:00Z"`
+}
+
+type ArticleComponent struct {
+	ArticleUuid uuid.UUID `json:"article_uuid" binding:"required"`
+	Amount      int32     `json:"amount" binding:"required,min=1"`
+}
+
+type SetArticleComponents struct {
+	Components []ArticleComponent `json:"components" binding:"dive"` // empty turns the bundle into a plain article
+}
+
+type StockDelivery struct {
+	Quantity     int32         `json:"quantity" binding:"required,min=1"`
+	Note         null.String   `json:"note"`
+	LocationUuid uuid.NullUUID `json:"location_uuid" swaggertype:"string"` // optional, defaults to the default location
+}
+
+type StockCorrection struct {
+	Quantity     int32         `json:"quantity" binding:"required"`        // negative takes units out of the stock
+	Note         string        `json:"note" binding:"required"`            // reason of the correction, e.g. breakage
+	LocationUuid uuid.NullUUID `json:"location_uuid" swaggertype:"string"` // optional, defaults to the default location
 }
@@ -1,20 +1,21 @@
 package schemas
 
 import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/google/uuid"
 	"github.com/guregu/null/v5"
 )
 
 type CreateArticleTransaction struct {
-	ArticleUuid     uuid.UUID `json:"article_uuid" binding:"required"`
-	TransactionUuid uuid.UUID `json:"transaction_uuid" binding:"required"`
-	Amount          int32     `json:"amount" binding:"required"`
-	Price           float64   `json:"price" binding:"required"`
+	ArticleUuid     uuid.UUID      `json:"article_uuid" binding:"required"`
+	TransactionUuid uuid.UUID      `json:"transaction_uuid" binding:"required"`
+	Amount          int32          `json:"amount" binding:"required,min=1"`
+	Price           util.NullMoney `json:"price"` // optional, has to match the server side unit price
 }
 
 type UpdateArticleTransaction struct {
-	ArticleUuid     uuid.NullUUID `json:"article_uuid"`
-	TransactionUuid uuid.NullUUID `json:"transaction_uuid"`
-	Amount          null.Int32    `json:"amount"`
-	Price           null.Float    `json:"price"`
+	ArticleUuid     uuid.NullUUID  `json:"article_uuid"`
+	TransactionUuid uuid.NullUUID  `json:"transaction_uuid"`
+	Amount          null.Int32     `json:"amount" binding:"omitempty,min=1"`
+	Price           util.NullMoney `json:"price"` // optional, has to match the server side unit price
 }
@@ -2,6 +2,8 @@ package schemas
 
 import (
 	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
 	"github.com/guregu/null/v5"
 )
 
@@ -10,6 +12,7 @@ type CreateArticleType struct {
 	Desc          null.String `json:"desc"`
 	IconCodepoint int32       `json:"icon_codepoint" binding:"required"`
 	Color         string      `json:"color" binding:"required"`
+	VatRate       null.Int32  `json:"vat_rate"` // optional, defaults to the standard rate
 }
 
 type UpdateArticleType struct {
@@ -17,9 +20,17 @@ type UpdateArticleType struct {
 	Desc          null.String `json:"desc"`
 	IconCodepoint null.Int32  `json:"icon_codepoint"`
 	Color         null.String `json:"color"`
+	VatRate       null.Int32  `json:"vat_rate"`
+}
+
+// MenuArticle is an article with the price it is sold at
+type MenuArticle struct {
+	db.Article
+	Price           util.Money    `json:"price"`
+	PricingRuleUuid uuid.NullUUID `json:"pricing_rule_uuid" swaggertype:"string"`
 }
 
 type ArticleTypeWithArticles struct {
 	db.ArticleType
-	Articles []db.Article `json:"articles"`
+	Articles []MenuArticle `json:"articles"`
 }
@@ -0,0 +1,38 @@
+package schemas
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+type CheckoutItem struct {
+	ArticleUuid uuid.UUID `json:"article_uuid" binding:"required"`
+	Amount      int32     `json:"amount" binding:"required,min=1"`
+}
+
+type Checkout struct {
+	Resident     string         `json:"resident"` // optional, guests without a resident pay cash
+	TerminalUuid uuid.NullUUID  `json:"terminal_uuid" swaggertype:"string"`
+	Items        []CheckoutItem `json:"items" binding:"required,min=1,dive"`
+	Total        util.NullMoney `json:"total"` // optional, has to match the server side total
+}
+
+type SplitShare struct {
+	Resident string         `json:"resident" binding:"required"`
+	Amount   util.NullMoney `json:"amount"` // required in amounts mode
+	Items    []int          `json:"items"`  // indices into the items of the cart, required in items mode
+}
+
+type SplitCheckout struct {
+	TerminalUuid uuid.NullUUID  `json:"terminal_uuid" swaggertype:"string"`
+	Items        []CheckoutItem `json:"items" binding:"required,min=1,dive"`
+	Total        util.NullMoney `json:"total"` // optional, has to match the server side total
+	Mode         string         `json:"mode" binding:"required,oneof=even amounts items"`
+	Shares       []SplitShare   `json:"shares" binding:"required,min=2,dive"`
+}
+
+type DepositReturn struct {
+	Resident     string         `json:"resident"` // optional, guests are paid out in cash
+	TerminalUuid uuid.NullUUID  `json:"terminal_uuid" swaggertype:"string"`
+	Items        []CheckoutItem `json:"items" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,9 @@
+package schemas
+
+import (
+	"github.com/guregu/null/v5"
+)
+
+type CloseDay struct {
+	Until null.Time `json:"until" swaggertype:"string" example:"2024-01-25T06:00:00Z"` // optional, defaults to now
+}
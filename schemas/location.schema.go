@@ -0,0 +1,27 @@
+package schemas
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type CreateLocation struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type UpdateLocation struct {
+	Name null.String `json:"name"`
+}
+
+type TransferStock struct {
+	ArticleUuid      uuid.UUID   `json:"article_uuid" binding:"required"`
+	FromLocationUuid uuid.UUID   `json:"from_location_uuid" binding:"required"`
+	ToLocationUuid   uuid.UUID   `json:"to_location_uuid" binding:"required"`
+	Quantity         int32       `json:"quantity" binding:"required,min=1"`
+	Note             null.String `json:"note"`
+}
+
+type StockTransferFilter struct {
+	ArticleUuid  string `form:"article_uuid" binding:"omitempty,uuid"`
+	LocationUuid string `form:"location_uuid" binding:"omitempty,uuid"` // transfers from or to the location
+}
@@ -0,0 +1,30 @@
+package schemas
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type CreatePricingRule struct {
+	Name            string         `json:"name" binding:"required"`
+	ArticleUuid     uuid.NullUUID  `json:"article_uuid" swaggertype:"string"`
+	ArticleTypeUuid uuid.NullUUID  `json:"article_type_uuid" swaggertype:"string"`
+	EventUuid       uuid.NullUUID  `json:"event_uuid" swaggertype:"string"`
+	Weekdays        null.Int32     `json:"weekdays"`    // bit n for weekday n, sunday is 0, empty is every day
+	StartsAt        null.Int32     `json:"starts_at"`   // minutes since midnight
+	EndsAt          null.Int32     `json:"ends_at"`     // minutes since midnight, before starts_at wraps midnight
+	FixedPrice      util.NullMoney `json:"fixed_price"` // either a fixed price
+	PercentOff      null.Int32     `json:"percent_off"` // or a discount in percent
+	Priority        int32          `json:"priority"`
+}
+
+type UpdatePricingRule struct {
+	Name       null.String    `json:"name"`
+	Weekdays   null.Int32     `json:"weekdays"`
+	StartsAt   null.Int32     `json:"starts_at"`
+	EndsAt     null.Int32     `json:"ends_at"`
+	FixedPrice util.NullMoney `json:"fixed_price"`
+	PercentOff null.Int32     `json:"percent_off"`
+	Priority   null.Int32     `json:"priority"`
+}
@@ -0,0 +1,36 @@
+package schemas
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type PurchaseOrderLine struct {
+	ArticleUuid uuid.UUID  `json:"article_uuid" binding:"required"`
+	Quantity    int32      `json:"quantity" binding:"required,min=1"`
+	UnitCost    util.Money `json:"unit_cost" binding:"min=0"` // agreed cost per unit
+}
+
+type CreatePurchaseOrder struct {
+	SupplierUuid uuid.UUID           `json:"supplier_uuid" binding:"required"`
+	Note         null.String         `json:"note"`
+	Lines        []PurchaseOrderLine `json:"lines" binding:"required,min=1,dive"`
+}
+
+type GoodsReceiptLine struct {
+	PurchaseOrderLineUuid uuid.UUID      `json:"purchase_order_line_uuid" binding:"required"`
+	Quantity              int32          `json:"quantity" binding:"required,min=1"`
+	UnitCost              util.NullMoney `json:"unit_cost" binding:"omitempty,min=0"` // optional, the actual cost per unit if it differs from the order
+}
+
+type ReceiveGoods struct {
+	Note         null.String        `json:"note"`                               // e.g. the delivery note number
+	LocationUuid uuid.NullUUID      `json:"location_uuid" swaggertype:"string"` // optional, where the goods are stored, defaults to the default location
+	Lines        []GoodsReceiptLine `json:"lines" binding:"required,min=1,dive"`
+}
+
+type PurchaseOrderFilter struct {
+	SupplierUuid string `form:"supplier_uuid" binding:"omitempty,uuid"`
+	Status       string `form:"status" binding:"omitempty,oneof=open partially_received received cancelled"`
+}
@@ -0,0 +1,16 @@
+package schemas
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+type CreateResidentGroup struct {
+	Name       string         `json:"name" binding:"required"`
+	MinBalance util.NullMoney `json:"min_balance"` // optional, lowest balance a sale may leave
+	DailyCap   util.NullMoney `json:"daily_cap"`   // optional, most a resident may spend per day
+}
+
+type UpdateResidentGroup struct {
+	MinBalance util.NullMoney `json:"min_balance"`
+	DailyCap   util.NullMoney `json:"daily_cap"`
+}
@@ -0,0 +1,28 @@
+package schemas
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+type OpenShift struct {
+	Bartender    string        `json:"bartender" binding:"required"`
+	TerminalUuid uuid.NullUUID `json:"terminal_uuid" swaggertype:"string"` // optional, the drawer of the terminal
+	EventUuid    uuid.NullUUID `json:"event_uuid" swaggertype:"string"`    // optional, defaults to the running event
+	OpeningFloat util.Money    `json:"opening_float"`
+}
+
+type DenominationCount struct {
+	Denomination util.Money `json:"denomination" binding:"required"`
+	Count        int32      `json:"count" binding:"min=0"`
+}
+
+type CloseShift struct {
+	ShiftUuid uuid.UUID           `json:"shift_uuid" binding:"required"`
+	Counts    []DenominationCount `json:"counts" binding:"dive"`
+}
+
+type ShiftFilter struct {
+	EventUuid string `form:"event_uuid" binding:"omitempty,uuid"`
+	Open      bool   `form:"open"`
+}
@@ -0,0 +1,5 @@
+package schemas
+
+type StockAlertFilter struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=500"` // defaults to 50
+}
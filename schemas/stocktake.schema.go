@@ -0,0 +1,21 @@
+package schemas
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type OpenStocktake struct {
+	Note         null.String   `json:"note"`
+	LocationUuid uuid.NullUUID `json:"location_uuid" swaggertype:"string"` // optional, counts only the location instead of the total stock
+}
+
+type StocktakeCount struct {
+	ArticleUuid uuid.UUID `json:"article_uuid" binding:"required"`
+	Counted     int32     `json:"counted" binding:"min=0"`
+	Replace     bool      `json:"replace"` // optional, overwrites earlier counts of the article instead of adding to them
+}
+
+type CountStocktake struct {
+	Counts []StocktakeCount `json:"counts" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,19 @@
+package schemas
+
+import (
+	"github.com/guregu/null/v5"
+)
+
+type CreateSupplier struct {
+	Name    string      `json:"name" binding:"required"`
+	Contact null.String `json:"contact"` // contact person
+	Email   null.String `json:"email"`
+	Phone   null.String `json:"phone"`
+}
+
+type UpdateSupplier struct {
+	Name    null.String `json:"name"`
+	Contact null.String `json:"contact"`
+	Email   null.String `json:"email"`
+	Phone   null.String `json:"phone"`
+}
@@ -0,0 +1,20 @@
+package schemas
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
+)
+
+type OpenTab struct {
+	Resident     string        `json:"resident" binding:"required"`
+	TerminalUuid uuid.NullUUID `json:"terminal_uuid" swaggertype:"string"`
+}
+
+type AddTabItems struct {
+	Items []CheckoutItem `json:"items" binding:"required,min=1,dive"`
+	Total util.NullMoney `json:"total"` // optional, has to match the server side total of the added items
+}
+
+type TabFilter struct {
+	Status string `form:"status" binding:"omitempty,oneof=open flagged closed"`
+}
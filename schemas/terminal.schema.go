@@ -0,0 +1,18 @@
+package schemas
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+type CreateTerminal struct {
+	Name           string        `json:"name" binding:"required"`
+	PaymentBackend null.String   `json:"payment_backend"`                    // savapage, wallet or cash
+	LocationUuid   uuid.NullUUID `json:"location_uuid" swaggertype:"string"` // optional, the location sales draw from, defaults to the default location
+}
+
+type UpdateTerminal struct {
+	Name           null.String   `json:"name"`
+	PaymentBackend null.String   `json:"payment_backend"`
+	LocationUuid   uuid.NullUUID `json:"location_uuid" swaggertype:"string"`
+}
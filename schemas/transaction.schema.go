@@ -3,15 +3,45 @@ package schemas
 import (
 	"time"
 
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/google/uuid"
 	"github.com/guregu/null/v5"
 )
 
+// CreateTransaction is the payload of the deprecated POST /transaction/{username}
 type CreateTransaction struct {
-	Date  time.Time `json:"date" binding:"required" example:"2024-01-24T00:00:00Z"`
-	Price float64   `json:"price" binding:"required"`
+	Date  time.Time  `json:"date" binding:"required" example:"2024-01-24T00:00:00Z"`
+	Price util.Money `json:"price" binding:"required"`
+}
+
+type CheckoutTransaction struct {
+	Resident string         `json:"resident" binding:"required"`
+	Items    []CheckoutItem `json:"items" binding:"required,min=1,dive"`
+	Price    util.NullMoney `json:"price"` // optional, has to match the server side total
+}
+
+type TransactionFilter struct {
+	Resident  string `form:"resident"`
+	EventUuid string `form:"event_uuid" binding:"omitempty,uuid"`
+}
+
+type ReceiptFilter struct {
+	Format string `form:"format" binding:"omitempty,oneof=txt html pdf"`
 }
 
 type UpdateTransaction struct {
-	Date  null.Time  `json:"date" example:"2024-01-24T00:00:00Z"`
-	Price null.Float `json:"price"`
+	Date null.Time `json:"date" example:"2024-01-24T00:00:00Z"`
+}
+
+type ReconcileTransaction struct {
+	Status string `json:"status" binding:"required,oneof=pending charged failed"`
+}
+
+type RefundItem struct {
+	ArticleTransactionUuid uuid.UUID `json:"article_transaction_uuid" binding:"required"`
+	Amount                 int32     `json:"amount" binding:"required,min=1"`
+}
+
+type RefundTransaction struct {
+	Items []RefundItem `json:"items" binding:"omitempty,dive"` // optional, refunds everything that is left when empty
 }
@@ -1,6 +1,7 @@
 package schemas
 
 import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
 	"github.com/guregu/null/v5"
 )
 
@@ -10,5 +11,9 @@ type CreateUser struct {
 }
 
 type UpdateUser struct {
-	Code null.String `json:"code"`
+	Code           null.String    `json:"code"`
+	PaymentBackend null.String    `json:"payment_backend"` // savapage, wallet or cash
+	GroupName      null.String    `json:"group_name"`
+	MinBalance     util.NullMoney `json:"min_balance"` // overrides the one of the group
+	DailyCap       util.NullMoney `json:"daily_cap"`   // overrides the one of the group
 }
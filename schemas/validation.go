@@ -0,0 +1,32 @@
+package schemas
+
+import (
+	"reflect"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/guregu/null/v5"
+)
+
+// binding tags of optional fields validate the value of the null types. A
+// missing value is nil and counts as empty, a zero value does not.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterCustomTypeFunc(nullValue, null.Int32{}, util.NullMoney{})
+	}
+}
+
+func nullValue(field reflect.Value) interface{} {
+	switch value := field.Interface().(type) {
+	case null.Int32:
+		if value.Valid {
+			return &value.Int32
+		}
+	case util.NullMoney:
+		if value.Valid {
+			return &value.Money
+		}
+	}
+	return nil
+}
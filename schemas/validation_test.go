@@ -0,0 +1,75 @@
+package schemas
+
+import (
+	"testing"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v5"
+)
+
+func TestUpdateArticleTransactionAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount null.Int32
+		valid  bool
+	}{
+		{"missing", null.Int32{}, true},
+		{"positive", null.Int32From(2), true},
+		{"zero", null.Int32From(0), false},
+		{"negative", null.Int32From(-1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := binding.Validator.ValidateStruct(&UpdateArticleTransaction{Amount: tt.amount})
+			if (err == nil) != tt.valid {
+				t.Errorf("amount %v: got error %v, want valid %v", tt.amount, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestUpdateArticleResellPrice(t *testing.T) {
+	tests := []struct {
+		name  string
+		price util.NullMoney
+		valid bool
+	}{
+		{"missing", util.NullMoney{}, true},
+		{"positive", util.NullMoneyFrom(250), true},
+		{"zero", util.NullMoneyFrom(0), true},
+		{"negative", util.NullMoneyFrom(-1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := binding.Validator.ValidateStruct(&UpdateArticle{ResellPrice: tt.price})
+			if (err == nil) != tt.valid {
+				t.Errorf("resell price %v: got error %v, want valid %v", tt.price, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderLineUnitCost(t *testing.T) {
+	tests := []struct {
+		name  string
+		cost  util.Money
+		valid bool
+	}{
+		{"positive", 120, true},
+		{"zero", 0, true},
+		{"negative", -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := binding.Validator.ValidateStruct(&PurchaseOrderLine{ArticleUuid: uuid.New(), Quantity: 1, UnitCost: tt.cost})
+			if (err == nil) != tt.valid {
+				t.Errorf("unit cost %v: got error %v, want valid %v", tt.cost, err, tt.valid)
+			}
+		})
+	}
+}
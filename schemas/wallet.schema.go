@@ -0,0 +1,19 @@
+package schemas
+
+import (
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+)
+
+type Wallet struct {
+	Resident string     `json:"resident"`
+	Balance  util.Money `json:"balance"`
+}
+
+type TopUpWallet struct {
+	Amount util.Money `json:"amount" binding:"required,gt=0"`
+}
+
+type CorrectWallet struct {
+	Amount util.Money `json:"amount" binding:"required"` // negative to take money from the wallet
+	Reason string     `json:"reason" binding:"required"`
+}
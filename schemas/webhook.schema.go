@@ -0,0 +1,10 @@
+package schemas
+
+import (
+	"github.com/guregu/null/v5"
+)
+
+type CreateWebhook struct {
+	Url    string      `json:"url" binding:"required,url"`
+	Secret null.String `json:"secret"` // optional, the body is signed with it in the X-Rupay-Signature header
+}
@@ -12,6 +12,7 @@ type Config struct {
 	SavaPageAdmin      string `mapstructure:"SAVAPAGE_ADMIN"`
 	SavaPagePassword   string `mapstructure:"SAVAPAGE_PASSWORD"`
 	SavaPageUrl        string `mapstructure:"SAVAPAGE_API"`
+	PaymentBackend     string `mapstructure:"PAYMENT_BACKEND"`
 	MqttBroker         string `mapstructure:"MQTT_BROKER"`
 	MqttClientId       string `mapstructure:"MQTT_CLIENT_ID"`
 	MqttClientName     string `mapstructure:"MQTT_CLIENT_NAME"`
@@ -19,6 +20,17 @@ type Config struct {
 	CertCaRoot         string `mapstructure:"CERT_CA_ROOT"`
 	CertMosquitto      string `mapstructure:"CERT_MOSQUITTO"`
 	KeyMosquitto       string `mapstructure:"KEY_MOSQUITTO"`
+	ReceiptName        string `mapstructure:"RECEIPT_NAME"`
+	ReceiptAddress     string `mapstructure:"RECEIPT_ADDRESS"`
+	ClosingTime        string `mapstructure:"CLOSING_TIME"`
+	BarTimezone        string `mapstructure:"BAR_TIMEZONE"`
+	StockAlertTopic    string `mapstructure:"STOCK_ALERT_TOPIC"`
+	SmtpHost           string `mapstructure:"SMTP_HOST"`
+	SmtpPort           string `mapstructure:"SMTP_PORT"`
+	SmtpUsername       string `mapstructure:"SMTP_USERNAME"`
+	SmtpPassword       string `mapstructure:"SMTP_PASSWORD"`
+	SmtpFrom           string `mapstructure:"SMTP_FROM"`
+	PurchasingEmail    string `mapstructure:"PURCHASING_EMAIL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -0,0 +1,154 @@
+package util
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in cents. It is stored as NUMERIC(12,2) and written to
+// JSON as a decimal number, so prices never pass through a float.
+type Money int64
+
+// ParseMoney parses a decimal string like "-1.5" or "12.30".
+// More than two decimal places are rejected instead of being rounded.
+func ParseMoney(s string) (Money, error) {
+	str := strings.TrimSpace(s)
+
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimLeft(str, "+-")
+
+	whole, frac, _ := strings.Cut(str, ".")
+	frac = strings.TrimRight(frac, "0")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("invalid amount %q: more than two decimal places", s)
+	}
+
+	if whole == "" {
+		whole = "0"
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+
+	euros, err := strconv.ParseUint(whole, 10, 63)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	cents, err := strconv.ParseUint(frac, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	m := Money(euros*100 + cents)
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+// String formats the amount with exactly two decimal places, e.g. "-1.50"
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Mul returns the price of n units
+func (m Money) Mul(n int32) Money {
+	return m * Money(n)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseMoney(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan implements the sql.Scanner interface
+func (m *Money) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		*m, err = ParseMoney(string(v))
+	case string:
+		*m, err = ParseMoney(v)
+	case int64:
+		*m = Money(v * 100)
+	case float64:
+		*m = Money(math.Round(v * 100))
+	default:
+		err = fmt.Errorf("cannot scan %T into Money", src)
+	}
+	return err
+}
+
+// Value implements the driver.Valuer interface
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// NullMoney is a nullable Money, in the spirit of the null package types
+type NullMoney struct {
+	Money Money
+	Valid bool
+}
+
+// NullMoneyFrom creates a valid NullMoney
+func NullMoneyFrom(m Money) NullMoney {
+	return NullMoney{Money: m, Valid: true}
+}
+
+func (n NullMoney) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Money.MarshalJSON()
+}
+
+func (n *NullMoney) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullMoney{}
+		return nil
+	}
+	if err := n.Money.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface
+func (n *NullMoney) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullMoney{}
+		return nil
+	}
+	if err := n.Money.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (n NullMoney) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Money.Value()
+}
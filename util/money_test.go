@@ -0,0 +1,176 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+		err  bool
+	}{
+		{in: "0", want: 0},
+		{in: "12.30", want: 1230},
+		{in: "12.3", want: 1230},
+		{in: "-1.5", want: -150},
+		{in: "+2", want: 200},
+		{in: ".05", want: 5},
+		{in: " 3.10 ", want: 310},
+		{in: "1.250", want: 125},
+		{in: "1.255", err: true},
+		{in: "", err: true},
+		{in: "-", err: true},
+		{in: "abc", err: true},
+		{in: "1.x", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseMoney(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 5, want: "0.05"},
+		{in: 150, want: "1.50"},
+		{in: -150, want: "-1.50"},
+		{in: -5, want: "-0.05"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMul(t *testing.T) {
+	tests := []struct {
+		m    Money
+		n    int32
+		want Money
+	}{
+		{m: 250, n: 3, want: 750},
+		{m: 199, n: -2, want: -398},
+		{m: 199, n: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.m.Mul(tt.n); got != tt.want {
+			t.Errorf("%v.Mul(%d) = %v, want %v", tt.m, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+		out  string
+	}{
+		{in: `1.5`, want: 150, out: `1.50`},
+		{in: `"2.05"`, want: 205, out: `2.05`},
+		{in: `-0.1`, want: -10, out: `-0.10`},
+	}
+
+	for _, tt := range tests {
+		var m Money
+		if err := json.Unmarshal([]byte(tt.in), &m); err != nil || m != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", tt.in, m, err, tt.want)
+			continue
+		}
+		out, err := json.Marshal(m)
+		if err != nil || string(out) != tt.out {
+			t.Errorf("Marshal(%v) = %s, %v, want %s", m, out, err, tt.out)
+		}
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`1.999`), &m); err == nil {
+		t.Errorf("Unmarshal(1.999) = %v, want an error", m)
+	}
+}
+
+func TestNullMoneyJSON(t *testing.T) {
+	var n NullMoney
+	if err := json.Unmarshal([]byte(`null`), &n); err != nil || n.Valid {
+		t.Errorf("Unmarshal(null) = %+v, %v, want invalid", n, err)
+	}
+	if out, _ := json.Marshal(n); string(out) != "null" {
+		t.Errorf("Marshal(invalid) = %s, want null", out)
+	}
+
+	if err := json.Unmarshal([]byte(`3.20`), &n); err != nil || !n.Valid || n.Money != 320 {
+		t.Errorf("Unmarshal(3.20) = %+v, %v, want 3.20", n, err)
+	}
+	if out, _ := json.Marshal(n); string(out) != "3.20" {
+		t.Errorf("Marshal(3.20) = %s, want 3.20", out)
+	}
+}
+
+func TestMoneyScanValue(t *testing.T) {
+	tests := []struct {
+		src  interface{}
+		want Money
+	}{
+		{src: []byte("12.34"), want: 1234},
+		{src: "-0.50", want: -50},
+		{src: int64(7), want: 700},
+		{src: float64(1.1), want: 110},
+	}
+
+	for _, tt := range tests {
+		var m Money
+		if err := m.Scan(tt.src); err != nil || m != tt.want {
+			t.Errorf("Scan(%#v) = %v, %v, want %v", tt.src, m, err, tt.want)
+			continue
+		}
+
+		value, err := m.Value()
+		if err != nil {
+			t.Errorf("Value() of %v failed: %v", m, err)
+			continue
+		}
+		var back Money
+		if err := back.Scan(value); err != nil || back != m {
+			t.Errorf("Scan(Value()) of %v = %v, %v", m, back, err)
+		}
+	}
+
+	var m Money
+	if err := m.Scan(true); err == nil {
+		t.Errorf("Scan(true) = %v, want an error", m)
+	}
+}
+
+func TestNullMoneyScanValue(t *testing.T) {
+	var n NullMoney
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Scan(nil) = %+v, %v, want invalid", n, err)
+	}
+	if value, err := n.Value(); err != nil || value != nil {
+		t.Errorf("Value() of invalid = %v, %v, want nil", value, err)
+	}
+
+	if err := n.Scan([]byte("4.00")); err != nil || !n.Valid || n.Money != 400 {
+		t.Errorf("Scan(4.00) = %+v, %v, want 4.00", n, err)
+	}
+	if value, err := n.Value(); err != nil || value != "4.00" {
+		t.Errorf("Value() of 4.00 = %v, %v, want 4.00", value, err)
+	}
+}
@@ -0,0 +1,117 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/guregu/null/v5"
+)
+
+// savaPageClient is shared by all SavaPage requests. The timeout makes sure a
+// hanging SavaPage cannot block a request or the payment worker forever.
+var savaPageClient = &http.Client{Timeout: 15 * time.Second}
+
+// ErrSavaPageNotApplied marks errors after which SavaPage certainly did not
+// apply an adjustment, because the request never reached it or was rejected
+var ErrSavaPageNotApplied = errors.New("SavaPage did not apply the adjustment")
+
+type savaPageResponse struct {
+	Success bool        `json:"succes"` // Note the field name matches the typo in the SavaPage API ("succes" not "success")
+	Result  NullMoney   `json:"result,omitempty"`
+	Error   null.String `json:"error,omitempty"`
+}
+
+// GetSavaPageBalance retrieves the balance of the users SavaPage account
+func GetSavaPageBalance(username string) (Money, error) {
+	config, err := LoadConfig("../.")
+	if err != nil {
+		return 0, fmt.Errorf("Failed to load config: %v", err)
+	}
+
+	query := url.Values{"type": {"USER"}, "name": {username}}
+	req, err := http.NewRequest("GET", config.SavaPageUrl+"financial/account/balance?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to create HTTP request: %v", err)
+	}
+
+	req.SetBasicAuth(config.SavaPageAdmin, config.SavaPagePassword)
+
+	resp, err := savaPageClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Failed to reach SavaPage, status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("Error reading response body: %v", err)
+	}
+
+	var response savaPageResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, fmt.Errorf("Error decoding JSON response: %v", err)
+	}
+
+	if !response.Success {
+		return 0, fmt.Errorf("SavaPage returned unsuccessful response")
+	}
+
+	return response.Result.Money, nil
+}
+
+// AdjustSavaPageBalance adds the amount to the users SavaPage account, a
+// negative amount is a debit. The details are stored with the adjustment in
+// SavaPage, which makes it possible to find it again during reconciliation.
+// Errors wrap ErrSavaPageNotApplied only if SavaPage certainly did not apply
+// the adjustment, after any other error it may have.
+func AdjustSavaPageBalance(username string, amount Money, details string) error {
+	config, err := LoadConfig("../.")
+	if err != nil {
+		return fmt.Errorf("%w: failed to load config: %v", ErrSavaPageNotApplied, err)
+	}
+
+	query := url.Values{
+		"type":    {"USER"},
+		"name":    {username},
+		"amount":  {amount.String()},
+		"adjust":  {"true"},
+		"details": {details},
+	}
+	req, err := http.NewRequest("POST", config.SavaPageUrl+"financial/account/balance?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create HTTP request: %v", ErrSavaPageNotApplied, err)
+	}
+
+	req.SetBasicAuth(config.SavaPageAdmin, config.SavaPagePassword)
+
+	resp, err := savaPageClient.Do(req)
+	if err != nil {
+		// only a failed dial is sure to have sent nothing
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return fmt.Errorf("%w: failed to connect: %v", ErrSavaPageNotApplied, err)
+		}
+		return fmt.Errorf("Failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// a client error is a rejection, a server error may come after the adjustment was applied
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return fmt.Errorf("%w: status code: %d", ErrSavaPageNotApplied, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to reach SavaPage, status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
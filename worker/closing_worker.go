@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+)
+
+// ClosingWorker closes the business day every day at a fixed time of day
+type ClosingWorker struct {
+	store *db.Store
+	at    time.Duration // since midnight
+}
+
+// NewClosingWorker parses the time of day in the form 15:04
+func NewClosingWorker(store *db.Store, at string) (*ClosingWorker, error) {
+	parsed, err := time.Parse("15:04", at)
+	if err != nil {
+		return nil, fmt.Errorf("invalid closing time %q: %w", at, err)
+	}
+	return &ClosingWorker{store, time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute}, nil
+}
+
+// Start closes the days in the background until the context is cancelled
+func (w *ClosingWorker) Start(ctx context.Context) {
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(w.next(time.Now())))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				w.close(ctx)
+			}
+		}
+	}()
+}
+
+// next returns the next closing time after now in local time
+func (w *ClosingWorker) next(now time.Time) time.Time {
+	year, month, day := now.Date()
+	next := time.Date(year, month, day, 0, 0, 0, 0, now.Location()).Add(w.at)
+	if !next.After(now) {
+		next = time.Date(year, month, day+1, 0, 0, 0, 0, now.Location()).Add(w.at)
+	}
+	return next
+}
+
+func (w *ClosingWorker) close(ctx context.Context) {
+	report, err := w.store.CloseDayTx(ctx, time.Now())
+	if err != nil {
+		// the day was closed by hand already
+		if errors.Is(err, db.ErrPeriodClosed) {
+			return
+		}
+		log.Printf("closing worker: failed to close the day: %v", err)
+		return
+	}
+	log.Printf("closing worker: closed the day with %d transactions and a revenue of %s", report.Transactions, report.Revenue)
+}
@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/KevinGruber2001/rupay-bar-backend/payment"
+	"github.com/KevinGruber2001/rupay-bar-backend/util"
+	"github.com/guregu/null/v5"
+)
+
+const (
+	// maxPaymentAttempts after which a payment is given up and its transaction marked as failed
+	maxPaymentAttempts = 8
+	// paymentBatchSize is the number of outbox entries processed per run
+	paymentBatchSize = 20
+)
+
+// PaymentWorker applies the pending balance adjustments of the payment outbox
+// with the payment backend of each entry
+type PaymentWorker struct {
+	store    *db.Store
+	payments *payment.Registry
+	interval time.Duration
+}
+
+func NewPaymentWorker(store *db.Store, payments *payment.Registry, interval time.Duration) *PaymentWorker {
+	return &PaymentWorker{store, payments, interval}
+}
+
+// Start processes the outbox in the background until the context is cancelled
+func (w *PaymentWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.processDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (w *PaymentWorker) processDue(ctx context.Context) {
+	entries, err := w.store.GetDuePaymentOutbox(ctx, paymentBatchSize)
+	if err != nil {
+		log.Printf("payment worker: failed to load outbox: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		w.process(ctx, entry)
+	}
+}
+
+func (w *PaymentWorker) process(ctx context.Context, entry db.PaymentOutbox) {
+	// a reversal pays back a share of a failed split bill, once it went
+	// through the share counts as failed
+	succeeded, failed := db.TransactionStatusCharged, db.TransactionStatusFailed
+	if entry.Reversal {
+		succeeded, failed = db.TransactionStatusFailed, db.TransactionStatusCharged
+	}
+
+	err := w.apply(ctx, entry)
+	if err == nil {
+		w.complete(ctx, entry, succeeded, w.balance(ctx, entry))
+		return
+	}
+
+	// retrying can not fix a missing backend or an empty wallet
+	if errors.Is(err, payment.ErrUnknownBackend) || errors.Is(err, payment.ErrInsufficientFunds) {
+		log.Printf("payment worker: giving up on %s: %v", entry.Uuid, err)
+		w.complete(ctx, entry, failed, util.NullMoney{})
+		return
+	}
+
+	// once the request reached SavaPage we cannot tell whether it applied the
+	// adjustment, retrying could charge twice. Only errors the backend marks
+	// as not applied are retried, all others wait for a manual
+	// reconciliation using the details marker.
+	ambiguous := !errors.Is(err, payment.ErrNotApplied)
+
+	attempt, recordErr := w.store.RecordPaymentOutboxAttempt(ctx, db.RecordPaymentOutboxAttemptParams{
+		LastError:           null.StringFrom(err.Error()),
+		NeedsReconciliation: ambiguous,
+		NextAttemptAt:       time.Now().Add(backoff(entry.Attempts + 1)),
+		Uuid:                entry.Uuid,
+	})
+	if recordErr != nil {
+		log.Printf("payment worker: failed to record attempt of %s: %v", entry.Uuid, recordErr)
+		return
+	}
+
+	if !ambiguous && attempt.Attempts >= maxPaymentAttempts {
+		w.complete(ctx, entry, failed, util.NullMoney{})
+	}
+}
+
+// apply debits negative and credits positive amounts
+func (w *PaymentWorker) apply(ctx context.Context, entry db.PaymentOutbox) error {
+	backend, err := w.payments.Get(entry.Backend)
+	if err != nil {
+		return err
+	}
+
+	if entry.Amount < 0 {
+		return backend.Debit(ctx, entry.Username.String, -entry.Amount, entry.Details)
+	}
+	return backend.Credit(ctx, entry.Username.String, entry.Amount, entry.Details)
+}
+
+// balance returns the balance of the account after an applied entry, it is
+// only printed on receipts so a failed lookup does not fail the payment
+func (w *PaymentWorker) balance(ctx context.Context, entry db.PaymentOutbox) util.NullMoney {
+	if !entry.Username.Valid {
+		return util.NullMoney{}
+	}
+
+	backend, err := w.payments.Get(entry.Backend)
+	if err != nil {
+		return util.NullMoney{}
+	}
+
+	balance, err := backend.Balance(ctx, entry.Username.String)
+	if err != nil {
+		if !errors.Is(err, payment.ErrNoBalance) {
+			log.Printf("payment worker: failed to load the balance after %s: %v", entry.Uuid, err)
+		}
+		return util.NullMoney{}
+	}
+	return util.NullMoneyFrom(balance)
+}
+
+func (w *PaymentWorker) complete(ctx context.Context, entry db.PaymentOutbox, status string, balanceAfter util.NullMoney) {
+	_, err := w.store.CompletePaymentTx(ctx, db.CompletePaymentTxParams{
+		OutboxUuid:      entry.Uuid,
+		TransactionUuid: entry.TransactionUuid,
+		Status:          status,
+		BalanceAfter:    balanceAfter,
+	})
+	if err != nil {
+		log.Printf("payment worker: failed to complete %s as %s: %v", entry.Uuid, status, err)
+	}
+}
+
+// backoff doubles the delay with every attempt, capped at ten minutes
+func backoff(attempt int32) time.Duration {
+	delay := time.Second << attempt
+	if attempt > 10 || delay > 10*time.Minute {
+		return 10 * time.Minute
+	}
+	return delay
+}
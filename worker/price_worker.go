@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// PriceWorker applies scheduled price changes to the articles once they are
+// due. Sales resolve prices from the price history anyway, the worker keeps
+// the prices of the articles themselves up to date.
+type PriceWorker struct {
+	store    *db.Store
+	interval time.Duration
+}
+
+func NewPriceWorker(store *db.Store, interval time.Duration) *PriceWorker {
+	return &PriceWorker{store, interval}
+}
+
+// Start applies due prices in the background until the context is cancelled
+func (w *PriceWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.applyDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (w *PriceWorker) applyDue(ctx context.Context) {
+	articles, err := w.store.ApplyArticlePrices(ctx, db.ApplyArticlePricesParams{At: time.Now(), ArticleUuid: uuid.NullUUID{}})
+	if err != nil {
+		log.Printf("price worker: failed to apply prices: %v", err)
+		return
+	}
+
+	for _, article := range articles {
+		log.Printf("price worker: applied the scheduled price of %s", article)
+	}
+}
@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/KevinGruber2001/rupay-bar-backend/alert"
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+	"github.com/guregu/null/v5"
+)
+
+const (
+	// maxStockAlertAttempts after which the delivery of an alert is given up
+	maxStockAlertAttempts = 8
+	// stockAlertBatchSize is the number of alerts delivered per run
+	stockAlertBatchSize = 20
+)
+
+// StockAlertWorker delivers the queued low stock alerts to all channels. A
+// channel that failed is retried with a backoff, channels that received
+// the alert already are skipped.
+type StockAlertWorker struct {
+	store    *db.Store
+	channels []alert.Channel
+	interval time.Duration
+}
+
+func NewStockAlertWorker(store *db.Store, channels []alert.Channel, interval time.Duration) *StockAlertWorker {
+	return &StockAlertWorker{store, channels, interval}
+}
+
+// Start delivers the alerts in the background until the context is cancelled
+func (w *StockAlertWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.processDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (w *StockAlertWorker) processDue(ctx context.Context) {
+	alerts, err := w.store.GetDueStockAlerts(ctx, stockAlertBatchSize)
+	if err != nil {
+		log.Printf("stock alert worker: failed to load alerts: %v", err)
+		return
+	}
+
+	for _, entry := range alerts {
+		w.process(ctx, entry)
+	}
+}
+
+func (w *StockAlertWorker) process(ctx context.Context, entry db.StockAlert) {
+	err := w.deliver(ctx, entry)
+	if err == nil {
+		w.complete(ctx, entry)
+		return
+	}
+
+	attempt, recordErr := w.store.RecordStockAlertAttempt(ctx, db.RecordStockAlertAttemptParams{
+		LastError:     null.StringFrom(err.Error()),
+		NextAttemptAt: time.Now().Add(backoff(entry.Attempts + 1)),
+		Uuid:          entry.Uuid,
+	})
+	if recordErr != nil {
+		log.Printf("stock alert worker: failed to record attempt of %s: %v", entry.Uuid, recordErr)
+		return
+	}
+
+	if attempt.Attempts >= maxStockAlertAttempts {
+		log.Printf("stock alert worker: giving up on %s: %v", entry.Uuid, err)
+		w.complete(ctx, entry)
+	}
+}
+
+// deliver sends the alert to every channel that did not receive it yet
+func (w *StockAlertWorker) deliver(ctx context.Context, entry db.StockAlert) error {
+	article, err := w.store.GetArticleById(ctx, entry.ArticleUuid)
+	if err != nil {
+		// the alert is deleted together with its article
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	delivered, err := w.store.GetStockAlertDeliveries(ctx, entry.Uuid)
+	if err != nil {
+		return err
+	}
+	done := make(map[string]bool, len(delivered))
+	for _, channel := range delivered {
+		done[channel] = true
+	}
+
+	message := alert.Alert{
+		Uuid:             entry.Uuid,
+		ArticleUuid:      entry.ArticleUuid,
+		Article:          article.Name,
+		Stock:            entry.Stock,
+		ReorderThreshold: entry.ReorderThreshold,
+		CreatedAt:        entry.CreatedAt,
+	}
+
+	var errs []error
+	for _, channel := range w.channels {
+		if done[channel.Name()] {
+			continue
+		}
+
+		if err := channel.Send(ctx, message); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		err := w.store.CreateStockAlertDelivery(ctx, db.CreateStockAlertDeliveryParams{AlertUuid: entry.Uuid, Channel: channel.Name()})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *StockAlertWorker) complete(ctx context.Context, entry db.StockAlert) {
+	if err := w.store.MarkStockAlertProcessed(ctx, entry.Uuid); err != nil {
+		log.Printf("stock alert worker: failed to complete %s: %v", entry.Uuid, err)
+	}
+}
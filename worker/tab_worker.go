@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/KevinGruber2001/rupay-bar-backend/db/sqlc"
+)
+
+// TabWorker closes the tabs that are still open after their event ended.
+// Tabs that can not be closed are flagged for the bar staff.
+type TabWorker struct {
+	store    *db.Store
+	interval time.Duration
+}
+
+func NewTabWorker(store *db.Store, interval time.Duration) *TabWorker {
+	return &TabWorker{store, interval}
+}
+
+// Start closes tabs in the background until the context is cancelled
+func (w *TabWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.closeEnded(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (w *TabWorker) closeEnded(ctx context.Context) {
+	tabs, err := w.store.GetOpenTabsOfEndedEvents(ctx)
+	if err != nil {
+		log.Printf("tab worker: failed to load tabs: %v", err)
+		return
+	}
+
+	for _, tab := range tabs {
+		if err := w.close(ctx, tab); err != nil {
+			log.Printf("tab worker: failed to close %s, flagging it: %v", tab.Uuid, err)
+			if _, err := w.store.FlagTab(ctx, tab.Uuid); err != nil {
+				log.Printf("tab worker: failed to flag %s: %v", tab.Uuid, err)
+			}
+		}
+	}
+}
+
+// close settles the tab now, the transaction still belongs to the event of
+// the tab. It is charged now, so it is not dated back into a business day
+// that may be closed already.
+func (w *TabWorker) close(ctx context.Context, tab db.Tab) error {
+	_, err := w.store.CloseTabTx(ctx, db.CloseTabTxParams{TabUuid: tab.Uuid, Date: time.Now()})
+	return err
+}